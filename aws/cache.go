@@ -24,7 +24,7 @@ func cacheAPIGatewayRestApis(ctx context.Context, a *aws, rt string, filters *fi
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -66,7 +66,7 @@ func cacheLoadBalancersV2(ctx context.Context, a *aws, rt string, filters *filte
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -108,7 +108,7 @@ func cacheLoadBalancersV2Listeners(ctx context.Context, a *aws, rt string, filte
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -145,7 +145,7 @@ func cacheIAMGroups(ctx context.Context, a *aws, rt string, filters *filter.Filt
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -182,7 +182,7 @@ func cacheIAMRoles(ctx context.Context, a *aws, rt string, filters *filter.Filte
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -219,7 +219,7 @@ func cacheIAMUsers(ctx context.Context, a *aws, rt string, filters *filter.Filte
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -256,7 +256,7 @@ func cacheRoute53Zones(ctx context.Context, a *aws, rt string, filters *filter.F
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -293,7 +293,7 @@ func cacheSESDomainIdentities(ctx context.Context, a *aws, rt string, filters *f
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -330,7 +330,7 @@ func cacheECSClusters(ctx context.Context, a *aws, rt string, filters *filter.Fi
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -366,7 +366,7 @@ func cacheGlueDatabases(ctx context.Context, a *aws, rt string, filters *filter.
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -401,7 +401,7 @@ func cacheTransitGatewayRouteTables(ctx context.Context, a *aws, rt string, filt
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
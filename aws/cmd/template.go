@@ -61,9 +61,11 @@ const (
 				input.{{.FilterByOwner}} = append(input.{{.FilterByOwner}}, c.accountID)
 			{{ end -}}
 
+			c.svc.mu.Lock()
 			if c.svc.{{.Service}} == nil {
 				c.svc.{{.Service}} = {{.Service}}.New(c.svc.session)
 			}
+			c.svc.mu.Unlock()
 
 			{{ if .HasNoSlice }}
 				var opt {{ .Output }}
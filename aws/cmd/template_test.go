@@ -148,9 +148,11 @@ func TestTemplateExecute(t *testing.T) {
 			},
 			opt: `
 			func (c *connector) Signature {
+				c.svc.mu.Lock()
 				if c.svc.Service == nil {
 					c.svc.Service = Service.New(c.svc.session)
 				}
+				c.svc.mu.Unlock()
 
 				opt := make([]*Service.Entity, 0)
 
@@ -192,9 +194,11 @@ func TestTemplateExecute(t *testing.T) {
 				}
 				input.OwnerField = append(input.OwnerField, c.accountID)
 
+				c.svc.mu.Lock()
 				if c.svc.Service == nil {
 					c.svc.Service = Service.New(c.svc.session)
 				}
+				c.svc.mu.Unlock()
 
 				opt := make([]*Service.Entity, 0)
 
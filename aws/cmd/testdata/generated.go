@@ -44,9 +44,11 @@ type Reader interface {
 }
 
 func (c *connector) GetInstances(ctx context.Context, input *ec2.DescribeInstancesInput) ([]*ec2.Instance, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.Instance, 0)
 
@@ -4,14 +4,21 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/defaults"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/cycloidio/terracognita/aws/reader"
 	"github.com/cycloidio/terracognita/cache"
 	"github.com/cycloidio/terracognita/errcode"
 	"github.com/cycloidio/terracognita/filter"
 	"github.com/cycloidio/terracognita/log"
 	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/util"
+	awsbase "github.com/hashicorp/aws-sdk-go-base/v2"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-cty/cty/gocty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -34,30 +41,193 @@ var skippableCodes = map[string]struct{}{
 	"RequestError":          struct{}{},
 }
 
+// globalTypePrefixes are the prefixes of the resource types
+// of the AWS services that are not regional, so they are
+// the same on all the regions
+var globalTypePrefixes = []string{"aws_iam_", "aws_route53_", "aws_cloudfront_"}
+
+// regionalTypePrefixes are the exceptions to the
+// globalTypePrefixes as they are regional
+var regionalTypePrefixes = []string{"aws_route53_resolver_"}
+
+// isGlobalType checks if the resource type t
+// is of one of the global services
+func isGlobalType(t string) bool {
+	for _, p := range regionalTypePrefixes {
+		if strings.HasPrefix(t, p) {
+			return false
+		}
+	}
+	for _, p := range globalTypePrefixes {
+		if strings.HasPrefix(t, p) {
+			return true
+		}
+	}
+	return false
+}
+
 type aws struct {
 	awsr reader.Reader
 
+	// alias is the alias of the provider when
+	// importing from more than one region
+	alias string
+
+	// global defines if the resources of the
+	// global services are read or not
+	global bool
+
 	tfAWSClient interface{}
 	tfProvider  *schema.Provider
+	grpcClient  *provider.GRPCClient
 
 	configuration map[string]interface{}
 
 	cache cache.Cache
 }
 
+// Options are the options to initialize
+// an AWS Provider with NewProviderWithOptions
+type Options struct {
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+	Region       string
+
+	// Profile and SharedCredentialsFile are used when the
+	// AccessKey and SecretKey are not set, as the credentials
+	// are then read from the chain of the AWS SDK
+	Profile               string
+	SharedCredentialsFile string
+
+	// RoleARN is the role to assume with the credentials, with the
+	// ExternalID and MFASerial if needed, the MFA token is read from
+	// the stdin. The RoleARN and ExternalID are set on the 'provider' block
+	RoleARN    string
+	ExternalID string
+	MFASerial  string
+
+	// Credentials are the already resolved credentials of the options,
+	// if set they are used instead of resolving them again so the
+	// providers of the same account share them (ex: the MFA is only asked once)
+	Credentials *credentials.Credentials
+
+	// Alias of the provider so it can be used along with
+	// other ones, like the ones of other regions
+	Alias string
+
+	// SkipGlobal skips the resources of the global services
+	// (IAM, Route53, CloudFront), as when importing from
+	// more than one region they are only read from one
+	SkipGlobal bool
+
+	// RateLimiter limits the requests to each AWS service, it can
+	// be shared by the providers of the same account. If it's nil
+	// one with the RateLimitsDefault is used
+	RateLimiter *util.RateLimiter
+}
+
+// RateLimitsDefault are the requests per second to the AWS services
+// with lower limits, which are shared by all the regions of the account
+var RateLimitsDefault = map[string]float64{
+	"iam":        5,
+	"route53":    3,
+	"cloudfront": 5,
+}
+
+// NewCredentials returns the credentials of the opts, which
+// can be set on the Options.Credentials to share them
+func NewCredentials(opts Options) (*credentials.Credentials, error) {
+	return opts.credentials()
+}
+
+// credentials returns the credentials of the o
+func (o Options) credentials() (*credentials.Credentials, error) {
+	if o.Credentials != nil {
+		return o.Credentials, nil
+	}
+	return reader.NewCredentials(reader.CredentialsOptions{
+		AccessKey:             o.AccessKey,
+		SecretKey:             o.SecretKey,
+		SessionToken:          o.SessionToken,
+		Profile:               o.Profile,
+		SharedCredentialsFile: o.SharedCredentialsFile,
+		RoleARN:               o.RoleARN,
+		ExternalID:            o.ExternalID,
+		MFASerial:             o.MFASerial,
+		Region:                o.Region,
+	})
+}
+
 // NewProvider returns an AWS Provider
 func NewProvider(ctx context.Context, accessKey, secretKey, region, sessionToken string) (provider.Provider, error) {
+	return NewProviderWithOptions(ctx, Options{
+		AccessKey:    accessKey,
+		SecretKey:    secretKey,
+		SessionToken: sessionToken,
+		Region:       region,
+	})
+}
+
+// RegionAlias returns the alias of the provider
+// of the region r (ex: eu-west-1 is eu_west_1)
+func RegionAlias(r string) string {
+	return strings.ReplaceAll(r, "-", "_")
+}
+
+// Regions returns the regions enabled on the account of the opts
+func Regions(ctx context.Context, opts Options) ([]string, error) {
+	creds, err := opts.credentials()
+	if err != nil {
+		return nil, err
+	}
+	return reader.Regions(ctx, creds, opts.Region)
+}
+
+// Accounts returns the accounts of the Organization of the account of the opts
+func Accounts(ctx context.Context, opts Options) ([]string, error) {
+	creds, err := opts.credentials()
+	if err != nil {
+		return nil, err
+	}
+	return reader.Accounts(ctx, creds, opts.Region)
+}
+
+// NewProviderWithOptions returns an AWS Provider initialized with the opts
+func NewProviderWithOptions(ctx context.Context, opts Options) (provider.Provider, error) {
+	creds, err := opts.credentials()
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize the credentials because: %s", err)
+	}
+
+	rl := opts.RateLimiter
+	if rl == nil {
+		rl = util.NewRateLimiter(RateLimitsDefault, nil)
+	}
+
 	log.Get().Log("func", "reader.New", "msg", "configuring aws Reader")
-	awsr, err := reader.New(ctx, accessKey, secretKey, region, sessionToken, nil)
+	awsr, err := reader.New(ctx, creds, opts.Region, nil, rl)
 	if err != nil {
 		return nil, fmt.Errorf("could not initialize 'reader' because: %s", err)
 	}
 
-	cfg := conns.Config{
-		AccessKey: accessKey,
-		SecretKey: secretKey,
-		Region:    region,
-		Token:     sessionToken,
+	cfg, err := tfConfig(opts, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	configuration := map[string]interface{}{
+		"region": opts.Region,
+	}
+
+	if opts.RoleARN != "" {
+		assumeRole := map[string]interface{}{
+			"role_arn": opts.RoleARN,
+		}
+		if opts.ExternalID != "" {
+			assumeRole["external_id"] = opts.ExternalID
+		}
+		configuration["assume_role"] = assumeRole
 	}
 
 	log.Get().Log("func", "aws.NewProvider", "msg", "configuring TF Client")
@@ -74,18 +244,70 @@ func NewProvider(ctx context.Context, accessKey, secretKey, region, sessionToken
 	tfp.SetMeta(awsClient)
 
 	return &aws{
-		awsr:        awsr,
-		tfAWSClient: awsClient,
-		tfProvider:  tfp,
-		cache:       cache.New(),
-		configuration: map[string]interface{}{
-			"region": region,
-		},
+		awsr:          awsr,
+		alias:         opts.Alias,
+		global:        !opts.SkipGlobal,
+		tfAWSClient:   awsClient,
+		tfProvider:    tfp,
+		grpcClient:    provider.NewGRPCClient(tfp),
+		cache:         cache.New(),
+		configuration: configuration,
 	}, nil
 }
 
+// tfConfig returns the configuration of the TF Client with the same
+// credentials of the opts, so it can refresh them on long imports.
+// As it can not ask for the MFA token, with a MFASerial the creds
+// of the reader are used, which are valid for the session duration
+func tfConfig(opts Options, creds *credentials.Credentials) (conns.Config, error) {
+	cfg := conns.Config{
+		Region: opts.Region,
+	}
+
+	if opts.MFASerial != "" {
+		value, err := creds.Get()
+		if err != nil {
+			return cfg, fmt.Errorf("could not get the credentials because: %s", err)
+		}
+		cfg.AccessKey = value.AccessKeyID
+		cfg.SecretKey = value.SecretAccessKey
+		cfg.Token = value.SessionToken
+		return cfg, nil
+	}
+
+	cfg.AccessKey = opts.AccessKey
+	cfg.SecretKey = opts.SecretKey
+	cfg.Token = opts.SessionToken
+	cfg.Profile = opts.Profile
+
+	if opts.SharedCredentialsFile != "" {
+		cfg.SharedCredentialsFiles = []string{opts.SharedCredentialsFile}
+		cfg.SharedConfigFiles = []string{opts.SharedCredentialsFile, defaults.SharedConfigFilename()}
+	}
+
+	if opts.RoleARN != "" {
+		cfg.AssumeRole = &awsbase.AssumeRole{
+			RoleARN:    opts.RoleARN,
+			ExternalID: opts.ExternalID,
+			Duration:   time.Hour,
+		}
+	}
+
+	return cfg, nil
+}
+
 func (a *aws) ResourceTypes() []string {
-	return ResourceTypeStrings()
+	if a.global {
+		return ResourceTypeStrings()
+	}
+
+	var types []string
+	for _, t := range ResourceTypeStrings() {
+		if !isGlobalType(t) {
+			types = append(types, t)
+		}
+	}
+	return types
 }
 
 func (a *aws) Resources(ctx context.Context, t string, f *filter.Filter) ([]provider.Resource, error) {
@@ -99,6 +321,12 @@ func (a *aws) Resources(ctx context.Context, t string, f *filter.Filter) ([]prov
 		return nil, errors.Errorf("the resource %q it's not implemented", t)
 	}
 
+	// They are read from the provider of
+	// the region that has the global ones
+	if !a.global && isGlobalType(t) {
+		return nil, nil
+	}
+
 	resources, err := rfn(ctx, a, t, f)
 	if err != nil {
 		// we filter the error from AWS and return a custom error
@@ -122,8 +350,34 @@ func (a *aws) TFProvider() *schema.Provider {
 	return a.tfProvider
 }
 
+func (a *aws) GRPCClient() *provider.GRPCClient {
+	return a.grpcClient
+}
+
 func (a *aws) String() string { return "aws" }
 
+func (a *aws) Alias() string { return a.alias }
+
+// NameKey returns the 'Name' tag for the
+// resource types without a 'name' attribute
+func (a *aws) NameKey(t string) string {
+	if isNamedByTag(a.tfProvider, t) {
+		return "tags.Name"
+	}
+	return "name"
+}
+
+// isNamedByTag checks if the name of the resources of type t is
+// the 'Name' tag, as the type has no 'name' attribute (ex: aws_instance)
+func isNamedByTag(tfp *schema.Provider, t string) bool {
+	tfr, ok := tfp.ResourcesMap[t]
+	if !ok {
+		return true
+	}
+	_, ok = tfr.Schema["name"]
+	return !ok
+}
+
 func (a *aws) Region() string { return a.awsr.GetRegion() }
 func (a *aws) TagKey() string { return "tags" }
 func (a *aws) HasResourceType(t string) bool {
@@ -200,3 +454,23 @@ func (a *aws) FilterByTags(tags interface{}) error {
 	}
 	return nil
 }
+
+// Retryable retries the AWS errors that are throttling, temporary
+// or of expired credentials (which are refreshed on the next call)
+func (a *aws) Retryable(err error) (bool, time.Duration) {
+	var aerr awserr.Error
+	if !errors.As(err, &aerr) {
+		return false, 0
+	}
+
+	if request.IsErrorThrottle(aerr) || request.IsErrorExpiredCreds(aerr) {
+		return true, 0
+	}
+
+	var rerr awserr.RequestFailure
+	if errors.As(aerr, &rerr) && util.IsRetryableHTTPStatus(rerr.StatusCode()) {
+		return true, 0
+	}
+
+	return request.IsErrorRetryable(aerr), 0
+}
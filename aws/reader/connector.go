@@ -4,9 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/defaults"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
 	"github.com/aws/aws-sdk-go/service/athena/athenaiface"
@@ -40,6 +46,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/mediastore/mediastoreiface"
 	"github.com/aws/aws-sdk-go/service/mq/mqiface"
 	"github.com/aws/aws-sdk-go/service/neptune/neptuneiface"
+	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 	"github.com/aws/aws-sdk-go/service/redshift/redshiftiface"
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
@@ -55,6 +62,12 @@ import (
 
 //go:generate go run ../cmd/ -output reader.go
 
+// RateLimiter limits the requests made to each AWS service,
+// which is identified by its ServiceName (ex: iam)
+type RateLimiter interface {
+	Wait(ctx context.Context, service string) error
+}
+
 // New returns an object which also contains the accountID and the region to use.
 //
 // The accountID is helpful to return only the AMI or snapshots that belong to the account.
@@ -64,30 +77,164 @@ import (
 // An error is returned if any of the needed AWS request for creating the reader returns an AWS error, in such case it
 // will have any of the common error codes (see below) or EmptyStaticCreds code or a go standard error in case that no
 // regions are matched with the ones available, at the time, in AWS.
+// The creds can be initialized with NewCredentials and, if the rl is not nil,
+// each request to the AWS services, also the retries, waits for it.
 // See:
 //   - https://docs.aws.amazon.com/AWSEC2/latest/APIReference/errors-overview.html#CommonErrors
 //   - https://docs.aws.amazon.com/STS/latest/APIReference/CommonErrors.html
-func New(ctx context.Context, accessKey, secretKey, region, sessionToken string, config *aws.Config) (Reader, error) {
+func New(ctx context.Context, creds *credentials.Credentials, region string, config *aws.Config, rl RateLimiter) (Reader, error) {
 	var c = connector{}
 
-	creds, ec2s, sts, err := configureAWS(accessKey, secretKey, region, sessionToken)
+	sess, err := configureAWS(creds, region)
 	if err != nil {
 		return nil, err
 	}
 	c.creds = creds
-	if err := c.setAccountID(ctx, sts); err != nil {
+	if err := c.setAccountID(ctx, sts.New(sess)); err != nil {
 		return nil, err
 	}
 
-	if err = c.setRegion(ctx, ec2s, region); err != nil {
+	if err = c.setRegion(ctx, ec2.New(sess), region); err != nil {
 		return nil, err
 	}
 
-	c.setService(config)
+	c.setService(config, rl)
 
 	return &c, nil
 }
 
+// CredentialsOptions are the options
+// to initialize the NewCredentials
+type CredentialsOptions struct {
+	// AccessKey, SecretKey and SessionToken are static credentials, if
+	// they are not set the credentials chain of the AWS SDK is used: ENV,
+	// shared credentials and config files (with SSO, role_arn/source_profile
+	// and credential_process), web identity and ECS/EC2 roles
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+
+	// Profile and SharedCredentialsFile are used on
+	// the shared credentials of the chain
+	Profile               string
+	SharedCredentialsFile string
+
+	// RoleARN is the role to assume with the credentials, with the
+	// ExternalID and MFASerial if the role requires them
+	RoleARN    string
+	ExternalID string
+	MFASerial  string
+
+	// Region is used for the STS requests,
+	// if it's empty a default one is used
+	Region string
+}
+
+// NewCredentials returns the credentials of the opts, if the RoleARN is set they
+// are the ones of assuming the role, which are refreshed when they expire.
+// If the MFASerial, or a profile, requires an MFA token it's read from the stdin
+func NewCredentials(opts CredentialsOptions) (*credentials.Credentials, error) {
+	region := opts.Region
+	if region == "" {
+		region = defaultRegion
+	}
+
+	sopts := session.Options{
+		Config: aws.Config{
+			Region: aws.String(region),
+		},
+		Profile:                 opts.Profile,
+		SharedConfigState:       session.SharedConfigEnable,
+		AssumeRoleTokenProvider: stscreds.StdinTokenProvider,
+	}
+
+	if opts.AccessKey != "" || opts.SecretKey != "" {
+		sopts.Config.Credentials = credentials.NewStaticCredentials(opts.AccessKey, opts.SecretKey, opts.SessionToken)
+	}
+
+	if opts.SharedCredentialsFile != "" {
+		sopts.SharedConfigFiles = []string{opts.SharedCredentialsFile, defaults.SharedConfigFilename()}
+	}
+
+	sess, err := session.NewSessionWithOptions(sopts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.RoleARN == "" {
+		return sess.Config.Credentials, nil
+	}
+
+	return stscreds.NewCredentials(sess, opts.RoleARN, func(p *stscreds.AssumeRoleProvider) {
+		// The maximum when chaining roles, so the
+		// credentials are refreshed less often
+		p.Duration = time.Hour
+		if opts.ExternalID != "" {
+			p.ExternalID = aws.String(opts.ExternalID)
+		}
+		if opts.MFASerial != "" {
+			p.SerialNumber = aws.String(opts.MFASerial)
+			p.TokenProvider = stscreds.StdinTokenProvider
+		}
+	}), nil
+}
+
+// Regions returns the names, sorted, of the regions enabled on the account of
+// the creds. The region is the one used to make the request, if it's
+// empty a default one is used.
+// An AWS error can be returned with one of the common error codes.
+// See https://docs.aws.amazon.com/AWSEC2/latest/APIReference/errors-overview.html#CommonErrors
+func Regions(ctx context.Context, creds *credentials.Credentials, region string) ([]string, error) {
+	sess, err := configureAWS(creds, region)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without the AllRegions only the
+	// enabled ones are returned
+	resp, err := ec2.New(sess).DescribeRegionsWithContext(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	regions := make([]string, 0, len(resp.Regions))
+	for _, r := range resp.Regions {
+		regions = append(regions, aws.StringValue(r.RegionName))
+	}
+	sort.Strings(regions)
+
+	return regions, nil
+}
+
+// Accounts returns the IDs, sorted, of the active accounts of the Organization
+// of the account of the creds, which has to be the management account or a
+// delegated administrator. The region is the one used to make the request, if
+// it's empty a default one is used.
+// An AWS error can be returned with one of the common error codes.
+// See https://docs.aws.amazon.com/organizations/latest/APIReference/CommonErrors.html
+func Accounts(ctx context.Context, creds *credentials.Credentials, region string) ([]string, error) {
+	sess, err := configureAWS(creds, region)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []string
+	err = organizations.New(sess).ListAccountsPagesWithContext(ctx, &organizations.ListAccountsInput{}, func(o *organizations.ListAccountsOutput, lastPage bool) bool {
+		for _, a := range o.Accounts {
+			if aws.StringValue(a.Status) == organizations.AccountStatusActive {
+				accounts = append(accounts, aws.StringValue(a.Id))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(accounts)
+
+	return accounts, nil
+}
+
 // The connector provides easy access to AWS SDK calls.
 //
 // By using it, calls can be made directly through multiple regions, and will filter only data that belongs to you.
@@ -152,6 +299,10 @@ type serviceConnector struct {
 	session                  *session.Session
 	sqs                      sqsiface.SQSAPI
 	storagegateway           storagegatewayiface.StorageGatewayAPI
+
+	// mu protects the lazy initialization of the
+	// services as they can be requested concurrently
+	mu sync.Mutex
 }
 
 /* The default region is only used to (1) get the list of region and
@@ -163,20 +314,19 @@ type serviceConnector struct {
  */
 const defaultRegion string = "eu-west-1"
 
-// configureAWS creates a new static credential with the passed accessKey and
-// secretKey and with it, a sessions which is used to create a EC2 client and
-// a Security Token Service client.
-// The only AWS error code that this function return is
+// configureAWS checks the creds and creates with them a session
+// which is used to create the clients of the AWS services.
+// The AWS error codes that this function returns are
 // * EmptyStaticCreds
-func configureAWS(accessKey, secretKey, region, token string) (*credentials.Credentials, ec2iface.EC2API, stsiface.STSAPI, error) {
+// * The ones of STS if the creds have to assume a role
+func configureAWS(creds *credentials.Credentials, region string) (*session.Session, error) {
 	if region == "" {
 		region = defaultRegion
 	}
 
-	creds := credentials.NewStaticCredentials(accessKey, secretKey, token)
 	_, err := creds.Get()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
 	sess := session.Must(
 		session.NewSession(&aws.Config{
@@ -186,7 +336,7 @@ func configureAWS(accessKey, secretKey, region, token string) (*credentials.Cred
 			Credentials: creds,
 		}),
 	)
-	return creds, ec2.New(sess), sts.New(sess), nil
+	return sess, nil
 }
 
 // setAccountID retrieves the caller ID from the Security Token Service and set
@@ -231,7 +381,7 @@ func (c *connector) setRegion(ctx context.Context, ec2 ec2iface.EC2API, region s
 	return nil
 }
 
-func (c *connector) setService(config *aws.Config) {
+func (c *connector) setService(config *aws.Config, rl RateLimiter) {
 	if config != nil {
 		config.Credentials = c.creds
 	} else {
@@ -244,6 +394,13 @@ func (c *connector) setService(config *aws.Config) {
 
 	config.Region = aws.String(c.region)
 	sess := session.Must(session.NewSession(config))
+	if rl != nil {
+		sess.Handlers.Sign.PushFront(func(r *request.Request) {
+			if err := rl.Wait(r.Context(), r.ClientInfo.ServiceName); err != nil {
+				r.Error = err
+			}
+		})
+	}
 	svc := &serviceConnector{
 		region:  c.region,
 		session: sess,
@@ -13,9 +13,11 @@ func (c *connector) ListBuckets(ctx context.Context, input *s3.ListBucketsInput)
 	var errs []error
 	var ropt = &s3.ListBucketsOutput{}
 
+	c.svc.mu.Lock()
 	if c.svc.s3 == nil {
 		c.svc.s3 = s3.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt, err := c.svc.s3.ListBucketsWithContext(ctx, input)
 	if err != nil {
@@ -608,9 +608,11 @@ type Reader interface {
 }
 
 func (c *connector) GetAPIGatewayDeployments(ctx context.Context, input *apigateway.GetDeploymentsInput) ([]*apigateway.Deployment, error) {
+	c.svc.mu.Lock()
 	if c.svc.apigateway == nil {
 		c.svc.apigateway = apigateway.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*apigateway.Deployment, 0)
 
@@ -639,9 +641,11 @@ func (c *connector) GetAPIGatewayDeployments(ctx context.Context, input *apigate
 }
 
 func (c *connector) GetAPIGatewayResources(ctx context.Context, input *apigateway.GetResourcesInput) ([]*apigateway.Resource, error) {
+	c.svc.mu.Lock()
 	if c.svc.apigateway == nil {
 		c.svc.apigateway = apigateway.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*apigateway.Resource, 0)
 
@@ -670,9 +674,11 @@ func (c *connector) GetAPIGatewayResources(ctx context.Context, input *apigatewa
 }
 
 func (c *connector) GetAPIGatewayRestAPIs(ctx context.Context, input *apigateway.GetRestApisInput) ([]*apigateway.RestApi, error) {
+	c.svc.mu.Lock()
 	if c.svc.apigateway == nil {
 		c.svc.apigateway = apigateway.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*apigateway.RestApi, 0)
 
@@ -701,9 +707,11 @@ func (c *connector) GetAPIGatewayRestAPIs(ctx context.Context, input *apigateway
 }
 
 func (c *connector) GetAPIGatewayStages(ctx context.Context, input *apigateway.GetStagesInput) ([]*apigateway.Stage, error) {
+	c.svc.mu.Lock()
 	if c.svc.apigateway == nil {
 		c.svc.apigateway = apigateway.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*apigateway.Stage, 0)
 
@@ -728,9 +736,11 @@ func (c *connector) GetAPIGatewayStages(ctx context.Context, input *apigateway.G
 }
 
 func (c *connector) GetAthenaWorkGroups(ctx context.Context, input *athena.ListWorkGroupsInput) ([]*athena.WorkGroupSummary, error) {
+	c.svc.mu.Lock()
 	if c.svc.athena == nil {
 		c.svc.athena = athena.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*athena.WorkGroupSummary, 0)
 
@@ -759,9 +769,11 @@ func (c *connector) GetAthenaWorkGroups(ctx context.Context, input *athena.ListW
 }
 
 func (c *connector) GetAutoScalingGroups(ctx context.Context, input *autoscaling.DescribeAutoScalingGroupsInput) ([]*autoscaling.Group, error) {
+	c.svc.mu.Lock()
 	if c.svc.autoscaling == nil {
 		c.svc.autoscaling = autoscaling.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*autoscaling.Group, 0)
 
@@ -790,9 +802,11 @@ func (c *connector) GetAutoScalingGroups(ctx context.Context, input *autoscaling
 }
 
 func (c *connector) GetLaunchConfigurations(ctx context.Context, input *autoscaling.DescribeLaunchConfigurationsInput) ([]*autoscaling.LaunchConfiguration, error) {
+	c.svc.mu.Lock()
 	if c.svc.autoscaling == nil {
 		c.svc.autoscaling = autoscaling.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*autoscaling.LaunchConfiguration, 0)
 
@@ -821,9 +835,11 @@ func (c *connector) GetLaunchConfigurations(ctx context.Context, input *autoscal
 }
 
 func (c *connector) GetAutoScalingPolicies(ctx context.Context, input *autoscaling.DescribePoliciesInput) ([]*autoscaling.ScalingPolicy, error) {
+	c.svc.mu.Lock()
 	if c.svc.autoscaling == nil {
 		c.svc.autoscaling = autoscaling.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*autoscaling.ScalingPolicy, 0)
 
@@ -852,9 +868,11 @@ func (c *connector) GetAutoScalingPolicies(ctx context.Context, input *autoscali
 }
 
 func (c *connector) GetAutoScalingScheduledActions(ctx context.Context, input *autoscaling.DescribeScheduledActionsInput) ([]*autoscaling.ScheduledUpdateGroupAction, error) {
+	c.svc.mu.Lock()
 	if c.svc.autoscaling == nil {
 		c.svc.autoscaling = autoscaling.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*autoscaling.ScheduledUpdateGroupAction, 0)
 
@@ -883,9 +901,11 @@ func (c *connector) GetAutoScalingScheduledActions(ctx context.Context, input *a
 }
 
 func (c *connector) GetBatchJobDefinitions(ctx context.Context, input *batch.DescribeJobDefinitionsInput) ([]*batch.JobDefinition, error) {
+	c.svc.mu.Lock()
 	if c.svc.batch == nil {
 		c.svc.batch = batch.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*batch.JobDefinition, 0)
 
@@ -914,9 +934,11 @@ func (c *connector) GetBatchJobDefinitions(ctx context.Context, input *batch.Des
 }
 
 func (c *connector) GetCloudFrontDistributions(ctx context.Context, input *cloudfront.ListDistributionsInput) ([]*cloudfront.DistributionSummary, error) {
+	c.svc.mu.Lock()
 	if c.svc.cloudfront == nil {
 		c.svc.cloudfront = cloudfront.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*cloudfront.DistributionSummary, 0)
 
@@ -945,9 +967,11 @@ func (c *connector) GetCloudFrontDistributions(ctx context.Context, input *cloud
 }
 
 func (c *connector) GetCloudFrontOriginAccessIdentities(ctx context.Context, input *cloudfront.ListCloudFrontOriginAccessIdentitiesInput) ([]*cloudfront.OriginAccessIdentitySummary, error) {
+	c.svc.mu.Lock()
 	if c.svc.cloudfront == nil {
 		c.svc.cloudfront = cloudfront.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*cloudfront.OriginAccessIdentitySummary, 0)
 
@@ -976,9 +1000,11 @@ func (c *connector) GetCloudFrontOriginAccessIdentities(ctx context.Context, inp
 }
 
 func (c *connector) GetCloudFrontPublicKeys(ctx context.Context, input *cloudfront.ListPublicKeysInput) ([]*cloudfront.PublicKeySummary, error) {
+	c.svc.mu.Lock()
 	if c.svc.cloudfront == nil {
 		c.svc.cloudfront = cloudfront.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*cloudfront.PublicKeySummary, 0)
 
@@ -1007,9 +1033,11 @@ func (c *connector) GetCloudFrontPublicKeys(ctx context.Context, input *cloudfro
 }
 
 func (c *connector) GetMetricAlarms(ctx context.Context, input *cloudwatch.DescribeAlarmsInput) ([]*cloudwatch.MetricAlarm, error) {
+	c.svc.mu.Lock()
 	if c.svc.cloudwatch == nil {
 		c.svc.cloudwatch = cloudwatch.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*cloudwatch.MetricAlarm, 0)
 
@@ -1038,9 +1066,11 @@ func (c *connector) GetMetricAlarms(ctx context.Context, input *cloudwatch.Descr
 }
 
 func (c *connector) GetRecordedResourceCounts(ctx context.Context, input *configservice.GetDiscoveredResourceCountsInput) ([]*configservice.ResourceCount, error) {
+	c.svc.mu.Lock()
 	if c.svc.configservice == nil {
 		c.svc.configservice = configservice.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*configservice.ResourceCount, 0)
 
@@ -1069,9 +1099,11 @@ func (c *connector) GetRecordedResourceCounts(ctx context.Context, input *config
 }
 
 func (c *connector) GetDAXClusters(ctx context.Context, input *dax.DescribeClustersInput) ([]*dax.Cluster, error) {
+	c.svc.mu.Lock()
 	if c.svc.dax == nil {
 		c.svc.dax = dax.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*dax.Cluster, 0)
 
@@ -1100,9 +1132,11 @@ func (c *connector) GetDAXClusters(ctx context.Context, input *dax.DescribeClust
 }
 
 func (c *connector) GetDirectConnectGateways(ctx context.Context, input *directconnect.DescribeDirectConnectGatewaysInput) ([]*directconnect.Gateway, error) {
+	c.svc.mu.Lock()
 	if c.svc.directconnect == nil {
 		c.svc.directconnect = directconnect.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*directconnect.Gateway, 0)
 
@@ -1131,9 +1165,11 @@ func (c *connector) GetDirectConnectGateways(ctx context.Context, input *directc
 }
 
 func (c *connector) GetDirectoryServiceDirectories(ctx context.Context, input *directoryservice.DescribeDirectoriesInput) ([]*directoryservice.DirectoryDescription, error) {
+	c.svc.mu.Lock()
 	if c.svc.directoryservice == nil {
 		c.svc.directoryservice = directoryservice.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*directoryservice.DirectoryDescription, 0)
 
@@ -1162,9 +1198,11 @@ func (c *connector) GetDirectoryServiceDirectories(ctx context.Context, input *d
 }
 
 func (c *connector) GetDMSDescribeReplicationInstances(ctx context.Context, input *databasemigrationservice.DescribeReplicationInstancesInput) ([]*databasemigrationservice.ReplicationInstance, error) {
+	c.svc.mu.Lock()
 	if c.svc.databasemigrationservice == nil {
 		c.svc.databasemigrationservice = databasemigrationservice.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*databasemigrationservice.ReplicationInstance, 0)
 
@@ -1193,9 +1231,11 @@ func (c *connector) GetDMSDescribeReplicationInstances(ctx context.Context, inpu
 }
 
 func (c *connector) GetDynamodbGlobalTables(ctx context.Context, input *dynamodb.ListGlobalTablesInput) ([]*dynamodb.GlobalTable, error) {
+	c.svc.mu.Lock()
 	if c.svc.dynamodb == nil {
 		c.svc.dynamodb = dynamodb.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*dynamodb.GlobalTable, 0)
 
@@ -1224,9 +1264,11 @@ func (c *connector) GetDynamodbGlobalTables(ctx context.Context, input *dynamodb
 }
 
 func (c *connector) GetDynamodbTables(ctx context.Context, input *dynamodb.ListTablesInput) ([]*string, error) {
+	c.svc.mu.Lock()
 	if c.svc.dynamodb == nil {
 		c.svc.dynamodb = dynamodb.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*string, 0)
 
@@ -1255,9 +1297,11 @@ func (c *connector) GetDynamodbTables(ctx context.Context, input *dynamodb.ListT
 }
 
 func (c *connector) GetAddresses(ctx context.Context, input *ec2.DescribeAddressesInput) ([]*ec2.Address, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.Address, 0)
 
@@ -1282,9 +1326,11 @@ func (c *connector) GetAddresses(ctx context.Context, input *ec2.DescribeAddress
 }
 
 func (c *connector) GetImages(ctx context.Context, input *ec2.DescribeImagesInput) ([]*ec2.Image, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.Image, 0)
 
@@ -1314,9 +1360,11 @@ func (c *connector) GetOwnImages(ctx context.Context, input *ec2.DescribeImagesI
 		input = &ec2.DescribeImagesInput{}
 	}
 	input.Owners = append(input.Owners, c.accountID)
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.Image, 0)
 
@@ -1341,9 +1389,11 @@ func (c *connector) GetOwnImages(ctx context.Context, input *ec2.DescribeImagesI
 }
 
 func (c *connector) GetInstances(ctx context.Context, input *ec2.DescribeInstancesInput) ([]*ec2.Instance, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.Instance, 0)
 
@@ -1374,9 +1424,11 @@ func (c *connector) GetInstances(ctx context.Context, input *ec2.DescribeInstanc
 }
 
 func (c *connector) GetEC2InternetGateways(ctx context.Context, input *ec2.DescribeInternetGatewaysInput) ([]*ec2.InternetGateway, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.InternetGateway, 0)
 
@@ -1405,9 +1457,11 @@ func (c *connector) GetEC2InternetGateways(ctx context.Context, input *ec2.Descr
 }
 
 func (c *connector) GetKeyPairs(ctx context.Context, input *ec2.DescribeKeyPairsInput) ([]*ec2.KeyPairInfo, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.KeyPairInfo, 0)
 
@@ -1432,9 +1486,11 @@ func (c *connector) GetKeyPairs(ctx context.Context, input *ec2.DescribeKeyPairs
 }
 
 func (c *connector) GetLaunchTemplates(ctx context.Context, input *ec2.DescribeLaunchTemplatesInput) ([]*ec2.LaunchTemplate, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.LaunchTemplate, 0)
 
@@ -1463,9 +1519,11 @@ func (c *connector) GetLaunchTemplates(ctx context.Context, input *ec2.DescribeL
 }
 
 func (c *connector) GetEC2NatGateways(ctx context.Context, input *ec2.DescribeNatGatewaysInput) ([]*ec2.NatGateway, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.NatGateway, 0)
 
@@ -1494,9 +1552,11 @@ func (c *connector) GetEC2NatGateways(ctx context.Context, input *ec2.DescribeNa
 }
 
 func (c *connector) GetSecurityGroups(ctx context.Context, input *ec2.DescribeSecurityGroupsInput) ([]*ec2.SecurityGroup, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.SecurityGroup, 0)
 
@@ -1525,9 +1585,11 @@ func (c *connector) GetSecurityGroups(ctx context.Context, input *ec2.DescribeSe
 }
 
 func (c *connector) GetSnapshots(ctx context.Context, input *ec2.DescribeSnapshotsInput) ([]*ec2.Snapshot, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.Snapshot, 0)
 
@@ -1561,9 +1623,11 @@ func (c *connector) GetOwnSnapshots(ctx context.Context, input *ec2.DescribeSnap
 		input = &ec2.DescribeSnapshotsInput{}
 	}
 	input.OwnerIds = append(input.OwnerIds, c.accountID)
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.Snapshot, 0)
 
@@ -1592,9 +1656,11 @@ func (c *connector) GetOwnSnapshots(ctx context.Context, input *ec2.DescribeSnap
 }
 
 func (c *connector) GetSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput) ([]*ec2.Subnet, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.Subnet, 0)
 
@@ -1623,9 +1689,11 @@ func (c *connector) GetSubnets(ctx context.Context, input *ec2.DescribeSubnetsIn
 }
 
 func (c *connector) GetVolumes(ctx context.Context, input *ec2.DescribeVolumesInput) ([]*ec2.Volume, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.Volume, 0)
 
@@ -1654,9 +1722,11 @@ func (c *connector) GetVolumes(ctx context.Context, input *ec2.DescribeVolumesIn
 }
 
 func (c *connector) GetVpcEndpoints(ctx context.Context, input *ec2.DescribeVpcEndpointsInput) ([]*ec2.VpcEndpoint, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.VpcEndpoint, 0)
 
@@ -1685,9 +1755,11 @@ func (c *connector) GetVpcEndpoints(ctx context.Context, input *ec2.DescribeVpcE
 }
 
 func (c *connector) GetVpcs(ctx context.Context, input *ec2.DescribeVpcsInput) ([]*ec2.Vpc, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.Vpc, 0)
 
@@ -1716,9 +1788,11 @@ func (c *connector) GetVpcs(ctx context.Context, input *ec2.DescribeVpcsInput) (
 }
 
 func (c *connector) GetVpcPeeringConnections(ctx context.Context, input *ec2.DescribeVpcPeeringConnectionsInput) ([]*ec2.VpcPeeringConnection, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.VpcPeeringConnection, 0)
 
@@ -1747,9 +1821,11 @@ func (c *connector) GetVpcPeeringConnections(ctx context.Context, input *ec2.Des
 }
 
 func (c *connector) GetVPNGateways(ctx context.Context, input *ec2.DescribeVpnGatewaysInput) ([]*ec2.VpnGateway, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.VpnGateway, 0)
 
@@ -1774,9 +1850,11 @@ func (c *connector) GetVPNGateways(ctx context.Context, input *ec2.DescribeVpnGa
 }
 
 func (c *connector) GetRouteTables(ctx context.Context, input *ec2.DescribeRouteTablesInput) ([]*ec2.RouteTable, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.RouteTable, 0)
 
@@ -1801,9 +1879,11 @@ func (c *connector) GetRouteTables(ctx context.Context, input *ec2.DescribeRoute
 }
 
 func (c *connector) GetTransitGateways(ctx context.Context, input *ec2.DescribeTransitGatewaysInput) ([]*ec2.TransitGateway, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.TransitGateway, 0)
 
@@ -1832,9 +1912,11 @@ func (c *connector) GetTransitGateways(ctx context.Context, input *ec2.DescribeT
 }
 
 func (c *connector) GetTransitGatewayVpcAttachments(ctx context.Context, input *ec2.DescribeTransitGatewayVpcAttachmentsInput) ([]*ec2.TransitGatewayVpcAttachment, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.TransitGatewayVpcAttachment, 0)
 
@@ -1863,9 +1945,11 @@ func (c *connector) GetTransitGatewayVpcAttachments(ctx context.Context, input *
 }
 
 func (c *connector) GetTransitGatewayRouteTables(ctx context.Context, input *ec2.DescribeTransitGatewayRouteTablesInput) ([]*ec2.TransitGatewayRouteTable, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.TransitGatewayRouteTable, 0)
 
@@ -1894,9 +1978,11 @@ func (c *connector) GetTransitGatewayRouteTables(ctx context.Context, input *ec2
 }
 
 func (c *connector) GetTransitGatewayMulticast(ctx context.Context, input *ec2.DescribeTransitGatewayMulticastDomainsInput) ([]*ec2.TransitGatewayMulticastDomain, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.TransitGatewayMulticastDomain, 0)
 
@@ -1925,9 +2011,11 @@ func (c *connector) GetTransitGatewayMulticast(ctx context.Context, input *ec2.D
 }
 
 func (c *connector) GetTransitGatewayPeeringAttachments(ctx context.Context, input *ec2.DescribeTransitGatewayPeeringAttachmentsInput) ([]*ec2.TransitGatewayPeeringAttachment, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.TransitGatewayPeeringAttachment, 0)
 
@@ -1956,9 +2044,11 @@ func (c *connector) GetTransitGatewayPeeringAttachments(ctx context.Context, inp
 }
 
 func (c *connector) GetTransitGatewayPrefixListReference(ctx context.Context, input *ec2.GetTransitGatewayPrefixListReferencesInput) ([]*ec2.TransitGatewayPrefixListReference, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.TransitGatewayPrefixListReference, 0)
 
@@ -1987,9 +2077,11 @@ func (c *connector) GetTransitGatewayPrefixListReference(ctx context.Context, in
 }
 
 func (c *connector) GetTransitGatewayRoutes(ctx context.Context, input *ec2.SearchTransitGatewayRoutesInput) ([]*ec2.TransitGatewayRoute, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.TransitGatewayRoute, 0)
 
@@ -2014,9 +2106,11 @@ func (c *connector) GetTransitGatewayRoutes(ctx context.Context, input *ec2.Sear
 }
 
 func (c *connector) GetTransitGatewayRouteTableAssociations(ctx context.Context, input *ec2.GetTransitGatewayRouteTableAssociationsInput) ([]*ec2.TransitGatewayRouteTableAssociation, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.TransitGatewayRouteTableAssociation, 0)
 
@@ -2045,9 +2139,11 @@ func (c *connector) GetTransitGatewayRouteTableAssociations(ctx context.Context,
 }
 
 func (c *connector) GetTransitGatewayRouteTablePropagations(ctx context.Context, input *ec2.GetTransitGatewayRouteTablePropagationsInput) ([]*ec2.TransitGatewayRouteTablePropagation, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ec2.TransitGatewayRouteTablePropagation, 0)
 
@@ -2076,9 +2172,11 @@ func (c *connector) GetTransitGatewayRouteTablePropagations(ctx context.Context,
 }
 
 func (c *connector) GetECSClustersArns(ctx context.Context, input *ecs.ListClustersInput) ([]*string, error) {
+	c.svc.mu.Lock()
 	if c.svc.ecs == nil {
 		c.svc.ecs = ecs.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*string, 0)
 
@@ -2107,9 +2205,11 @@ func (c *connector) GetECSClustersArns(ctx context.Context, input *ecs.ListClust
 }
 
 func (c *connector) GetECSClusters(ctx context.Context, input *ecs.DescribeClustersInput) ([]*ecs.Cluster, error) {
+	c.svc.mu.Lock()
 	if c.svc.ecs == nil {
 		c.svc.ecs = ecs.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ecs.Cluster, 0)
 
@@ -2134,9 +2234,11 @@ func (c *connector) GetECSClusters(ctx context.Context, input *ecs.DescribeClust
 }
 
 func (c *connector) GetECSServicesArns(ctx context.Context, input *ecs.ListServicesInput) ([]*string, error) {
+	c.svc.mu.Lock()
 	if c.svc.ecs == nil {
 		c.svc.ecs = ecs.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*string, 0)
 
@@ -2165,9 +2267,11 @@ func (c *connector) GetECSServicesArns(ctx context.Context, input *ecs.ListServi
 }
 
 func (c *connector) GetECSServices(ctx context.Context, input *ecs.DescribeServicesInput) ([]*ecs.Service, error) {
+	c.svc.mu.Lock()
 	if c.svc.ecs == nil {
 		c.svc.ecs = ecs.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ecs.Service, 0)
 
@@ -2192,9 +2296,11 @@ func (c *connector) GetECSServices(ctx context.Context, input *ecs.DescribeServi
 }
 
 func (c *connector) GetECSTaskDefinitionsArns(ctx context.Context, input *ecs.ListTaskDefinitionsInput) ([]*string, error) {
+	c.svc.mu.Lock()
 	if c.svc.ecs == nil {
 		c.svc.ecs = ecs.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*string, 0)
 
@@ -2223,9 +2329,11 @@ func (c *connector) GetECSTaskDefinitionsArns(ctx context.Context, input *ecs.Li
 }
 
 func (c *connector) GetEFSFileSystems(ctx context.Context, input *efs.DescribeFileSystemsInput) ([]*efs.FileSystemDescription, error) {
+	c.svc.mu.Lock()
 	if c.svc.efs == nil {
 		c.svc.efs = efs.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*efs.FileSystemDescription, 0)
 
@@ -2254,9 +2362,11 @@ func (c *connector) GetEFSFileSystems(ctx context.Context, input *efs.DescribeFi
 }
 
 func (c *connector) GetEKSCluster(ctx context.Context, input *eks.DescribeClusterInput) (*eks.Cluster, error) {
+	c.svc.mu.Lock()
 	if c.svc.eks == nil {
 		c.svc.eks = eks.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	var opt *eks.Cluster
 
@@ -2281,9 +2391,11 @@ func (c *connector) GetEKSCluster(ctx context.Context, input *eks.DescribeCluste
 }
 
 func (c *connector) GetEKSClusters(ctx context.Context, input *eks.ListClustersInput) ([]*string, error) {
+	c.svc.mu.Lock()
 	if c.svc.eks == nil {
 		c.svc.eks = eks.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*string, 0)
 
@@ -2312,9 +2424,11 @@ func (c *connector) GetEKSClusters(ctx context.Context, input *eks.ListClustersI
 }
 
 func (c *connector) GetElastiCacheClusters(ctx context.Context, input *elasticache.DescribeCacheClustersInput) ([]*elasticache.CacheCluster, error) {
+	c.svc.mu.Lock()
 	if c.svc.elasticache == nil {
 		c.svc.elasticache = elasticache.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elasticache.CacheCluster, 0)
 
@@ -2343,9 +2457,11 @@ func (c *connector) GetElastiCacheClusters(ctx context.Context, input *elasticac
 }
 
 func (c *connector) GetElastiCacheReplicationGroups(ctx context.Context, input *elasticache.DescribeReplicationGroupsInput) ([]*elasticache.ReplicationGroup, error) {
+	c.svc.mu.Lock()
 	if c.svc.elasticache == nil {
 		c.svc.elasticache = elasticache.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elasticache.ReplicationGroup, 0)
 
@@ -2374,9 +2490,11 @@ func (c *connector) GetElastiCacheReplicationGroups(ctx context.Context, input *
 }
 
 func (c *connector) GetElastiCacheTags(ctx context.Context, input *elasticache.ListTagsForResourceInput) ([]*elasticache.Tag, error) {
+	c.svc.mu.Lock()
 	if c.svc.elasticache == nil {
 		c.svc.elasticache = elasticache.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elasticache.Tag, 0)
 
@@ -2401,9 +2519,11 @@ func (c *connector) GetElastiCacheTags(ctx context.Context, input *elasticache.L
 }
 
 func (c *connector) GetElasticBeanstalkApplications(ctx context.Context, input *elasticbeanstalk.DescribeApplicationsInput) ([]*elasticbeanstalk.ApplicationDescription, error) {
+	c.svc.mu.Lock()
 	if c.svc.elasticbeanstalk == nil {
 		c.svc.elasticbeanstalk = elasticbeanstalk.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elasticbeanstalk.ApplicationDescription, 0)
 
@@ -2428,9 +2548,11 @@ func (c *connector) GetElasticBeanstalkApplications(ctx context.Context, input *
 }
 
 func (c *connector) GetElasticsearchDomainNames(ctx context.Context, input *elasticsearchservice.ListDomainNamesInput) ([]*elasticsearchservice.DomainInfo, error) {
+	c.svc.mu.Lock()
 	if c.svc.elasticsearchservice == nil {
 		c.svc.elasticsearchservice = elasticsearchservice.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elasticsearchservice.DomainInfo, 0)
 
@@ -2455,9 +2577,11 @@ func (c *connector) GetElasticsearchDomainNames(ctx context.Context, input *elas
 }
 
 func (c *connector) GetElasticsearchDomains(ctx context.Context, input *elasticsearchservice.DescribeElasticsearchDomainsInput) ([]*elasticsearchservice.ElasticsearchDomainStatus, error) {
+	c.svc.mu.Lock()
 	if c.svc.elasticsearchservice == nil {
 		c.svc.elasticsearchservice = elasticsearchservice.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elasticsearchservice.ElasticsearchDomainStatus, 0)
 
@@ -2482,9 +2606,11 @@ func (c *connector) GetElasticsearchDomains(ctx context.Context, input *elastics
 }
 
 func (c *connector) GetLoadBalancerAttributes(ctx context.Context, input *elb.DescribeLoadBalancerAttributesInput) ([]*elb.AdditionalAttribute, error) {
+	c.svc.mu.Lock()
 	if c.svc.elb == nil {
 		c.svc.elb = elb.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elb.AdditionalAttribute, 0)
 
@@ -2509,9 +2635,11 @@ func (c *connector) GetLoadBalancerAttributes(ctx context.Context, input *elb.De
 }
 
 func (c *connector) GetLoadBalancers(ctx context.Context, input *elb.DescribeLoadBalancersInput) ([]*elb.LoadBalancerDescription, error) {
+	c.svc.mu.Lock()
 	if c.svc.elb == nil {
 		c.svc.elb = elb.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elb.LoadBalancerDescription, 0)
 
@@ -2540,9 +2668,11 @@ func (c *connector) GetLoadBalancers(ctx context.Context, input *elb.DescribeLoa
 }
 
 func (c *connector) GetLoadBalancerPolicies(ctx context.Context, input *elb.DescribeLoadBalancerPoliciesInput) ([]*elb.PolicyDescription, error) {
+	c.svc.mu.Lock()
 	if c.svc.elb == nil {
 		c.svc.elb = elb.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elb.PolicyDescription, 0)
 
@@ -2567,9 +2697,11 @@ func (c *connector) GetLoadBalancerPolicies(ctx context.Context, input *elb.Desc
 }
 
 func (c *connector) GetLoadBalancersTags(ctx context.Context, input *elb.DescribeTagsInput) ([]*elb.TagDescription, error) {
+	c.svc.mu.Lock()
 	if c.svc.elb == nil {
 		c.svc.elb = elb.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elb.TagDescription, 0)
 
@@ -2594,9 +2726,11 @@ func (c *connector) GetLoadBalancersTags(ctx context.Context, input *elb.Describ
 }
 
 func (c *connector) GetListenerCertificates(ctx context.Context, input *elbv2.DescribeListenerCertificatesInput) ([]*elbv2.Certificate, error) {
+	c.svc.mu.Lock()
 	if c.svc.elbv2 == nil {
 		c.svc.elbv2 = elbv2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elbv2.Certificate, 0)
 
@@ -2625,9 +2759,11 @@ func (c *connector) GetListenerCertificates(ctx context.Context, input *elbv2.De
 }
 
 func (c *connector) GetLoadBalancersV2Listeners(ctx context.Context, input *elbv2.DescribeListenersInput) ([]*elbv2.Listener, error) {
+	c.svc.mu.Lock()
 	if c.svc.elbv2 == nil {
 		c.svc.elbv2 = elbv2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elbv2.Listener, 0)
 
@@ -2656,9 +2792,11 @@ func (c *connector) GetLoadBalancersV2Listeners(ctx context.Context, input *elbv
 }
 
 func (c *connector) GetLoadBalancersV2(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) ([]*elbv2.LoadBalancer, error) {
+	c.svc.mu.Lock()
 	if c.svc.elbv2 == nil {
 		c.svc.elbv2 = elbv2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elbv2.LoadBalancer, 0)
 
@@ -2687,9 +2825,11 @@ func (c *connector) GetLoadBalancersV2(ctx context.Context, input *elbv2.Describ
 }
 
 func (c *connector) GetLoadBalancersV2Tags(ctx context.Context, input *elbv2.DescribeTagsInput) ([]*elbv2.TagDescription, error) {
+	c.svc.mu.Lock()
 	if c.svc.elbv2 == nil {
 		c.svc.elbv2 = elbv2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elbv2.TagDescription, 0)
 
@@ -2714,9 +2854,11 @@ func (c *connector) GetLoadBalancersV2Tags(ctx context.Context, input *elbv2.Des
 }
 
 func (c *connector) GetLoadBalancersV2TargetGroupAttributes(ctx context.Context, input *elbv2.DescribeTargetGroupAttributesInput) ([]*elbv2.TargetGroupAttribute, error) {
+	c.svc.mu.Lock()
 	if c.svc.elbv2 == nil {
 		c.svc.elbv2 = elbv2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elbv2.TargetGroupAttribute, 0)
 
@@ -2741,9 +2883,11 @@ func (c *connector) GetLoadBalancersV2TargetGroupAttributes(ctx context.Context,
 }
 
 func (c *connector) GetLoadBalancersV2TargetGroups(ctx context.Context, input *elbv2.DescribeTargetGroupsInput) ([]*elbv2.TargetGroup, error) {
+	c.svc.mu.Lock()
 	if c.svc.elbv2 == nil {
 		c.svc.elbv2 = elbv2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elbv2.TargetGroup, 0)
 
@@ -2772,9 +2916,11 @@ func (c *connector) GetLoadBalancersV2TargetGroups(ctx context.Context, input *e
 }
 
 func (c *connector) GetLoadBalancersV2TargetHealth(ctx context.Context, input *elbv2.DescribeTargetHealthInput) ([]*elbv2.TargetHealthDescription, error) {
+	c.svc.mu.Lock()
 	if c.svc.elbv2 == nil {
 		c.svc.elbv2 = elbv2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elbv2.TargetHealthDescription, 0)
 
@@ -2799,9 +2945,11 @@ func (c *connector) GetLoadBalancersV2TargetHealth(ctx context.Context, input *e
 }
 
 func (c *connector) GetLoadBalancersV2Rules(ctx context.Context, input *elbv2.DescribeRulesInput) ([]*elbv2.Rule, error) {
+	c.svc.mu.Lock()
 	if c.svc.elbv2 == nil {
 		c.svc.elbv2 = elbv2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*elbv2.Rule, 0)
 
@@ -2830,9 +2978,11 @@ func (c *connector) GetLoadBalancersV2Rules(ctx context.Context, input *elbv2.De
 }
 
 func (c *connector) GetEMRClusters(ctx context.Context, input *emr.ListClustersInput) ([]*emr.ClusterSummary, error) {
+	c.svc.mu.Lock()
 	if c.svc.emr == nil {
 		c.svc.emr = emr.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*emr.ClusterSummary, 0)
 
@@ -2861,9 +3011,11 @@ func (c *connector) GetEMRClusters(ctx context.Context, input *emr.ListClustersI
 }
 
 func (c *connector) GetFSXFileSystems(ctx context.Context, input *fsx.DescribeFileSystemsInput) ([]*fsx.FileSystem, error) {
+	c.svc.mu.Lock()
 	if c.svc.fsx == nil {
 		c.svc.fsx = fsx.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*fsx.FileSystem, 0)
 
@@ -2892,9 +3044,11 @@ func (c *connector) GetFSXFileSystems(ctx context.Context, input *fsx.DescribeFi
 }
 
 func (c *connector) GetGlueDatabases(ctx context.Context, input *glue.GetDatabasesInput) ([]*glue.Database, error) {
+	c.svc.mu.Lock()
 	if c.svc.glue == nil {
 		c.svc.glue = glue.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*glue.Database, 0)
 
@@ -2923,9 +3077,11 @@ func (c *connector) GetGlueDatabases(ctx context.Context, input *glue.GetDatabas
 }
 
 func (c *connector) GetGlueTables(ctx context.Context, input *glue.GetTablesInput) ([]*glue.TableData, error) {
+	c.svc.mu.Lock()
 	if c.svc.glue == nil {
 		c.svc.glue = glue.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*glue.TableData, 0)
 
@@ -2954,9 +3110,11 @@ func (c *connector) GetGlueTables(ctx context.Context, input *glue.GetTablesInpu
 }
 
 func (c *connector) GetAccessKeys(ctx context.Context, input *iam.ListAccessKeysInput) ([]*iam.AccessKeyMetadata, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*iam.AccessKeyMetadata, 0)
 
@@ -2985,9 +3143,11 @@ func (c *connector) GetAccessKeys(ctx context.Context, input *iam.ListAccessKeys
 }
 
 func (c *connector) GetAccountAliases(ctx context.Context, input *iam.ListAccountAliasesInput) ([]*string, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*string, 0)
 
@@ -3016,9 +3176,11 @@ func (c *connector) GetAccountAliases(ctx context.Context, input *iam.ListAccoun
 }
 
 func (c *connector) GetAccountPasswordPolicy(ctx context.Context, input *iam.GetAccountPasswordPolicyInput) (*iam.PasswordPolicy, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	var opt *iam.PasswordPolicy
 
@@ -3043,9 +3205,11 @@ func (c *connector) GetAccountPasswordPolicy(ctx context.Context, input *iam.Get
 }
 
 func (c *connector) GetAttachedGroupPolicies(ctx context.Context, input *iam.ListAttachedGroupPoliciesInput) ([]*iam.AttachedPolicy, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*iam.AttachedPolicy, 0)
 
@@ -3074,9 +3238,11 @@ func (c *connector) GetAttachedGroupPolicies(ctx context.Context, input *iam.Lis
 }
 
 func (c *connector) GetAttachedRolePolicies(ctx context.Context, input *iam.ListAttachedRolePoliciesInput) ([]*iam.AttachedPolicy, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*iam.AttachedPolicy, 0)
 
@@ -3105,9 +3271,11 @@ func (c *connector) GetAttachedRolePolicies(ctx context.Context, input *iam.List
 }
 
 func (c *connector) GetAttachedUserPolicies(ctx context.Context, input *iam.ListAttachedUserPoliciesInput) ([]*iam.AttachedPolicy, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*iam.AttachedPolicy, 0)
 
@@ -3136,9 +3304,11 @@ func (c *connector) GetAttachedUserPolicies(ctx context.Context, input *iam.List
 }
 
 func (c *connector) GetGroupUsers(ctx context.Context, input *iam.GetGroupInput) ([]*iam.User, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*iam.User, 0)
 
@@ -3167,9 +3337,11 @@ func (c *connector) GetGroupUsers(ctx context.Context, input *iam.GetGroupInput)
 }
 
 func (c *connector) GetGroupPolicies(ctx context.Context, input *iam.ListGroupPoliciesInput) ([]*string, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*string, 0)
 
@@ -3198,9 +3370,11 @@ func (c *connector) GetGroupPolicies(ctx context.Context, input *iam.ListGroupPo
 }
 
 func (c *connector) GetGroups(ctx context.Context, input *iam.ListGroupsInput) ([]*iam.Group, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*iam.Group, 0)
 
@@ -3229,9 +3403,11 @@ func (c *connector) GetGroups(ctx context.Context, input *iam.ListGroupsInput) (
 }
 
 func (c *connector) GetGroupsForUser(ctx context.Context, input *iam.ListGroupsForUserInput) ([]*iam.Group, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*iam.Group, 0)
 
@@ -3260,9 +3436,11 @@ func (c *connector) GetGroupsForUser(ctx context.Context, input *iam.ListGroupsF
 }
 
 func (c *connector) GetInstanceProfiles(ctx context.Context, input *iam.ListInstanceProfilesInput) ([]*iam.InstanceProfile, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*iam.InstanceProfile, 0)
 
@@ -3291,9 +3469,11 @@ func (c *connector) GetInstanceProfiles(ctx context.Context, input *iam.ListInst
 }
 
 func (c *connector) GetOpenIDConnectProviders(ctx context.Context, input *iam.ListOpenIDConnectProvidersInput) ([]*iam.OpenIDConnectProviderListEntry, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*iam.OpenIDConnectProviderListEntry, 0)
 
@@ -3318,9 +3498,11 @@ func (c *connector) GetOpenIDConnectProviders(ctx context.Context, input *iam.Li
 }
 
 func (c *connector) GetPolicies(ctx context.Context, input *iam.ListPoliciesInput) ([]*iam.Policy, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*iam.Policy, 0)
 
@@ -3349,9 +3531,11 @@ func (c *connector) GetPolicies(ctx context.Context, input *iam.ListPoliciesInpu
 }
 
 func (c *connector) GetRolePolicies(ctx context.Context, input *iam.ListRolePoliciesInput) ([]*string, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*string, 0)
 
@@ -3380,9 +3564,11 @@ func (c *connector) GetRolePolicies(ctx context.Context, input *iam.ListRolePoli
 }
 
 func (c *connector) GetRoles(ctx context.Context, input *iam.ListRolesInput) ([]*iam.Role, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*iam.Role, 0)
 
@@ -3411,9 +3597,11 @@ func (c *connector) GetRoles(ctx context.Context, input *iam.ListRolesInput) ([]
 }
 
 func (c *connector) GetSAMLProviders(ctx context.Context, input *iam.ListSAMLProvidersInput) ([]*iam.SAMLProviderListEntry, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*iam.SAMLProviderListEntry, 0)
 
@@ -3438,9 +3626,11 @@ func (c *connector) GetSAMLProviders(ctx context.Context, input *iam.ListSAMLPro
 }
 
 func (c *connector) GetServerCertificates(ctx context.Context, input *iam.ListServerCertificatesInput) ([]*iam.ServerCertificateMetadata, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*iam.ServerCertificateMetadata, 0)
 
@@ -3469,9 +3659,11 @@ func (c *connector) GetServerCertificates(ctx context.Context, input *iam.ListSe
 }
 
 func (c *connector) GetSSHPublicKeys(ctx context.Context, input *iam.ListSSHPublicKeysInput) ([]*iam.SSHPublicKeyMetadata, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*iam.SSHPublicKeyMetadata, 0)
 
@@ -3500,9 +3692,11 @@ func (c *connector) GetSSHPublicKeys(ctx context.Context, input *iam.ListSSHPubl
 }
 
 func (c *connector) GetUserPolicies(ctx context.Context, input *iam.ListUserPoliciesInput) ([]*string, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*string, 0)
 
@@ -3531,9 +3725,11 @@ func (c *connector) GetUserPolicies(ctx context.Context, input *iam.ListUserPoli
 }
 
 func (c *connector) GetUsers(ctx context.Context, input *iam.ListUsersInput) ([]*iam.User, error) {
+	c.svc.mu.Lock()
 	if c.svc.iam == nil {
 		c.svc.iam = iam.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*iam.User, 0)
 
@@ -3562,9 +3758,11 @@ func (c *connector) GetUsers(ctx context.Context, input *iam.ListUsersInput) ([]
 }
 
 func (c *connector) GetKinesisStreams(ctx context.Context, input *kinesis.ListStreamsInput) ([]*string, error) {
+	c.svc.mu.Lock()
 	if c.svc.kinesis == nil {
 		c.svc.kinesis = kinesis.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*string, 0)
 
@@ -3589,9 +3787,11 @@ func (c *connector) GetKinesisStreams(ctx context.Context, input *kinesis.ListSt
 }
 
 func (c *connector) GetLambdaFunctions(ctx context.Context, input *lambda.ListFunctionsInput) ([]*lambda.FunctionConfiguration, error) {
+	c.svc.mu.Lock()
 	if c.svc.lambda == nil {
 		c.svc.lambda = lambda.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*lambda.FunctionConfiguration, 0)
 
@@ -3620,9 +3820,11 @@ func (c *connector) GetLambdaFunctions(ctx context.Context, input *lambda.ListFu
 }
 
 func (c *connector) GetLightsailInstances(ctx context.Context, input *lightsail.GetInstancesInput) ([]*lightsail.Instance, error) {
+	c.svc.mu.Lock()
 	if c.svc.lightsail == nil {
 		c.svc.lightsail = lightsail.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*lightsail.Instance, 0)
 
@@ -3651,9 +3853,11 @@ func (c *connector) GetLightsailInstances(ctx context.Context, input *lightsail.
 }
 
 func (c *connector) GetMediastoreContainers(ctx context.Context, input *mediastore.ListContainersInput) ([]*mediastore.Container, error) {
+	c.svc.mu.Lock()
 	if c.svc.mediastore == nil {
 		c.svc.mediastore = mediastore.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*mediastore.Container, 0)
 
@@ -3682,9 +3886,11 @@ func (c *connector) GetMediastoreContainers(ctx context.Context, input *mediasto
 }
 
 func (c *connector) GetMQBrokers(ctx context.Context, input *mq.ListBrokersInput) ([]*mq.BrokerSummary, error) {
+	c.svc.mu.Lock()
 	if c.svc.mq == nil {
 		c.svc.mq = mq.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*mq.BrokerSummary, 0)
 
@@ -3713,9 +3919,11 @@ func (c *connector) GetMQBrokers(ctx context.Context, input *mq.ListBrokersInput
 }
 
 func (c *connector) GetNeptuneDBClusters(ctx context.Context, input *neptune.DescribeDBClustersInput) ([]*neptune.DBCluster, error) {
+	c.svc.mu.Lock()
 	if c.svc.neptune == nil {
 		c.svc.neptune = neptune.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*neptune.DBCluster, 0)
 
@@ -3744,9 +3952,11 @@ func (c *connector) GetNeptuneDBClusters(ctx context.Context, input *neptune.Des
 }
 
 func (c *connector) GetRDSDBClusters(ctx context.Context, input *rds.DescribeDBClustersInput) ([]*rds.DBCluster, error) {
+	c.svc.mu.Lock()
 	if c.svc.rds == nil {
 		c.svc.rds = rds.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*rds.DBCluster, 0)
 
@@ -3775,9 +3985,11 @@ func (c *connector) GetRDSDBClusters(ctx context.Context, input *rds.DescribeDBC
 }
 
 func (c *connector) GetDBInstances(ctx context.Context, input *rds.DescribeDBInstancesInput) ([]*rds.DBInstance, error) {
+	c.svc.mu.Lock()
 	if c.svc.rds == nil {
 		c.svc.rds = rds.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*rds.DBInstance, 0)
 
@@ -3806,9 +4018,11 @@ func (c *connector) GetDBInstances(ctx context.Context, input *rds.DescribeDBIns
 }
 
 func (c *connector) GetDBParameterGroups(ctx context.Context, input *rds.DescribeDBParameterGroupsInput) ([]*rds.DBParameterGroup, error) {
+	c.svc.mu.Lock()
 	if c.svc.rds == nil {
 		c.svc.rds = rds.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*rds.DBParameterGroup, 0)
 
@@ -3837,9 +4051,11 @@ func (c *connector) GetDBParameterGroups(ctx context.Context, input *rds.Describ
 }
 
 func (c *connector) GetDBSubnetGroups(ctx context.Context, input *rds.DescribeDBSubnetGroupsInput) ([]*rds.DBSubnetGroup, error) {
+	c.svc.mu.Lock()
 	if c.svc.rds == nil {
 		c.svc.rds = rds.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*rds.DBSubnetGroup, 0)
 
@@ -3868,9 +4084,11 @@ func (c *connector) GetDBSubnetGroups(ctx context.Context, input *rds.DescribeDB
 }
 
 func (c *connector) GetRDSGlobalClusters(ctx context.Context, input *rds.DescribeGlobalClustersInput) ([]*rds.GlobalCluster, error) {
+	c.svc.mu.Lock()
 	if c.svc.rds == nil {
 		c.svc.rds = rds.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*rds.GlobalCluster, 0)
 
@@ -3899,9 +4117,11 @@ func (c *connector) GetRDSGlobalClusters(ctx context.Context, input *rds.Describ
 }
 
 func (c *connector) GetDBInstancesTags(ctx context.Context, input *rds.ListTagsForResourceInput) ([]*rds.Tag, error) {
+	c.svc.mu.Lock()
 	if c.svc.rds == nil {
 		c.svc.rds = rds.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*rds.Tag, 0)
 
@@ -3926,9 +4146,11 @@ func (c *connector) GetDBInstancesTags(ctx context.Context, input *rds.ListTagsF
 }
 
 func (c *connector) GetRedshiftClusters(ctx context.Context, input *redshift.DescribeClustersInput) ([]*redshift.Cluster, error) {
+	c.svc.mu.Lock()
 	if c.svc.redshift == nil {
 		c.svc.redshift = redshift.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*redshift.Cluster, 0)
 
@@ -3957,9 +4179,11 @@ func (c *connector) GetRedshiftClusters(ctx context.Context, input *redshift.Des
 }
 
 func (c *connector) GetQueryLoggingConfigs(ctx context.Context, input *route53.ListQueryLoggingConfigsInput) ([]*route53.QueryLoggingConfig, error) {
+	c.svc.mu.Lock()
 	if c.svc.route53 == nil {
 		c.svc.route53 = route53.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*route53.QueryLoggingConfig, 0)
 
@@ -3988,9 +4212,11 @@ func (c *connector) GetQueryLoggingConfigs(ctx context.Context, input *route53.L
 }
 
 func (c *connector) GetHealthChecks(ctx context.Context, input *route53.ListHealthChecksInput) ([]*route53.HealthCheck, error) {
+	c.svc.mu.Lock()
 	if c.svc.route53 == nil {
 		c.svc.route53 = route53.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*route53.HealthCheck, 0)
 
@@ -4019,9 +4245,11 @@ func (c *connector) GetHealthChecks(ctx context.Context, input *route53.ListHeal
 }
 
 func (c *connector) GetHostedZones(ctx context.Context, input *route53.ListHostedZonesInput) ([]*route53.HostedZone, error) {
+	c.svc.mu.Lock()
 	if c.svc.route53 == nil {
 		c.svc.route53 = route53.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*route53.HostedZone, 0)
 
@@ -4050,9 +4278,11 @@ func (c *connector) GetHostedZones(ctx context.Context, input *route53.ListHoste
 }
 
 func (c *connector) GetResourceRecordSets(ctx context.Context, input *route53.ListResourceRecordSetsInput) ([]*route53.ResourceRecordSet, error) {
+	c.svc.mu.Lock()
 	if c.svc.route53 == nil {
 		c.svc.route53 = route53.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*route53.ResourceRecordSet, 0)
 
@@ -4081,9 +4311,11 @@ func (c *connector) GetResourceRecordSets(ctx context.Context, input *route53.Li
 }
 
 func (c *connector) GetReusableDelegationSets(ctx context.Context, input *route53.ListReusableDelegationSetsInput) ([]*route53.DelegationSet, error) {
+	c.svc.mu.Lock()
 	if c.svc.route53 == nil {
 		c.svc.route53 = route53.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*route53.DelegationSet, 0)
 
@@ -4112,9 +4344,11 @@ func (c *connector) GetReusableDelegationSets(ctx context.Context, input *route5
 }
 
 func (c *connector) GetVPCAssociationAuthorizations(ctx context.Context, input *route53.ListVPCAssociationAuthorizationsInput) ([]*route53.VPC, error) {
+	c.svc.mu.Lock()
 	if c.svc.route53 == nil {
 		c.svc.route53 = route53.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*route53.VPC, 0)
 
@@ -4143,9 +4377,11 @@ func (c *connector) GetVPCAssociationAuthorizations(ctx context.Context, input *
 }
 
 func (c *connector) GetResolverEndpoints(ctx context.Context, input *route53resolver.ListResolverEndpointsInput) ([]*route53resolver.ResolverEndpoint, error) {
+	c.svc.mu.Lock()
 	if c.svc.route53resolver == nil {
 		c.svc.route53resolver = route53resolver.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*route53resolver.ResolverEndpoint, 0)
 
@@ -4174,9 +4410,11 @@ func (c *connector) GetResolverEndpoints(ctx context.Context, input *route53reso
 }
 
 func (c *connector) GetResolverRuleAssociations(ctx context.Context, input *route53resolver.ListResolverRuleAssociationsInput) ([]*route53resolver.ResolverRuleAssociation, error) {
+	c.svc.mu.Lock()
 	if c.svc.route53resolver == nil {
 		c.svc.route53resolver = route53resolver.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*route53resolver.ResolverRuleAssociation, 0)
 
@@ -4205,9 +4443,11 @@ func (c *connector) GetResolverRuleAssociations(ctx context.Context, input *rout
 }
 
 func (c *connector) GetResolverRules(ctx context.Context, input *route53resolver.ListResolverRulesInput) ([]*route53resolver.ResolverRule, error) {
+	c.svc.mu.Lock()
 	if c.svc.route53resolver == nil {
 		c.svc.route53resolver = route53resolver.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*route53resolver.ResolverRule, 0)
 
@@ -4236,9 +4476,11 @@ func (c *connector) GetResolverRules(ctx context.Context, input *route53resolver
 }
 
 func (c *connector) GetBucketTags(ctx context.Context, input *s3.GetBucketTaggingInput) ([]*s3.Tag, error) {
+	c.svc.mu.Lock()
 	if c.svc.s3 == nil {
 		c.svc.s3 = s3.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*s3.Tag, 0)
 
@@ -4263,9 +4505,11 @@ func (c *connector) GetBucketTags(ctx context.Context, input *s3.GetBucketTaggin
 }
 
 func (c *connector) ListObjects(ctx context.Context, input *s3.ListObjectsInput) ([]*s3.Object, error) {
+	c.svc.mu.Lock()
 	if c.svc.s3 == nil {
 		c.svc.s3 = s3.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*s3.Object, 0)
 
@@ -4294,9 +4538,11 @@ func (c *connector) ListObjects(ctx context.Context, input *s3.ListObjectsInput)
 }
 
 func (c *connector) GetObjectsTags(ctx context.Context, input *s3.GetObjectTaggingInput) ([]*s3.Tag, error) {
+	c.svc.mu.Lock()
 	if c.svc.s3 == nil {
 		c.svc.s3 = s3.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*s3.Tag, 0)
 
@@ -4321,9 +4567,11 @@ func (c *connector) GetObjectsTags(ctx context.Context, input *s3.GetObjectTaggi
 }
 
 func (c *connector) GetActiveReceiptRuleSet(ctx context.Context, input *ses.DescribeActiveReceiptRuleSetInput) (*string, error) {
+	c.svc.mu.Lock()
 	if c.svc.ses == nil {
 		c.svc.ses = ses.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	var opt *string
 
@@ -4348,9 +4596,11 @@ func (c *connector) GetActiveReceiptRuleSet(ctx context.Context, input *ses.Desc
 }
 
 func (c *connector) GetActiveReceiptRulesSet(ctx context.Context, input *ses.DescribeActiveReceiptRuleSetInput) ([]*ses.ReceiptRule, error) {
+	c.svc.mu.Lock()
 	if c.svc.ses == nil {
 		c.svc.ses = ses.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ses.ReceiptRule, 0)
 
@@ -4375,9 +4625,11 @@ func (c *connector) GetActiveReceiptRulesSet(ctx context.Context, input *ses.Des
 }
 
 func (c *connector) GetConfigurationSets(ctx context.Context, input *ses.ListConfigurationSetsInput) ([]*ses.ConfigurationSet, error) {
+	c.svc.mu.Lock()
 	if c.svc.ses == nil {
 		c.svc.ses = ses.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ses.ConfigurationSet, 0)
 
@@ -4406,9 +4658,11 @@ func (c *connector) GetConfigurationSets(ctx context.Context, input *ses.ListCon
 }
 
 func (c *connector) GetIdentities(ctx context.Context, input *ses.ListIdentitiesInput) ([]*string, error) {
+	c.svc.mu.Lock()
 	if c.svc.ses == nil {
 		c.svc.ses = ses.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*string, 0)
 
@@ -4437,9 +4691,11 @@ func (c *connector) GetIdentities(ctx context.Context, input *ses.ListIdentities
 }
 
 func (c *connector) GetIdentityNotificationAttributes(ctx context.Context, input *ses.GetIdentityNotificationAttributesInput) (map[string]*ses.IdentityNotificationAttributes, error) {
+	c.svc.mu.Lock()
 	if c.svc.ses == nil {
 		c.svc.ses = ses.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make(map[string]*ses.IdentityNotificationAttributes, 0)
 
@@ -4464,9 +4720,11 @@ func (c *connector) GetIdentityNotificationAttributes(ctx context.Context, input
 }
 
 func (c *connector) GetReceiptFilters(ctx context.Context, input *ses.ListReceiptFiltersInput) ([]*ses.ReceiptFilter, error) {
+	c.svc.mu.Lock()
 	if c.svc.ses == nil {
 		c.svc.ses = ses.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ses.ReceiptFilter, 0)
 
@@ -4491,9 +4749,11 @@ func (c *connector) GetReceiptFilters(ctx context.Context, input *ses.ListReceip
 }
 
 func (c *connector) GetTemplates(ctx context.Context, input *ses.ListTemplatesInput) ([]*ses.TemplateMetadata, error) {
+	c.svc.mu.Lock()
 	if c.svc.ses == nil {
 		c.svc.ses = ses.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*ses.TemplateMetadata, 0)
 
@@ -4522,9 +4782,11 @@ func (c *connector) GetTemplates(ctx context.Context, input *ses.ListTemplatesIn
 }
 
 func (c *connector) GetSQSQueues(ctx context.Context, input *sqs.ListQueuesInput) ([]*string, error) {
+	c.svc.mu.Lock()
 	if c.svc.sqs == nil {
 		c.svc.sqs = sqs.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*string, 0)
 
@@ -4553,9 +4815,11 @@ func (c *connector) GetSQSQueues(ctx context.Context, input *sqs.ListQueuesInput
 }
 
 func (c *connector) GetStorageGatewayGateways(ctx context.Context, input *storagegateway.ListGatewaysInput) ([]*storagegateway.GatewayInfo, error) {
+	c.svc.mu.Lock()
 	if c.svc.storagegateway == nil {
 		c.svc.storagegateway = storagegateway.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
 	opt := make([]*storagegateway.GatewayInfo, 0)
 
@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/cycloidio/terracognita/filter"
 	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/tag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -579,7 +580,7 @@ func albTargetGroups(ctx context.Context, a *aws, resourceType string, filters *
 /*
 func amis(ctx context.Context, a *aws, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
 	var input = &ec2.DescribeImagesInput{
-	Filters: toEC2Filters(filters),
+	Filters: toEC2Filters(a, resourceType, filters),
 	}
 
 	images, err := a.awsr.GetOwnImages(ctx, input)
@@ -1111,7 +1112,7 @@ func dynamodbTables(ctx context.Context, a *aws, resourceType string, filters *f
 /*
 func ebsSnapshots(ctx context.Context, a *aws, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
 	var input = &ec2.DescribeSnapshotsInput{
-	Filters: toEC2Filters(filters),
+	Filters: toEC2Filters(a, resourceType, filters),
 	}
 
 	snapshots, err := a.awsr.GetOwnSnapshots(ctx, input)
@@ -1134,7 +1135,7 @@ func ebsSnapshots(ctx context.Context, a *aws, resourceType string, filters *fil
 
 func ebsVolumes(ctx context.Context, a *aws, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
 	var input = &ec2.DescribeVolumesInput{
-		Filters: toEC2Filters(filters),
+		Filters: toEC2Filters(a, resourceType, filters),
 	}
 
 	volumes, err := a.awsr.GetVolumes(ctx, input)
@@ -1526,7 +1527,7 @@ func efsFileSystems(ctx context.Context, a *aws, resourceType string, filters *f
 
 func eips(ctx context.Context, a *aws, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
 	var input = &ec2.DescribeAddressesInput{
-		Filters: toEC2Filters(filters),
+		Filters: toEC2Filters(a, resourceType, filters),
 	}
 	eips, err := a.awsr.GetAddresses(ctx, input)
 	if err != nil {
@@ -2307,7 +2308,7 @@ func iamUserSSHKeys(ctx context.Context, a *aws, resourceType string, filters *f
 
 func instances(ctx context.Context, a *aws, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
 	var input = &ec2.DescribeInstancesInput{
-		Filters:    toEC2Filters(filters),
+		Filters:    toEC2Filters(a, resourceType, filters),
 		MaxResults: awsSDK.Int64(1000),
 	}
 
@@ -2330,7 +2331,7 @@ func instances(ctx context.Context, a *aws, resourceType string, filters *filter
 
 func internetGateways(ctx context.Context, a *aws, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
 	var input = &ec2.DescribeInternetGatewaysInput{
-		Filters: toEC2Filters(filters),
+		Filters: toEC2Filters(a, resourceType, filters),
 	}
 
 	internetGateways, err := a.awsr.GetEC2InternetGateways(ctx, input)
@@ -2352,7 +2353,7 @@ func internetGateways(ctx context.Context, a *aws, resourceType string, filters
 
 func keyPairs(ctx context.Context, a *aws, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
 	var input = &ec2.DescribeKeyPairsInput{
-		Filters: toEC2Filters(filters),
+		Filters: toEC2Filters(a, resourceType, filters),
 	}
 
 	keyPairs, err := a.awsr.GetKeyPairs(ctx, input)
@@ -2439,7 +2440,7 @@ func launchConfigurations(ctx context.Context, a *aws, resourceType string, filt
 
 func launchTemplates(ctx context.Context, a *aws, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
 	var input = &ec2.DescribeLaunchTemplatesInput{
-		Filters:    toEC2Filters(filters),
+		Filters:    toEC2Filters(a, resourceType, filters),
 		MaxResults: awsSDK.Int64(200),
 	}
 
@@ -2586,7 +2587,7 @@ func mqBrokers(ctx context.Context, a *aws, resourceType string, filters *filter
 
 func natGateways(ctx context.Context, a *aws, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
 	var input = &ec2.DescribeNatGatewaysInput{
-		Filter: toEC2Filters(filters),
+		Filter: toEC2Filters(a, resourceType, filters),
 	}
 
 	natGateways, err := a.awsr.GetEC2NatGateways(ctx, input)
@@ -2894,7 +2895,7 @@ func s3Buckets(ctx context.Context, a *aws, resourceType string, filters *filter
 
 func securityGroups(ctx context.Context, a *aws, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
 	var input = &ec2.DescribeSecurityGroupsInput{
-		Filters: toEC2Filters(filters),
+		Filters: toEC2Filters(a, resourceType, filters),
 	}
 
 	sgs, err := a.awsr.GetSecurityGroups(ctx, input)
@@ -3158,7 +3159,7 @@ func storagegatewayGateways(ctx context.Context, a *aws, resourceType string, fi
 
 func subnets(ctx context.Context, a *aws, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
 	var input = &ec2.DescribeSubnetsInput{
-		Filters: toEC2Filters(filters),
+		Filters: toEC2Filters(a, resourceType, filters),
 	}
 
 	subnets, err := a.awsr.GetSubnets(ctx, input)
@@ -3185,7 +3186,7 @@ func volumeAttachments(ctx context.Context, a *aws, resourceType string, filters
 	}
 
 	var input = &ec2.DescribeVolumesInput{
-		Filters: toEC2Filters(filters),
+		Filters: toEC2Filters(a, resourceType, filters),
 	}
 
 	volumes, err := a.awsr.GetVolumes(ctx, input)
@@ -3209,7 +3210,7 @@ func volumeAttachments(ctx context.Context, a *aws, resourceType string, filters
 
 func vpcPeeringConnections(ctx context.Context, a *aws, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
 	var input = &ec2.DescribeVpcPeeringConnectionsInput{
-		Filters: toEC2Filters(filters),
+		Filters: toEC2Filters(a, resourceType, filters),
 	}
 
 	vpcPeeringConnections, err := a.awsr.GetVpcPeeringConnections(ctx, input)
@@ -3232,7 +3233,7 @@ func vpcPeeringConnections(ctx context.Context, a *aws, resourceType string, fil
 
 func vpcs(ctx context.Context, a *aws, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
 	var input = &ec2.DescribeVpcsInput{
-		Filters: toEC2Filters(filters),
+		Filters: toEC2Filters(a, resourceType, filters),
 	}
 
 	vpcs, err := a.awsr.GetVpcs(ctx, input)
@@ -3254,7 +3255,7 @@ func vpcs(ctx context.Context, a *aws, resourceType string, filters *filter.Filt
 
 func vpcEndpoints(ctx context.Context, a *aws, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
 	var input = &ec2.DescribeVpcEndpointsInput{
-		Filters:    toEC2Filters(filters),
+		Filters:    toEC2Filters(a, resourceType, filters),
 		MaxResults: awsSDK.Int64(1000),
 	}
 
@@ -3277,7 +3278,7 @@ func vpcEndpoints(ctx context.Context, a *aws, resourceType string, filters *fil
 
 func vpnGateways(ctx context.Context, a *aws, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
 	var input = &ec2.DescribeVpnGatewaysInput{
-		Filters: toEC2Filters(filters),
+		Filters: toEC2Filters(a, resourceType, filters),
 	}
 
 	vpnGateways, err := a.awsr.GetVPNGateways(ctx, input)
@@ -3297,30 +3298,51 @@ func vpnGateways(ctx context.Context, a *aws, resourceType string, filters *filt
 	return resources, nil
 }
 
-func toEC2Filters(filters *filter.Filter) []*ec2.Filter {
-	tags := filters.Tags
-	if len(tags) == 0 {
+// toEC2Filters returns the filters of the tag conditions of the filters, and of the
+// prefix of the NamePattern if the resourceType is named by the 'Name' tag. The
+// resources are then checked with all the filters once read
+func toEC2Filters(a *aws, resourceType string, filters *filter.Filter) []*ec2.Filter {
+	conditions := filters.TagConditions()
+	if p := filters.NamePrefix(); p != "" && !strings.ContainsAny(p, `*?\`) && isNamedByTag(a.tfProvider, resourceType) {
+		conditions = append(conditions, tag.Condition{Name: "Name", Values: []string{p + "*"}})
+	}
+	if len(conditions) == 0 {
 		return nil
 	}
-	filtersEc2 := make([]*ec2.Filter, 0, len(tags))
+	filtersEc2 := make([]*ec2.Filter, 0, len(conditions))
 
-	for _, t := range tags {
-		filtersEc2 = append(filtersEc2, t.ToEC2Filter())
+	for _, c := range conditions {
+		filtersEc2 = append(filtersEc2, c.ToEC2Filter())
 	}
 
 	return filtersEc2
 }
 
+// toRedshiftTag returns the keys and values of the tag conditions of the filters, Redshift
+// returns the resources with any of them so they are checked with all the tags filters
+// once read. The values are only used if all the conditions have values without globs
 func toRedshiftTag(filters *filter.Filter) ([]*string, []*string) {
-	tags := filters.Tags
-	if len(tags) == 0 {
+	conditions := filters.TagConditions()
+	if len(conditions) == 0 {
 		return nil, nil
 	}
-	filtersRedshiftTagKey := make([]*string, 0, len(tags))
-	filtersRedshiftTagValue := make([]*string, 0, len(tags))
-	for _, t := range tags {
-		filtersRedshiftTagKey = append(filtersRedshiftTagKey, &t.Name)
-		filtersRedshiftTagValue = append(filtersRedshiftTagValue, &t.Value)
+	filtersRedshiftTagKey := make([]*string, 0, len(conditions))
+	filtersRedshiftTagValue := make([]*string, 0, len(conditions))
+	withValues := true
+	for _, c := range conditions {
+		filtersRedshiftTagKey = append(filtersRedshiftTagKey, awsSDK.String(c.Name))
+		if len(c.Values) == 0 {
+			withValues = false
+		}
+		for _, v := range c.Values {
+			if tag.IsGlob(v) {
+				withValues = false
+			}
+			filtersRedshiftTagValue = append(filtersRedshiftTagValue, awsSDK.String(v))
+		}
+	}
+	if !withValues {
+		return filtersRedshiftTagKey, nil
 	}
 	return filtersRedshiftTagKey, filtersRedshiftTagValue
 }
@@ -40,7 +40,7 @@ func cacheVirtualNetworks(ctx context.Context, a *azurerm, ar *AzureReader, rt s
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -73,7 +73,7 @@ func cacheSecurityGroups(ctx context.Context, a *azurerm, ar *AzureReader, rt st
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -106,7 +106,7 @@ func cacheRouteTables(ctx context.Context, a *azurerm, ar *AzureReader, rt strin
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -140,7 +140,7 @@ func cacheVirtualHubs(ctx context.Context, a *azurerm, ar *AzureReader, rt strin
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -174,7 +174,7 @@ func cacheLbs(ctx context.Context, a *azurerm, ar *AzureReader, rt string, filte
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -212,7 +212,7 @@ func cacheVirtualMachines(ctx context.Context, a *azurerm, ar *AzureReader, rtLi
 			}
 
 			err = a.cache.Set(rt, rs)
-			if err != nil {
+			if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 				return nil, err
 			}
 			resources = append(resources, rs...)
@@ -249,7 +249,7 @@ func cacheVirtualMachineScaleSets(ctx context.Context, a *azurerm, ar *AzureRead
 			}
 
 			err = a.cache.Set(rt, rs)
-			if err != nil {
+			if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 				return nil, err
 			}
 		}
@@ -285,7 +285,7 @@ func cacheWorkflows(ctx context.Context, a *azurerm, ar *AzureReader, rt string,
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -321,7 +321,7 @@ func cacheContainerRegistries(ctx context.Context, a *azurerm, ar *AzureReader,
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -357,7 +357,7 @@ func cacheKubernetesClusters(ctx context.Context, a *azurerm, ar *AzureReader, r
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -393,7 +393,7 @@ func cacheStorageAccounts(ctx context.Context, a *azurerm, ar *AzureReader, rt s
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -429,7 +429,7 @@ func cacheMariadbServers(ctx context.Context, a *azurerm, ar *AzureReader, rt st
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -463,7 +463,7 @@ func cacheMysqlServers(ctx context.Context, a *azurerm, ar *AzureReader, rt stri
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -497,7 +497,7 @@ func cachePostgresqlServers(ctx context.Context, a *azurerm, ar *AzureReader, rt
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -531,7 +531,7 @@ func cacheMsSQLServers(ctx context.Context, a *azurerm, ar *AzureReader, rt stri
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -566,7 +566,7 @@ func cacheRedisCaches(ctx context.Context, a *azurerm, ar *AzureReader, rt strin
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -603,7 +603,7 @@ func cacheDNSZones(ctx context.Context, a *azurerm, ar *AzureReader, rt string,
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -640,7 +640,7 @@ func cachePrivateDNSZones(ctx context.Context, a *azurerm, ar *AzureReader, rt s
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -676,7 +676,7 @@ func cacheApplicationInsights(ctx context.Context, a *azurerm, ar *AzureReader,
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -712,7 +712,7 @@ func cachelogAnalyticsWorkspaces(ctx context.Context, a *azurerm, ar *AzureReade
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -750,7 +750,7 @@ func cacheWebApps(ctx context.Context, a *azurerm, ar *AzureReader, rtList []str
 			}
 
 			err = a.cache.Set(rt, rs)
-			if err != nil {
+			if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 				return nil, err
 			}
 			resources = append(resources, rs...)
@@ -788,7 +788,7 @@ func cacheServicePlans(ctx context.Context, a *azurerm, ar *AzureReader, rt stri
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -825,7 +825,7 @@ func cacheStaticSites(ctx context.Context, a *azurerm, ar *AzureReader, rt strin
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -861,7 +861,7 @@ func cacheDataProtectionBackupVaults(ctx context.Context, a *azurerm, ar *AzureR
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -897,7 +897,7 @@ func cacheRecoveryServicesVaults(ctx context.Context, a *azurerm, ar *AzureReade
 		}
 
 		err = a.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
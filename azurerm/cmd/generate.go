@@ -26,6 +26,7 @@ var azureAPIs = []AzureAPI{
 	{API: "dns", APIVersion: "2018-05-01", AddAPISufix: true},
 	{API: "privatedns", APIVersion: "2018-09-01", AddAPISufix: true},
 	{API: "policy", OtherPath: "resources/mgmt", APIVersion: "2021-06-01-preview", AddAPISufix: true, IsPreview: true},
+	{API: "authorization", APIVersion: "2020-10-01", AddAPISufix: true},
 	{API: "policyinsights", APIVersion: "2020-07-01-preview", AddAPISufix: true, IsPreview: true},
 	{API: "keyvault", APIVersion: "2020-04-01-preview", IsPreview: true},                                                                       // used for keyvault resources
 	{API: "insights", OtherPath: "appinsights/mgmt", APIVersion: "2020-02-02", AddAPISufix: true},                                              // used for  app insights resources
@@ -496,6 +497,26 @@ var functions = []Function{
 			Type: "*int32",
 		},
 	}},
+	{ResourceName: "Assignment", API: "policy", ResourceGroup: false, ExtraArgs: []Arg{
+		{
+			Name: "filter",
+			Type: "string",
+		},
+		{
+			Name: "top",
+			Type: "*int32",
+		},
+	}},
+	{ResourceName: "RoleDefinition", API: "authorization", ResourceGroup: false, ExtraArgs: []Arg{
+		{
+			Name: "scope",
+			Type: "string",
+		},
+		{
+			Name: "filter",
+			Type: "string",
+		},
+	}},
 	{ResourceName: "Remediation", API: "policyinsights", AzureSDKListFunction: "ListForResourceGroup", ResourceGroup: true, Subscription: true, ExtraArgs: []Arg{
 		{
 			Name: "top",
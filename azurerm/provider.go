@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/Azure/go-autorest/autorest"
 	autorestAzure "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-cty/cty/gocty"
@@ -20,6 +22,7 @@ import (
 	"github.com/cycloidio/terracognita/filter"
 	"github.com/cycloidio/terracognita/log"
 	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/util"
 )
 
 // version of the Terraform provider, this is automatically changed with the 'make update-terraform-provider'
@@ -37,19 +40,94 @@ var skippableCodes = map[string]struct{}{
 type azurerm struct {
 	tfAzureRMClient interface{}
 	tfProvider      *schema.Provider
+	grpcClient      *provider.GRPCClient
 	azurerReaders   []*AzureReader
 
+	// alias is the alias of the provider when
+	// importing from more than one subscription
+	alias string
+
 	configuraiton map[string]interface{}
 
 	cache cache.Cache
 }
 
+// Options are the options to initialize
+// an AzureRM Provider with NewProviderWithOptions
+type Options struct {
+	ClientID       string
+	ClientSecret   string
+	Environment    string
+	SubscriptionID string
+	TenantID       string
+
+	// ResourceGroupNames to import from, if AllResourceGroups
+	// is set all the ones of the subscription are imported
+	ResourceGroupNames []string
+	AllResourceGroups  bool
+
+	// Alias of the provider so it can be used along with
+	// other ones, like the ones of other subscriptions.
+	// The aliased providers have the SubscriptionID
+	// on the 'provider' block
+	Alias string
+
+	// RateLimiter limits the requests to each Azure resource provider,
+	// it can be shared by the providers of the same subscription.
+	// If it's nil one with the RateLimitsDefault is used
+	RateLimiter *util.RateLimiter
+}
+
+// RateLimitsDefault are the requests per second to each Azure resource
+// provider (ex: compute of Microsoft.Compute), the reads of the Azure
+// Resource Manager are limited by subscription and region
+var RateLimitsDefault = map[string]float64{
+	util.RateLimitAll: 10,
+}
+
 // NewProvider returns a AzureRM Provider
 func NewProvider(ctx context.Context, clientID, clientSecret, environment string, resourceGroupNames []string, subscriptionID, tenantID string) (provider.Provider, error) {
+	return NewProviderWithOptions(ctx, Options{
+		ClientID:           clientID,
+		ClientSecret:       clientSecret,
+		Environment:        environment,
+		SubscriptionID:     subscriptionID,
+		TenantID:           tenantID,
+		ResourceGroupNames: resourceGroupNames,
+	})
+}
+
+// SubscriptionAlias returns the alias of the provider of the subscription id,
+// it has a prefix as the alias can not start with a number
+// (ex: 0000-1111 is subscription_0000_1111)
+func SubscriptionAlias(id string) string {
+	return "subscription_" + strings.ReplaceAll(id, "-", "_")
+}
+
+// NewProviderWithOptions returns an AzureRM Provider initialized with the opts
+func NewProviderWithOptions(ctx context.Context, opts Options) (provider.Provider, error) {
+	resourceGroupNames := opts.ResourceGroupNames
+	if opts.AllResourceGroups {
+		log.Get().Log("func", "azurerm.NewProvider", "msg", "listing the resource groups")
+		var err error
+		resourceGroupNames, err = ResourceGroupNames(ctx, opts.ClientID, opts.ClientSecret, opts.Environment, opts.SubscriptionID, opts.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("could not list the resource groups: %s", err)
+		}
+	}
+	if len(resourceGroupNames) == 0 {
+		return nil, fmt.Errorf("no resource groups to import from on the subscription %s", opts.SubscriptionID)
+	}
+
+	rl := opts.RateLimiter
+	if rl == nil {
+		rl = util.NewRateLimiter(RateLimitsDefault, nil)
+	}
+
 	readers := make([]*AzureReader, 0, len(resourceGroupNames))
 	log.Get().Log("func", "azurerm.NewProvider", "msg", "loading Azure reader")
 	for _, rgn := range resourceGroupNames {
-		reader, err := NewAzureReader(ctx, clientID, clientSecret, environment, rgn, subscriptionID, tenantID)
+		reader, err := NewAzureReader(ctx, opts.ClientID, opts.ClientSecret, opts.Environment, rgn, opts.SubscriptionID, opts.TenantID, rl)
 		if err != nil {
 			return nil, fmt.Errorf("could not initialize AzureReader: %s", err)
 		}
@@ -60,11 +138,11 @@ func NewProvider(ctx context.Context, clientID, clientSecret, environment string
 	tfp := tfazurerm.AzureProvider()
 
 	rawCfg := terraform.NewResourceConfigRaw(map[string]interface{}{
-		"client_id":       clientID,
-		"client_secret":   clientSecret,
-		"environment":     environment,
-		"subscription_id": subscriptionID,
-		"tenant_id":       tenantID,
+		"client_id":       opts.ClientID,
+		"client_secret":   opts.ClientSecret,
+		"environment":     opts.Environment,
+		"subscription_id": opts.SubscriptionID,
+		"tenant_id":       opts.TenantID,
 	})
 
 	log.Get().Log("func", "azurerm.NewProvider", "msg", "loading TF client")
@@ -72,14 +150,23 @@ func NewProvider(ctx context.Context, clientID, clientSecret, environment string
 		return nil, fmt.Errorf("could not initialize 'terraform/azurerm.Provider.Configure()' because: %s", diags[0].Summary)
 	}
 
+	configuration := map[string]interface{}{
+		"environment": opts.Environment,
+	}
+	// The default provider has the subscription from
+	// the ENV, the aliased ones need it to differ
+	if opts.Alias != "" {
+		configuration["subscription_id"] = opts.SubscriptionID
+	}
+
 	return &azurerm{
 		tfAzureRMClient: tfp.Meta(),
 		tfProvider:      tfp,
+		grpcClient:      provider.NewGRPCClient(tfp),
 		azurerReaders:   readers,
+		alias:           opts.Alias,
 		cache:           cache.New(),
-		configuraiton: map[string]interface{}{
-			"environment": environment,
-		},
+		configuraiton:   configuration,
 	}, nil
 }
 func (a *azurerm) HasResourceType(t string) bool {
@@ -89,6 +176,7 @@ func (a *azurerm) HasResourceType(t string) bool {
 
 func (a *azurerm) Region() string                        { return a.azurerReaders[0].GetLocation() }
 func (a *azurerm) String() string                        { return "azurerm" }
+func (a *azurerm) Alias() string                         { return a.alias }
 func (a *azurerm) TagKey() string                        { return "tags" }
 func (a *azurerm) Source() string                        { return "hashicorp/azurerm" }
 func (a *azurerm) Version() string                       { return version }
@@ -109,8 +197,15 @@ func (a *azurerm) Resources(ctx context.Context, t string, f *filter.Filter) ([]
 		return nil, errors.Errorf("the resource %q it's not implemented", t)
 	}
 
+	// The ones of the subscription are the
+	// same from all the resource groups
+	readers := a.azurerReaders
+	if _, ok := subscriptionTypes[rt]; ok {
+		readers = readers[:1]
+	}
+
 	resources := make([]provider.Resource, 0, 0)
-	for _, ar := range a.azurerReaders {
+	for _, ar := range readers {
 		nres, err := rfn(ctx, a, ar, t, f)
 		if err != nil {
 			// we filter the error from Azure and return a custom error
@@ -148,6 +243,10 @@ func (a *azurerm) TFProvider() *schema.Provider {
 	return a.tfProvider
 }
 
+func (a *azurerm) GRPCClient() *provider.GRPCClient {
+	return a.grpcClient
+}
+
 func (a *azurerm) FixResource(t string, v cty.Value) (cty.Value, error) {
 	var err error
 	switch t {
@@ -349,3 +448,23 @@ func (a *azurerm) FixResource(t string, v cty.Value) (cty.Value, error) {
 	return v, nil
 }
 func (a *azurerm) FilterByTags(tags interface{}) error { return nil }
+
+// Retryable retries the Azure errors that are
+// throttling or temporary of the server
+func (a *azurerm) Retryable(err error) (bool, time.Duration) {
+	var derr autorest.DetailedError
+	if !errors.As(err, &derr) {
+		return false, 0
+	}
+
+	code, ok := derr.StatusCode.(int)
+	if !ok || !util.IsRetryableHTTPStatus(code) {
+		return false, 0
+	}
+
+	if derr.Response != nil {
+		return true, util.RetryAfter(derr.Response.Header)
+	}
+
+	return true, 0
+}
@@ -3,12 +3,18 @@ package azurerm
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sort"
+	"strings"
 
 	azureResourcesAPI "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	azureSubscriptionsAPI "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2021-01-01/subscriptions"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/go-azure-helpers/authentication"
 	"github.com/hashicorp/go-azure-helpers/sender"
+
+	"github.com/cycloidio/terracognita/util"
 )
 
 //go:generate go run ./cmd
@@ -22,8 +28,100 @@ type AzureReader struct {
 	resourceGroup azureResourcesAPI.Group
 }
 
-// NewAzureReader returns a AzureReader
-func NewAzureReader(ctx context.Context, clientID, clientSecret, environment, resourceGroupName, subscriptionID, tenantID string) (*AzureReader, error) {
+// NewAzureReader returns a AzureReader, if the rl
+// is not nil each request waits for it
+func NewAzureReader(ctx context.Context, clientID, clientSecret, environment, resourceGroupName, subscriptionID, tenantID string, rl *util.RateLimiter) (*AzureReader, error) {
+	cfg, auth, env, err := newAuthorizer(ctx, clientID, clientSecret, environment, subscriptionID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resource Group
+	client := azureResourcesAPI.NewGroupsClientWithBaseURI(env.ResourceManagerEndpoint, cfg.SubscriptionID)
+	client.Authorizer = auth
+	resourceGroup, err := client.Get(ctx, resourceGroupName)
+	if err != nil {
+		return nil, fmt.Errorf("could not 'azure/resources.GroupsClient.Get' the resource group because: %s", err)
+	}
+
+	if rl != nil {
+		auth = rateLimitAuthorizer{Authorizer: auth, rl: rl}
+	}
+
+	return &AzureReader{
+		config:        *cfg,
+		authorizer:    auth,
+		resourceGroup: resourceGroup,
+		env:           env,
+	}, nil
+}
+
+// ResourceGroupNames returns the names of all the resource groups of the subscription
+func ResourceGroupNames(ctx context.Context, clientID, clientSecret, environment, subscriptionID, tenantID string) ([]string, error) {
+	cfg, auth, env, err := newAuthorizer(ctx, clientID, clientSecret, environment, subscriptionID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	client := azureResourcesAPI.NewGroupsClientWithBaseURI(env.ResourceManagerEndpoint, cfg.SubscriptionID)
+	client.Authorizer = auth
+	output, err := client.List(ctx, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not 'azure/resources.GroupsClient.List' the resource groups because: %s", err)
+	}
+
+	var names []string
+	for output.NotDone() {
+		for _, g := range output.Values() {
+			names = append(names, *g.Name)
+		}
+
+		if err := output.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("could not 'azure/resources.GroupsClient.List' the resource groups because: %s", err)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Subscriptions returns the IDs of all the enabled
+// subscriptions that are accessible with the credentials
+func Subscriptions(ctx context.Context, clientID, clientSecret, environment, tenantID string) ([]string, error) {
+	_, auth, env, err := newAuthorizer(ctx, clientID, clientSecret, environment, "", tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	client := azureSubscriptionsAPI.NewClientWithBaseURI(env.ResourceManagerEndpoint)
+	client.Authorizer = auth
+	output, err := client.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not 'azure/subscriptions.Client.List' the subscriptions because: %s", err)
+	}
+
+	var ids []string
+	for output.NotDone() {
+		for _, s := range output.Values() {
+			if s.State == azureSubscriptionsAPI.StateEnabled {
+				ids = append(ids, *s.SubscriptionID)
+			}
+		}
+
+		if err := output.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("could not 'azure/subscriptions.Client.List' the subscriptions because: %s", err)
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// newAuthorizer returns the Config, Authorizer and Environment to use the
+// Azure APIs, if the subscriptionID is empty it's only of the tenant
+func newAuthorizer(ctx context.Context, clientID, clientSecret, environment, subscriptionID, tenantID string) (*authentication.Config, autorest.Authorizer, *azure.Environment, error) {
 	// Config
 	cfgBuilder := &authentication.Builder{
 		ClientID:       clientID,
@@ -31,51 +129,39 @@ func NewAzureReader(ctx context.Context, clientID, clientSecret, environment, re
 		Environment:    environment,
 		SubscriptionID: subscriptionID,
 		TenantID:       tenantID,
+		TenantOnly:     subscriptionID == "",
 
 		SupportsClientSecretAuth: true,
 	}
 
 	cfg, err := cfgBuilder.Build()
 	if err != nil {
-		return nil, fmt.Errorf("could not build 'azure/authentication.Config' because: %s", err)
+		return nil, nil, nil, fmt.Errorf("could not build 'azure/authentication.Config' because: %s", err)
 	}
 
 	// Authorizer
 	env, err := authentication.DetermineEnvironment(cfg.Environment)
 	if err != nil {
-		return nil, fmt.Errorf("could not initialize 'azure.Environment.' because: %s", err)
+		return nil, nil, nil, fmt.Errorf("could not initialize 'azure.Environment.' because: %s", err)
 	}
 
 	oauthConfig, err := cfg.BuildOAuthConfig(env.ActiveDirectoryEndpoint)
 	if err != nil {
-		return nil, fmt.Errorf("could not initialize 'azure/authentication.OAuthConfig.' because: %s", err)
+		return nil, nil, nil, fmt.Errorf("could not initialize 'azure/authentication.OAuthConfig.' because: %s", err)
 	}
 	// OAuthConfigForTenant returns a pointer, which can be nil.
 	if oauthConfig == nil {
-		return nil, fmt.Errorf("could not configure OAuthConfig for tenant %s", cfg.TenantID)
+		return nil, nil, nil, fmt.Errorf("could not configure OAuthConfig for tenant %s", cfg.TenantID)
 	}
 
 	azureSender := sender.BuildSender("AzureRM")
 
 	auth, err := cfg.GetADALToken(ctx, azureSender, oauthConfig, env.ResourceManagerEndpoint)
 	if err != nil {
-		return nil, fmt.Errorf("could not initialize 'azure/autorest.Authorizer.' because: %s", err)
-	}
-
-	// Resource Group
-	client := azureResourcesAPI.NewGroupsClientWithBaseURI(env.ResourceManagerEndpoint, cfg.SubscriptionID)
-	client.Authorizer = auth
-	resourceGroup, err := client.Get(ctx, resourceGroupName)
-	if err != nil {
-		return nil, fmt.Errorf("could not 'azure/resources.GroupsClient.Get' the resource group because: %s", err)
+		return nil, nil, nil, fmt.Errorf("could not initialize 'azure/autorest.Authorizer.' because: %s", err)
 	}
 
-	return &AzureReader{
-		config:        *cfg,
-		authorizer:    auth,
-		resourceGroup: resourceGroup,
-		env:           env,
-	}, nil
+	return cfg, auth, env, nil
 }
 
 // GetResourceGroup returns the current Resource Group resource
@@ -92,3 +178,43 @@ func (ar *AzureReader) GetResourceGroupName() string {
 func (ar *AzureReader) GetLocation() string {
 	return *ar.resourceGroup.Location
 }
+
+// GetSubscriptionID returns the ID of the subscription
+func (ar *AzureReader) GetSubscriptionID() string {
+	return ar.config.SubscriptionID
+}
+
+// rateLimitAuthorizer waits for the rl before authorizing
+// each request, as the authorizer is used by all the clients
+type rateLimitAuthorizer struct {
+	autorest.Authorizer
+	rl *util.RateLimiter
+}
+
+func (a rateLimitAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return a.Authorizer.WithAuthorization()(autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			if err := a.rl.Wait(r.Context(), resourceProvider(r.URL.Path)); err != nil {
+				return r, err
+			}
+			return p.Prepare(r)
+		}))
+	}
+}
+
+// resourceProvider returns the resource provider of the path of an Azure Resource
+// Manager URL (ex: compute of /subscriptions/id/providers/Microsoft.Compute/...),
+// the ones without it are of the resources (ex: the resource groups)
+func resourceProvider(path string) string {
+	const prefix = "/providers/microsoft."
+	lp := strings.ToLower(path)
+	i := strings.Index(lp, prefix)
+	if i == -1 {
+		return "resources"
+	}
+	rp := lp[i+len(prefix):]
+	if j := strings.Index(rp, "/"); j != -1 {
+		rp = rp[:j]
+	}
+	return rp
+}
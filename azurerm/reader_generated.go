@@ -8,6 +8,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/apimanagement/mgmt/2021-08-01/apimanagement"
 	"github.com/Azure/azure-sdk-for-go/services/appinsights/mgmt/2020-02-02/insights"
+	"github.com/Azure/azure-sdk-for-go/services/authorization/mgmt/2020-10-01/authorization"
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-12-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/containerregistry/mgmt/2019-05-01/containerregistry"
 	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-01-01/containerservice"
@@ -1943,6 +1944,56 @@ func (ar *AzureReader) ListPOLICYSetDefinitions(ctx context.Context, filter stri
 
 }
 
+// ListPOLICYAssignments returns a list of Assignments within a subscription
+func (ar *AzureReader) ListPOLICYAssignments(ctx context.Context, filter string, top *int32) ([]policy.Assignment, error) {
+	client := policy.NewAssignmentsClientWithBaseURI(ar.env.ResourceManagerEndpoint, ar.config.SubscriptionID)
+	client.Authorizer = ar.authorizer
+
+	output, err := client.List(ctx, filter, top)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list policy.Assignment from Azure APIs")
+	}
+
+	resources := make([]policy.Assignment, 0)
+	for output.NotDone() {
+
+		for _, res := range output.Values() {
+			resources = append(resources, res)
+		}
+
+		if err := output.NextWithContext(ctx); err != nil {
+			break
+		}
+	}
+	return resources, nil
+
+}
+
+// ListAUTHORIZATIONRoleDefinitions returns a list of RoleDefinitions within a subscription
+func (ar *AzureReader) ListAUTHORIZATIONRoleDefinitions(ctx context.Context, scope string, filter string) ([]authorization.RoleDefinition, error) {
+	client := authorization.NewRoleDefinitionsClientWithBaseURI(ar.env.ResourceManagerEndpoint, ar.config.SubscriptionID)
+	client.Authorizer = ar.authorizer
+
+	output, err := client.List(ctx, scope, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list authorization.RoleDefinition from Azure APIs")
+	}
+
+	resources := make([]authorization.RoleDefinition, 0)
+	for output.NotDone() {
+
+		for _, res := range output.Values() {
+			resources = append(resources, res)
+		}
+
+		if err := output.NextWithContext(ctx); err != nil {
+			break
+		}
+	}
+	return resources, nil
+
+}
+
 // ListPOLICYINSIGHTSRemediations returns a list of Remediations within a subscription and a resource group
 func (ar *AzureReader) ListPOLICYINSIGHTSRemediations(ctx context.Context, top *int32, filter string) ([]policyinsights.Remediation, error) {
 	client := policyinsights.NewRemediationsClientWithBaseURI(ar.env.ResourceManagerEndpoint, ar.config.SubscriptionID)
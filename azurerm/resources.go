@@ -183,6 +183,9 @@ const (
 	BackupPolicyVM
 	BackupProtectedVM
 	BackupPolicyVMWorkload
+	// Subscription
+	SubscriptionPolicyAssignment
+	RoleDefinition
 )
 
 type rtFn func(ctx context.Context, a *azurerm, ar *AzureReader, resourceType string, filters *filter.Filter) ([]provider.Resource, error)
@@ -354,6 +357,17 @@ var (
 		BackupPolicyVM:         backupPolicyVMs,
 		BackupProtectedVM:      backupProtectedVMs,
 		BackupPolicyVMWorkload: backupPolicyVMWorkloads,
+		// Subscription
+		SubscriptionPolicyAssignment: subscriptionPolicyAssignments,
+		RoleDefinition:               roleDefinitions,
+	}
+
+	// subscriptionTypes are the resource types that are of the
+	// subscription and not of the resource groups, so they
+	// are only read once and not from each resource group
+	subscriptionTypes = map[ResourceType]struct{}{
+		SubscriptionPolicyAssignment: struct{}{},
+		RoleDefinition:               struct{}{},
 	}
 )
 
@@ -364,21 +378,18 @@ func resourceGroup(ctx context.Context, a *azurerm, ar *AzureReader, resourceTyp
 	return resources, nil
 }
 
+// filterByTags checks if the tags match all the tags filters of the f
 func filterByTags(f *filter.Filter, tags map[string]*string) bool {
-	if len(f.Tags) == 0 {
+	if !f.HasTags() {
 		return true
 	}
-	for _, t := range f.Tags {
-		if v, ok := tags[t.Name]; ok {
-			if v == nil {
-				continue
-			}
-			if *v == t.Value {
-				return true
-			}
+	ts := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if v != nil {
+			ts[k] = *v
 		}
 	}
-	return false
+	return f.MatchTags(ts)
 }
 
 // Compute Resources
@@ -2867,3 +2878,40 @@ func backupPolicyVMWorkloads(ctx context.Context, a *azurerm, ar *AzureReader, r
 	}
 	return resources, nil
 }
+
+// Subscription
+
+func subscriptionPolicyAssignments(ctx context.Context, a *azurerm, ar *AzureReader, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
+	policyAssignments, err := ar.ListPOLICYAssignments(ctx, "atScope()", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list Policy Assignments from reader")
+	}
+	// The atScope() also has the ones inherited from
+	// the management groups, which are not of the subscription
+	scope := fmt.Sprintf("/subscriptions/%s/providers/", ar.GetSubscriptionID())
+	resources := make([]provider.Resource, 0, len(policyAssignments))
+	for _, policyAssignment := range policyAssignments {
+		if !strings.HasPrefix(strings.ToLower(*policyAssignment.ID), strings.ToLower(scope)) {
+			continue
+		}
+		r := provider.NewResource(*policyAssignment.ID, resourceType, a)
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+func roleDefinitions(ctx context.Context, a *azurerm, ar *AzureReader, resourceType string, filters *filter.Filter) ([]provider.Resource, error) {
+	scope := fmt.Sprintf("/subscriptions/%s", ar.GetSubscriptionID())
+	// Only the custom ones as the built-in can not be managed
+	roleDefinitions, err := ar.ListAUTHORIZATIONRoleDefinitions(ctx, scope, "type eq 'CustomRole'")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list Role Definitions from reader")
+	}
+	resources := make([]provider.Resource, 0, len(roleDefinitions))
+	for _, roleDefinition := range roleDefinitions {
+		// The ID has the scope as it can not be read from the API
+		r := provider.NewResource(fmt.Sprintf("%s|%s", *roleDefinition.ID, scope), resourceType, a)
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _ResourceTypeName = "azurerm_resource_groupazurerm_availability_setazurerm_imageazurerm_managed_diskazurerm_virtual_machineazurerm_virtual_machine_data_disk_attachmentazurerm_virtual_machine_extensionazurerm_virtual_machine_scale_set_extensionazurerm_virtual_networkazurerm_linux_virtual_machineazurerm_linux_virtual_machine_scale_setazurerm_windows_virtual_machineazurerm_windows_virtual_machine_scale_setazurerm_subnetazurerm_network_interfaceazurerm_network_interface_security_group_associationazurerm_network_security_groupazurerm_application_gatewayazurerm_application_security_groupazurerm_network_ddos_protection_planazurerm_firewallazurerm_local_network_gatewayazurerm_nat_gatewayazurerm_network_profileazurerm_network_security_ruleazurerm_public_ipazurerm_public_ip_prefixazurerm_routeazurerm_route_tableazurerm_virtual_network_gatewayazurerm_virtual_network_gateway_connectionazurerm_virtual_network_peeringazurerm_web_application_firewall_policyazurerm_virtual_hubazurerm_virtual_hub_bgp_connectionazurerm_virtual_hub_connectionazurerm_virtual_hub_ipazurerm_virtual_hub_route_tableazurerm_virtual_hub_security_partner_providerazurerm_lbazurerm_lb_backend_address_poolazurerm_lb_ruleazurerm_lb_outbound_ruleazurerm_lb_nat_ruleazurerm_lb_nat_poolazurerm_lb_probeazurerm_virtual_desktop_host_poolazurerm_virtual_desktop_application_groupazurerm_logic_app_workflowazurerm_logic_app_trigger_customazurerm_logic_app_action_customazurerm_container_registryazurerm_container_registry_webhookazurerm_kubernetes_clusterazurerm_kubernetes_cluster_node_poolazurerm_storage_accountazurerm_storage_queueazurerm_storage_shareazurerm_storage_tableazurerm_storage_blobazurerm_mariadb_configurationazurerm_mariadb_databaseazurerm_mariadb_firewall_ruleazurerm_mariadb_serverazurerm_mariadb_virtual_network_ruleazurerm_mysql_configurationazurerm_mysql_databaseazurerm_mysql_firewall_ruleazurerm_mysql_serverazurerm_mysql_virtual_network_ruleazurerm_postgresql_configurationazurerm_postgresql_databaseazurerm_postgresql_firewall_ruleazurerm_postgresql_serverazurerm_postgresql_virtual_network_ruleazurerm_mssql_elasticpoolazurerm_mssql_databaseazurerm_mssql_firewall_ruleazurerm_mssql_serverazurerm_mssql_server_security_alert_policyazurerm_mssql_server_vulnerability_assessmentazurerm_mssql_virtual_machineazurerm_mssql_virtual_network_ruleazurerm_redis_cacheazurerm_redis_firewall_ruleazurerm_dns_zoneazurerm_dns_a_recordazurerm_dns_aaaa_recordazurerm_dns_caa_recordazurerm_dns_cname_recordazurerm_dns_mx_recordazurerm_dns_ns_recordazurerm_dns_ptr_recordazurerm_dns_srv_recordazurerm_dns_txt_recordazurerm_private_dns_zoneazurerm_private_dns_a_recordazurerm_private_dns_aaaa_recordazurerm_private_dns_cname_recordazurerm_private_dns_mx_recordazurerm_private_dns_ptr_recordazurerm_private_dns_srv_recordazurerm_private_dns_txt_recordazurerm_private_dns_zone_virtual_network_linkazurerm_policy_definitionazurerm_policy_remediationazurerm_policy_set_definitionazurerm_key_vaultazurerm_key_vault_access_policyazurerm_application_insightsazurerm_application_insights_api_keyazurerm_application_insights_analytics_itemazurerm_log_analytics_workspaceazurerm_log_analytics_linked_serviceazurerm_log_analytics_datasource_windows_performance_counterazurerm_log_analytics_datasource_windows_eventazurerm_monitor_action_groupazurerm_monitor_activity_log_alertazurerm_monitor_autoscale_settingazurerm_monitor_log_profileazurerm_monitor_metric_alertazurerm_windows_web_appazurerm_linux_web_appazurerm_linux_web_app_slotazurerm_windows_web_app_slotazurerm_web_app_active_slotazurerm_service_planazurerm_source_control_tokenazurerm_static_siteazurerm_static_site_custom_domainazurerm_web_app_hybrid_connectionazurerm_data_protection_backup_vaultazurerm_data_protection_backup_instance_diskazurerm_data_protection_backup_policy_diskazurerm_api_managementazurerm_recovery_services_vaultazurerm_backup_policy_vmazurerm_backup_protected_vmazurerm_backup_policy_vm_workload"
+const _ResourceTypeName = "azurerm_resource_groupazurerm_availability_setazurerm_imageazurerm_managed_diskazurerm_virtual_machineazurerm_virtual_machine_data_disk_attachmentazurerm_virtual_machine_extensionazurerm_virtual_machine_scale_set_extensionazurerm_virtual_networkazurerm_linux_virtual_machineazurerm_linux_virtual_machine_scale_setazurerm_windows_virtual_machineazurerm_windows_virtual_machine_scale_setazurerm_subnetazurerm_network_interfaceazurerm_network_interface_security_group_associationazurerm_network_security_groupazurerm_application_gatewayazurerm_application_security_groupazurerm_network_ddos_protection_planazurerm_firewallazurerm_local_network_gatewayazurerm_nat_gatewayazurerm_network_profileazurerm_network_security_ruleazurerm_public_ipazurerm_public_ip_prefixazurerm_routeazurerm_route_tableazurerm_virtual_network_gatewayazurerm_virtual_network_gateway_connectionazurerm_virtual_network_peeringazurerm_web_application_firewall_policyazurerm_virtual_hubazurerm_virtual_hub_bgp_connectionazurerm_virtual_hub_connectionazurerm_virtual_hub_ipazurerm_virtual_hub_route_tableazurerm_virtual_hub_security_partner_providerazurerm_lbazurerm_lb_backend_address_poolazurerm_lb_ruleazurerm_lb_outbound_ruleazurerm_lb_nat_ruleazurerm_lb_nat_poolazurerm_lb_probeazurerm_virtual_desktop_host_poolazurerm_virtual_desktop_application_groupazurerm_logic_app_workflowazurerm_logic_app_trigger_customazurerm_logic_app_action_customazurerm_container_registryazurerm_container_registry_webhookazurerm_kubernetes_clusterazurerm_kubernetes_cluster_node_poolazurerm_storage_accountazurerm_storage_queueazurerm_storage_shareazurerm_storage_tableazurerm_storage_blobazurerm_mariadb_configurationazurerm_mariadb_databaseazurerm_mariadb_firewall_ruleazurerm_mariadb_serverazurerm_mariadb_virtual_network_ruleazurerm_mysql_configurationazurerm_mysql_databaseazurerm_mysql_firewall_ruleazurerm_mysql_serverazurerm_mysql_virtual_network_ruleazurerm_postgresql_configurationazurerm_postgresql_databaseazurerm_postgresql_firewall_ruleazurerm_postgresql_serverazurerm_postgresql_virtual_network_ruleazurerm_mssql_elasticpoolazurerm_mssql_databaseazurerm_mssql_firewall_ruleazurerm_mssql_serverazurerm_mssql_server_security_alert_policyazurerm_mssql_server_vulnerability_assessmentazurerm_mssql_virtual_machineazurerm_mssql_virtual_network_ruleazurerm_redis_cacheazurerm_redis_firewall_ruleazurerm_dns_zoneazurerm_dns_a_recordazurerm_dns_aaaa_recordazurerm_dns_caa_recordazurerm_dns_cname_recordazurerm_dns_mx_recordazurerm_dns_ns_recordazurerm_dns_ptr_recordazurerm_dns_srv_recordazurerm_dns_txt_recordazurerm_private_dns_zoneazurerm_private_dns_a_recordazurerm_private_dns_aaaa_recordazurerm_private_dns_cname_recordazurerm_private_dns_mx_recordazurerm_private_dns_ptr_recordazurerm_private_dns_srv_recordazurerm_private_dns_txt_recordazurerm_private_dns_zone_virtual_network_linkazurerm_policy_definitionazurerm_policy_remediationazurerm_policy_set_definitionazurerm_key_vaultazurerm_key_vault_access_policyazurerm_application_insightsazurerm_application_insights_api_keyazurerm_application_insights_analytics_itemazurerm_log_analytics_workspaceazurerm_log_analytics_linked_serviceazurerm_log_analytics_datasource_windows_performance_counterazurerm_log_analytics_datasource_windows_eventazurerm_monitor_action_groupazurerm_monitor_activity_log_alertazurerm_monitor_autoscale_settingazurerm_monitor_log_profileazurerm_monitor_metric_alertazurerm_windows_web_appazurerm_linux_web_appazurerm_linux_web_app_slotazurerm_windows_web_app_slotazurerm_web_app_active_slotazurerm_service_planazurerm_source_control_tokenazurerm_static_siteazurerm_static_site_custom_domainazurerm_web_app_hybrid_connectionazurerm_data_protection_backup_vaultazurerm_data_protection_backup_instance_diskazurerm_data_protection_backup_policy_diskazurerm_api_managementazurerm_recovery_services_vaultazurerm_backup_policy_vmazurerm_backup_protected_vmazurerm_backup_policy_vm_workloadazurerm_subscription_policy_assignmentazurerm_role_definition"
 
-var _ResourceTypeIndex = [...]uint16{0, 22, 46, 59, 79, 102, 146, 179, 222, 245, 274, 313, 344, 385, 399, 424, 476, 506, 533, 567, 603, 619, 648, 667, 690, 719, 736, 760, 773, 792, 823, 865, 896, 935, 954, 988, 1018, 1040, 1071, 1116, 1126, 1157, 1172, 1196, 1215, 1234, 1250, 1283, 1324, 1350, 1382, 1413, 1439, 1473, 1499, 1535, 1558, 1579, 1600, 1621, 1641, 1670, 1694, 1723, 1745, 1781, 1808, 1830, 1857, 1877, 1911, 1943, 1970, 2002, 2027, 2066, 2091, 2113, 2140, 2160, 2202, 2247, 2276, 2310, 2329, 2356, 2372, 2392, 2415, 2437, 2461, 2482, 2503, 2525, 2547, 2569, 2593, 2621, 2652, 2684, 2713, 2743, 2773, 2803, 2848, 2873, 2899, 2928, 2945, 2976, 3004, 3040, 3083, 3114, 3150, 3210, 3256, 3284, 3318, 3351, 3378, 3406, 3429, 3450, 3476, 3504, 3531, 3551, 3579, 3598, 3631, 3664, 3700, 3744, 3786, 3808, 3839, 3863, 3890, 3923}
+var _ResourceTypeIndex = [...]uint16{0, 22, 46, 59, 79, 102, 146, 179, 222, 245, 274, 313, 344, 385, 399, 424, 476, 506, 533, 567, 603, 619, 648, 667, 690, 719, 736, 760, 773, 792, 823, 865, 896, 935, 954, 988, 1018, 1040, 1071, 1116, 1126, 1157, 1172, 1196, 1215, 1234, 1250, 1283, 1324, 1350, 1382, 1413, 1439, 1473, 1499, 1535, 1558, 1579, 1600, 1621, 1641, 1670, 1694, 1723, 1745, 1781, 1808, 1830, 1857, 1877, 1911, 1943, 1970, 2002, 2027, 2066, 2091, 2113, 2140, 2160, 2202, 2247, 2276, 2310, 2329, 2356, 2372, 2392, 2415, 2437, 2461, 2482, 2503, 2525, 2547, 2569, 2593, 2621, 2652, 2684, 2713, 2743, 2773, 2803, 2848, 2873, 2899, 2928, 2945, 2976, 3004, 3040, 3083, 3114, 3150, 3210, 3256, 3284, 3318, 3351, 3378, 3406, 3429, 3450, 3476, 3504, 3531, 3551, 3579, 3598, 3631, 3664, 3700, 3744, 3786, 3808, 3839, 3863, 3890, 3923, 3961, 3984}
 
-const _ResourceTypeLowerName = "azurerm_resource_groupazurerm_availability_setazurerm_imageazurerm_managed_diskazurerm_virtual_machineazurerm_virtual_machine_data_disk_attachmentazurerm_virtual_machine_extensionazurerm_virtual_machine_scale_set_extensionazurerm_virtual_networkazurerm_linux_virtual_machineazurerm_linux_virtual_machine_scale_setazurerm_windows_virtual_machineazurerm_windows_virtual_machine_scale_setazurerm_subnetazurerm_network_interfaceazurerm_network_interface_security_group_associationazurerm_network_security_groupazurerm_application_gatewayazurerm_application_security_groupazurerm_network_ddos_protection_planazurerm_firewallazurerm_local_network_gatewayazurerm_nat_gatewayazurerm_network_profileazurerm_network_security_ruleazurerm_public_ipazurerm_public_ip_prefixazurerm_routeazurerm_route_tableazurerm_virtual_network_gatewayazurerm_virtual_network_gateway_connectionazurerm_virtual_network_peeringazurerm_web_application_firewall_policyazurerm_virtual_hubazurerm_virtual_hub_bgp_connectionazurerm_virtual_hub_connectionazurerm_virtual_hub_ipazurerm_virtual_hub_route_tableazurerm_virtual_hub_security_partner_providerazurerm_lbazurerm_lb_backend_address_poolazurerm_lb_ruleazurerm_lb_outbound_ruleazurerm_lb_nat_ruleazurerm_lb_nat_poolazurerm_lb_probeazurerm_virtual_desktop_host_poolazurerm_virtual_desktop_application_groupazurerm_logic_app_workflowazurerm_logic_app_trigger_customazurerm_logic_app_action_customazurerm_container_registryazurerm_container_registry_webhookazurerm_kubernetes_clusterazurerm_kubernetes_cluster_node_poolazurerm_storage_accountazurerm_storage_queueazurerm_storage_shareazurerm_storage_tableazurerm_storage_blobazurerm_mariadb_configurationazurerm_mariadb_databaseazurerm_mariadb_firewall_ruleazurerm_mariadb_serverazurerm_mariadb_virtual_network_ruleazurerm_mysql_configurationazurerm_mysql_databaseazurerm_mysql_firewall_ruleazurerm_mysql_serverazurerm_mysql_virtual_network_ruleazurerm_postgresql_configurationazurerm_postgresql_databaseazurerm_postgresql_firewall_ruleazurerm_postgresql_serverazurerm_postgresql_virtual_network_ruleazurerm_mssql_elasticpoolazurerm_mssql_databaseazurerm_mssql_firewall_ruleazurerm_mssql_serverazurerm_mssql_server_security_alert_policyazurerm_mssql_server_vulnerability_assessmentazurerm_mssql_virtual_machineazurerm_mssql_virtual_network_ruleazurerm_redis_cacheazurerm_redis_firewall_ruleazurerm_dns_zoneazurerm_dns_a_recordazurerm_dns_aaaa_recordazurerm_dns_caa_recordazurerm_dns_cname_recordazurerm_dns_mx_recordazurerm_dns_ns_recordazurerm_dns_ptr_recordazurerm_dns_srv_recordazurerm_dns_txt_recordazurerm_private_dns_zoneazurerm_private_dns_a_recordazurerm_private_dns_aaaa_recordazurerm_private_dns_cname_recordazurerm_private_dns_mx_recordazurerm_private_dns_ptr_recordazurerm_private_dns_srv_recordazurerm_private_dns_txt_recordazurerm_private_dns_zone_virtual_network_linkazurerm_policy_definitionazurerm_policy_remediationazurerm_policy_set_definitionazurerm_key_vaultazurerm_key_vault_access_policyazurerm_application_insightsazurerm_application_insights_api_keyazurerm_application_insights_analytics_itemazurerm_log_analytics_workspaceazurerm_log_analytics_linked_serviceazurerm_log_analytics_datasource_windows_performance_counterazurerm_log_analytics_datasource_windows_eventazurerm_monitor_action_groupazurerm_monitor_activity_log_alertazurerm_monitor_autoscale_settingazurerm_monitor_log_profileazurerm_monitor_metric_alertazurerm_windows_web_appazurerm_linux_web_appazurerm_linux_web_app_slotazurerm_windows_web_app_slotazurerm_web_app_active_slotazurerm_service_planazurerm_source_control_tokenazurerm_static_siteazurerm_static_site_custom_domainazurerm_web_app_hybrid_connectionazurerm_data_protection_backup_vaultazurerm_data_protection_backup_instance_diskazurerm_data_protection_backup_policy_diskazurerm_api_managementazurerm_recovery_services_vaultazurerm_backup_policy_vmazurerm_backup_protected_vmazurerm_backup_policy_vm_workload"
+const _ResourceTypeLowerName = "azurerm_resource_groupazurerm_availability_setazurerm_imageazurerm_managed_diskazurerm_virtual_machineazurerm_virtual_machine_data_disk_attachmentazurerm_virtual_machine_extensionazurerm_virtual_machine_scale_set_extensionazurerm_virtual_networkazurerm_linux_virtual_machineazurerm_linux_virtual_machine_scale_setazurerm_windows_virtual_machineazurerm_windows_virtual_machine_scale_setazurerm_subnetazurerm_network_interfaceazurerm_network_interface_security_group_associationazurerm_network_security_groupazurerm_application_gatewayazurerm_application_security_groupazurerm_network_ddos_protection_planazurerm_firewallazurerm_local_network_gatewayazurerm_nat_gatewayazurerm_network_profileazurerm_network_security_ruleazurerm_public_ipazurerm_public_ip_prefixazurerm_routeazurerm_route_tableazurerm_virtual_network_gatewayazurerm_virtual_network_gateway_connectionazurerm_virtual_network_peeringazurerm_web_application_firewall_policyazurerm_virtual_hubazurerm_virtual_hub_bgp_connectionazurerm_virtual_hub_connectionazurerm_virtual_hub_ipazurerm_virtual_hub_route_tableazurerm_virtual_hub_security_partner_providerazurerm_lbazurerm_lb_backend_address_poolazurerm_lb_ruleazurerm_lb_outbound_ruleazurerm_lb_nat_ruleazurerm_lb_nat_poolazurerm_lb_probeazurerm_virtual_desktop_host_poolazurerm_virtual_desktop_application_groupazurerm_logic_app_workflowazurerm_logic_app_trigger_customazurerm_logic_app_action_customazurerm_container_registryazurerm_container_registry_webhookazurerm_kubernetes_clusterazurerm_kubernetes_cluster_node_poolazurerm_storage_accountazurerm_storage_queueazurerm_storage_shareazurerm_storage_tableazurerm_storage_blobazurerm_mariadb_configurationazurerm_mariadb_databaseazurerm_mariadb_firewall_ruleazurerm_mariadb_serverazurerm_mariadb_virtual_network_ruleazurerm_mysql_configurationazurerm_mysql_databaseazurerm_mysql_firewall_ruleazurerm_mysql_serverazurerm_mysql_virtual_network_ruleazurerm_postgresql_configurationazurerm_postgresql_databaseazurerm_postgresql_firewall_ruleazurerm_postgresql_serverazurerm_postgresql_virtual_network_ruleazurerm_mssql_elasticpoolazurerm_mssql_databaseazurerm_mssql_firewall_ruleazurerm_mssql_serverazurerm_mssql_server_security_alert_policyazurerm_mssql_server_vulnerability_assessmentazurerm_mssql_virtual_machineazurerm_mssql_virtual_network_ruleazurerm_redis_cacheazurerm_redis_firewall_ruleazurerm_dns_zoneazurerm_dns_a_recordazurerm_dns_aaaa_recordazurerm_dns_caa_recordazurerm_dns_cname_recordazurerm_dns_mx_recordazurerm_dns_ns_recordazurerm_dns_ptr_recordazurerm_dns_srv_recordazurerm_dns_txt_recordazurerm_private_dns_zoneazurerm_private_dns_a_recordazurerm_private_dns_aaaa_recordazurerm_private_dns_cname_recordazurerm_private_dns_mx_recordazurerm_private_dns_ptr_recordazurerm_private_dns_srv_recordazurerm_private_dns_txt_recordazurerm_private_dns_zone_virtual_network_linkazurerm_policy_definitionazurerm_policy_remediationazurerm_policy_set_definitionazurerm_key_vaultazurerm_key_vault_access_policyazurerm_application_insightsazurerm_application_insights_api_keyazurerm_application_insights_analytics_itemazurerm_log_analytics_workspaceazurerm_log_analytics_linked_serviceazurerm_log_analytics_datasource_windows_performance_counterazurerm_log_analytics_datasource_windows_eventazurerm_monitor_action_groupazurerm_monitor_activity_log_alertazurerm_monitor_autoscale_settingazurerm_monitor_log_profileazurerm_monitor_metric_alertazurerm_windows_web_appazurerm_linux_web_appazurerm_linux_web_app_slotazurerm_windows_web_app_slotazurerm_web_app_active_slotazurerm_service_planazurerm_source_control_tokenazurerm_static_siteazurerm_static_site_custom_domainazurerm_web_app_hybrid_connectionazurerm_data_protection_backup_vaultazurerm_data_protection_backup_instance_diskazurerm_data_protection_backup_policy_diskazurerm_api_managementazurerm_recovery_services_vaultazurerm_backup_policy_vmazurerm_backup_protected_vmazurerm_backup_policy_vm_workloadazurerm_subscription_policy_assignmentazurerm_role_definition"
 
 func (i ResourceType) String() string {
 	if i < 0 || i >= ResourceType(len(_ResourceTypeIndex)-1) {
@@ -163,9 +163,11 @@ func _ResourceTypeNoOp() {
 	_ = x[BackupPolicyVM-(136)]
 	_ = x[BackupProtectedVM-(137)]
 	_ = x[BackupPolicyVMWorkload-(138)]
+	_ = x[SubscriptionPolicyAssignment-(139)]
+	_ = x[RoleDefinition-(140)]
 }
 
-var _ResourceTypeValues = []ResourceType{ResourceGroup, AvailabilitySet, Image, ManagedDisk, VirtualMachine, VirtualMachineDataDiskAttachment, VirtualMachineExtension, VirtualMachineScaleSetExtension, VirtualNetwork, LinuxVirtualMachine, LinuxVirtualMachineScaleSet, WindowsVirtualMachine, WindowsVirtualMachineScaleSet, Subnet, NetworkInterface, NetworkInterfaceSecurityGroupAssociation, NetworkSecurityGroup, ApplicationGateway, ApplicationSecurityGroup, NetworkDdosProtectionPlan, Firewall, LocalNetworkGateway, NatGateway, NetworkProfile, NetworkSecurityRule, PublicIP, PublicIPPrefix, Route, RouteTable, VirtualNetworkGateway, VirtualNetworkGatewayConnection, VirtualNetworkPeering, WebApplicationFirewallPolicy, VirtualHub, VirtualHubBgpConnection, VirtualHubConnection, VirtualHubIP, VirtualHubRouteTable, VirtualHubSecurityPartnerProvider, Lb, LbBackendAddressPool, LbRule, LbOutboundRule, LbNatRule, LbNatPool, LbProbe, VirtualDesktopHostPool, VirtualDesktopApplicationGroup, LogicAppWorkflow, LogicAppTriggerCustom, LogicAppActionCustom, ContainerRegistry, ContainerRegistryWebhook, KubernetesCluster, KubernetesClusterNodePool, StorageAccount, StorageQueue, StorageShare, StorageTable, StorageBlob, MariadbConfiguration, MariadbDatabase, MariadbFirewallRule, MariadbServer, MariadbVirtualNetworkRule, MysqlConfiguration, MysqlDatabase, MysqlFirewallRule, MysqlServer, MysqlVirtualNetworkRule, PostgresqlConfiguration, PostgresqlDatabase, PostgresqlFirewallRule, PostgresqlServer, PostgresqlVirtualNetworkRule, MssqlElasticpool, MssqlDatabase, MssqlFirewallRule, MssqlServer, MssqlServerSecurityAlertPolicy, MssqlServerVulnerabilityAssessment, MssqlVirtualMachine, MssqlVirtualNetworkRule, RedisCache, RedisFirewallRule, DNSZone, DNSARecord, DNSAaaaRecord, DNSCaaRecord, DNSCnameRecord, DNSMxRecord, DNSNsRecord, DNSPtrRecord, DNSSrvRecord, DNSTxtRecord, PrivateDNSZone, PrivateDNSARecord, PrivateDNSAaaaRecord, PrivateDNSCnameRecord, PrivateDNSMxRecord, PrivateDNSPtrRecord, PrivateDNSSrvRecord, PrivateDNSTxtRecord, PrivateDNSZoneVirtualNetworkLink, PolicyDefinition, PolicyRemediation, PolicySetDefinition, KeyVault, KeyVaultAccessPolicy, ApplicationInsights, ApplicationInsightsAPIKey, ApplicationInsightsAnalyticsItem, LogAnalyticsWorkspace, LogAnalyticsLinkedService, LogAnalyticsDatasourceWindowsPerformanceCounter, LogAnalyticsDatasourceWindowsEvent, MonitorActionGroup, MonitorActivityLogAlert, MonitorAutoscaleSetting, MonitorLogProfile, MonitorMetricAlert, WindowsWebApp, LinuxWebApp, LinuxWebAppSlot, WindowsWebAppSlot, WebAppActiveSlot, ServicePlan, SourceControlToken, StaticSite, StaticSiteCustomDomain, WebAppHybridConnection, DataProtectionBackupVault, DataProtectionBackupInstanceDisk, DataProtectionBackupPolicyDisk, APIManagement, RecoveryServicesVault, BackupPolicyVM, BackupProtectedVM, BackupPolicyVMWorkload}
+var _ResourceTypeValues = []ResourceType{ResourceGroup, AvailabilitySet, Image, ManagedDisk, VirtualMachine, VirtualMachineDataDiskAttachment, VirtualMachineExtension, VirtualMachineScaleSetExtension, VirtualNetwork, LinuxVirtualMachine, LinuxVirtualMachineScaleSet, WindowsVirtualMachine, WindowsVirtualMachineScaleSet, Subnet, NetworkInterface, NetworkInterfaceSecurityGroupAssociation, NetworkSecurityGroup, ApplicationGateway, ApplicationSecurityGroup, NetworkDdosProtectionPlan, Firewall, LocalNetworkGateway, NatGateway, NetworkProfile, NetworkSecurityRule, PublicIP, PublicIPPrefix, Route, RouteTable, VirtualNetworkGateway, VirtualNetworkGatewayConnection, VirtualNetworkPeering, WebApplicationFirewallPolicy, VirtualHub, VirtualHubBgpConnection, VirtualHubConnection, VirtualHubIP, VirtualHubRouteTable, VirtualHubSecurityPartnerProvider, Lb, LbBackendAddressPool, LbRule, LbOutboundRule, LbNatRule, LbNatPool, LbProbe, VirtualDesktopHostPool, VirtualDesktopApplicationGroup, LogicAppWorkflow, LogicAppTriggerCustom, LogicAppActionCustom, ContainerRegistry, ContainerRegistryWebhook, KubernetesCluster, KubernetesClusterNodePool, StorageAccount, StorageQueue, StorageShare, StorageTable, StorageBlob, MariadbConfiguration, MariadbDatabase, MariadbFirewallRule, MariadbServer, MariadbVirtualNetworkRule, MysqlConfiguration, MysqlDatabase, MysqlFirewallRule, MysqlServer, MysqlVirtualNetworkRule, PostgresqlConfiguration, PostgresqlDatabase, PostgresqlFirewallRule, PostgresqlServer, PostgresqlVirtualNetworkRule, MssqlElasticpool, MssqlDatabase, MssqlFirewallRule, MssqlServer, MssqlServerSecurityAlertPolicy, MssqlServerVulnerabilityAssessment, MssqlVirtualMachine, MssqlVirtualNetworkRule, RedisCache, RedisFirewallRule, DNSZone, DNSARecord, DNSAaaaRecord, DNSCaaRecord, DNSCnameRecord, DNSMxRecord, DNSNsRecord, DNSPtrRecord, DNSSrvRecord, DNSTxtRecord, PrivateDNSZone, PrivateDNSARecord, PrivateDNSAaaaRecord, PrivateDNSCnameRecord, PrivateDNSMxRecord, PrivateDNSPtrRecord, PrivateDNSSrvRecord, PrivateDNSTxtRecord, PrivateDNSZoneVirtualNetworkLink, PolicyDefinition, PolicyRemediation, PolicySetDefinition, KeyVault, KeyVaultAccessPolicy, ApplicationInsights, ApplicationInsightsAPIKey, ApplicationInsightsAnalyticsItem, LogAnalyticsWorkspace, LogAnalyticsLinkedService, LogAnalyticsDatasourceWindowsPerformanceCounter, LogAnalyticsDatasourceWindowsEvent, MonitorActionGroup, MonitorActivityLogAlert, MonitorAutoscaleSetting, MonitorLogProfile, MonitorMetricAlert, WindowsWebApp, LinuxWebApp, LinuxWebAppSlot, WindowsWebAppSlot, WebAppActiveSlot, ServicePlan, SourceControlToken, StaticSite, StaticSiteCustomDomain, WebAppHybridConnection, DataProtectionBackupVault, DataProtectionBackupInstanceDisk, DataProtectionBackupPolicyDisk, APIManagement, RecoveryServicesVault, BackupPolicyVM, BackupProtectedVM, BackupPolicyVMWorkload, SubscriptionPolicyAssignment, RoleDefinition}
 
 var _ResourceTypeNameToValueMap = map[string]ResourceType{
 	_ResourceTypeName[0:22]:           ResourceGroup,
@@ -446,6 +448,10 @@ var _ResourceTypeNameToValueMap = map[string]ResourceType{
 	_ResourceTypeLowerName[3863:3890]: BackupProtectedVM,
 	_ResourceTypeName[3890:3923]:      BackupPolicyVMWorkload,
 	_ResourceTypeLowerName[3890:3923]: BackupPolicyVMWorkload,
+	_ResourceTypeName[3923:3961]:      SubscriptionPolicyAssignment,
+	_ResourceTypeLowerName[3923:3961]: SubscriptionPolicyAssignment,
+	_ResourceTypeName[3961:3984]:      RoleDefinition,
+	_ResourceTypeLowerName[3961:3984]: RoleDefinition,
 }
 
 var _ResourceTypeNames = []string{
@@ -588,6 +594,8 @@ var _ResourceTypeNames = []string{
 	_ResourceTypeName[3839:3863],
 	_ResourceTypeName[3863:3890],
 	_ResourceTypeName[3890:3923],
+	_ResourceTypeName[3923:3961],
+	_ResourceTypeName[3961:3984],
 }
 
 // ResourceTypeString retrieves an enum value from the enum constants string name.
@@ -1,16 +1,21 @@
 package cache
 
 import (
+	"sync"
+
 	"github.com/cycloidio/terracognita/errcode"
 	"github.com/cycloidio/terracognita/provider"
 )
 
 // Cache implements a simple cache of provider.Resource
-// it's not concurrently safe
+// it's concurrently safe
 type Cache interface {
 	// Set set's the rs to the key
 	// if an already existing key
-	// was there, it'll return an error
+	// was there, it'll return an error.
+	// As the same key can be filled concurrently
+	// the callers can ignore the
+	// ErrCacheKeyAlreadyExisting error
 	Set(key string, rs []provider.Resource) error
 
 	// Get get's the values of the key
@@ -21,6 +26,7 @@ type Cache interface {
 
 type cache struct {
 	data map[string][]provider.Resource
+	mu   sync.RWMutex
 }
 
 // New returns a new Cache implementaion
@@ -31,6 +37,9 @@ func New() Cache {
 }
 
 func (c *cache) Set(key string, rs []provider.Resource) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	_, ok := c.data[key]
 	if ok {
 		return errcode.ErrCacheKeyAlreadyExisting
@@ -40,6 +49,9 @@ func (c *cache) Set(key string, rs []provider.Resource) error {
 }
 
 func (c *cache) Get(key string) ([]provider.Resource, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	rs, ok := c.data[key]
 	if !ok {
 		return nil, errcode.ErrCacheKeyNotFound
@@ -18,7 +18,6 @@ func TestSetGet(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		c := cache.New()
 		p := mock.NewProvider(ctrl)
-		p.EXPECT().TFProvider().Return(nil)
 		r := provider.NewResource("id", "", p)
 		err := c.Set("k", []provider.Resource{r})
 		defer ctrl.Finish()
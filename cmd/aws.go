@@ -1,14 +1,10 @@
 package cmd
 
 import (
-	"context"
-
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/aws/credentials"
 	kitlog "github.com/go-kit/kit/log"
 
-	"github.com/cycloidio/terracognita/aws"
 	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/terracognita"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -26,21 +22,7 @@ var (
 				return err
 			}
 
-			viper.BindPFlag("aws-access-key", cmd.Flags().Lookup("aws-access-key"))
-			viper.BindPFlag("aws-secret-access-key", cmd.Flags().Lookup("aws-secret-access-key"))
-			viper.BindPFlag("aws-default-region", cmd.Flags().Lookup("aws-default-region"))
-			viper.BindPFlag("aws-session-token", cmd.Flags().Lookup("aws-session-token"))
-
-			viper.BindPFlag("aws-shared-credentials-file", cmd.Flags().Lookup("aws-shared-credentials-file"))
-			viper.BindPFlag("aws-profile", cmd.Flags().Lookup("aws-profile"))
-
-			viper.BindPFlag("tags", cmd.Flags().Lookup("tags"))
-
-			// We define aliases so we have an easier access on the code
-			viper.RegisterAlias("access-key", "aws-access-key")
-			viper.RegisterAlias("secret-key", "aws-secret-access-key")
-			viper.RegisterAlias("session-token", "aws-session-token")
-			viper.RegisterAlias("region", "aws-default-region")
+			bindAWSFlags(cmd)
 
 			return nil
 		},
@@ -49,26 +31,15 @@ var (
 			logger := log.Get()
 			logger = kitlog.With(logger, "func", "cmd.aws.RunE")
 
-			loadAWSCredentials()
-
-			// Validate required flags
-			if err := requiredStringFlags("access-key", "secret-key", "region"); err != nil {
-				return err
-			}
-
-			tags, err := initializeTags("tags")
+			c, err := newAWSConfig()
 			if err != nil {
 				return err
 			}
 
-			ctx := context.Background()
+			ctx, cancel := newContext()
+			defer cancel()
 
-			awsP, err := aws.NewProvider(ctx, viper.GetString("access-key"), viper.GetString("secret-key"), viper.GetString("region"), viper.GetString("session-token"))
-			if err != nil {
-				return err
-			}
-
-			err = importProvider(ctx, logger, awsP, tags)
+			err = importProvider(ctx, logger, c)
 			if err != nil {
 				return err
 			}
@@ -78,57 +49,92 @@ var (
 	}
 )
 
-func init() {
-	awsCmd.AddCommand(awsResourcesCmd)
-
-	// Required flags
-	awsCmd.Flags().String("aws-access-key", "", "Access Key (required)")
-	awsCmd.Flags().String("aws-secret-access-key", "", "Secret Key (required)")
-	awsCmd.Flags().String("aws-session-token", "", "Use to validate the temporary security credentials")
-	awsCmd.Flags().String("aws-default-region", "", "Region to search in, for now * is not supported (required)")
-	awsCmd.Flags().String("aws-shared-credentials-file", "", "Path to the AWS credential path")
-	awsCmd.Flags().String("aws-profile", "", "Name of the Profile to use with the Credentials")
-
-	// Filter flags
-	awsCmd.Flags().StringSliceVarP(&tags, "tags", "t", []string{}, "List of tags to filter with format 'NAME:VALUE'")
+// bindAWSFlags binds the AWS flags of the cmd to viper
+func bindAWSFlags(cmd *cobra.Command) {
+	viper.BindPFlag("aws-access-key", cmd.Flags().Lookup("aws-access-key"))
+	viper.BindPFlag("aws-secret-access-key", cmd.Flags().Lookup("aws-secret-access-key"))
+	viper.BindPFlag("aws-default-region", cmd.Flags().Lookup("aws-default-region"))
+	viper.BindPFlag("aws-session-token", cmd.Flags().Lookup("aws-session-token"))
+	viper.BindPFlag("aws-regions", cmd.Flags().Lookup("aws-regions"))
+	viper.BindPFlag("aws-accounts", cmd.Flags().Lookup("aws-accounts"))
+	viper.BindPFlag("aws-role-name", cmd.Flags().Lookup("aws-role-name"))
+
+	viper.BindPFlag("aws-shared-credentials-file", cmd.Flags().Lookup("aws-shared-credentials-file"))
+	viper.BindPFlag("aws-profile", cmd.Flags().Lookup("aws-profile"))
+	viper.BindPFlag("aws-role-arn", cmd.Flags().Lookup("aws-role-arn"))
+	viper.BindPFlag("aws-external-id", cmd.Flags().Lookup("aws-external-id"))
+	viper.BindPFlag("aws-mfa-serial", cmd.Flags().Lookup("aws-mfa-serial"))
+
+	viper.BindPFlag("tags", cmd.Flags().Lookup("tags"))
+
+	// We define aliases so we have an easier access on the code
+	viper.RegisterAlias("access-key", "aws-access-key")
+	viper.RegisterAlias("secret-key", "aws-secret-access-key")
+	viper.RegisterAlias("session-token", "aws-session-token")
+	viper.RegisterAlias("region", "aws-default-region")
 }
 
-// loadAWSCredentials will first read from ENV and if AccessKey and SecretAccessKey are not found (both of them)
-// will fallback to the SharedCredentials with the profile
-func loadAWSCredentials() error {
-	creds := credentials.NewCredentials(&credentials.ChainProvider{
-		Providers: []credentials.Provider{
-			&credentials.EnvProvider{},
-			&credentials.SharedCredentialsProvider{Filename: viper.GetString("aws-shared-credentials-file"), Profile: viper.GetString("aws-profile")},
-		},
-	})
-
-	value, err := creds.Get()
-	if err != nil {
-		// The NoCredentialProviders is an error returned by Get to identify that none
-		// of the Providers (credentials.EnvProvider and credentials.SharedCredentialsProvider)
-		// did find any information.
-		// So we escape it means nothing was found by AWS
-		if awsE, ok := err.(awserr.Error); ok && awsE.Code() == "NoCredentialProviders" {
-			return nil
+// newAWSConfig returns the terracognita.Config
+// with the AWS flags
+func newAWSConfig() (terracognita.Config, error) {
+	// Validate required flags, the access-key and secret-key
+	// are loaded from the credentials chain of the AWS SDK if
+	// not set and the region is not needed if the regions are set
+	regions := viper.GetStringSlice("aws-regions")
+	if len(regions) == 0 {
+		if err := requiredStringFlags("region"); err != nil {
+			return terracognita.Config{}, err
 		}
-		return err
 	}
 
-	// If the values are already set
-	// it'll not be override as they
-	// are more relevant
-	if !viper.IsSet("access-key") {
-		viper.Set("access-key", value.AccessKeyID)
+	tags, err := initializeExpr("tags")
+	if err != nil {
+		return terracognita.Config{}, err
 	}
 
-	if !viper.IsSet("secret-key") {
-		viper.Set("secret-key", value.SecretAccessKey)
+	c, err := newConfig(tags)
+	if err != nil {
+		return c, err
 	}
 
-	if !viper.IsSet("session-token") {
-		viper.Set("session-token", value.SessionToken)
+	c.AWS = &terracognita.AWSConfig{
+		AccessKey:    viper.GetString("access-key"),
+		SecretKey:    viper.GetString("secret-key"),
+		SessionToken: viper.GetString("session-token"),
+		Region:       viper.GetString("region"),
+		Regions:      regions,
+		Accounts:     viper.GetStringSlice("aws-accounts"),
+		RoleName:     viper.GetString("aws-role-name"),
+
+		SharedCredentialsFile: viper.GetString("aws-shared-credentials-file"),
+		Profile:               viper.GetString("aws-profile"),
+
+		RoleARN:    viper.GetString("aws-role-arn"),
+		ExternalID: viper.GetString("aws-external-id"),
+		MFASerial:  viper.GetString("aws-mfa-serial"),
 	}
 
-	return nil
+	return c, nil
+}
+
+func init() {
+	awsCmd.AddCommand(awsResourcesCmd)
+	awsCmd.AddCommand(newDriftCmd("aws", bindAWSFlags, newAWSConfig))
+
+	// Required flags
+	awsCmd.PersistentFlags().String("aws-access-key", "", "Access Key, if not set the credentials are read from the chain of the AWS SDK (ENV, shared credentials and config with SSO, web identity, ECS/EC2 roles)")
+	awsCmd.PersistentFlags().String("aws-secret-access-key", "", "Secret Key, if not set the credentials are read from the chain of the AWS SDK")
+	awsCmd.PersistentFlags().String("aws-session-token", "", "Use to validate the temporary security credentials")
+	awsCmd.PersistentFlags().String("aws-default-region", "", "Region to search in (required if no --aws-regions)")
+	awsCmd.PersistentFlags().StringSlice("aws-regions", []string{}, "List of regions to search in, or 'all' for all the enabled ones. Each region has its own 'provider' block with the region as alias")
+	awsCmd.PersistentFlags().StringSlice("aws-accounts", []string{}, "List of account IDs to import from, or 'all' for all the accounts of the Organization, assuming the --aws-role-name on each one. Each account is written on a directory named as it next to the --hcl, --tfstate or --module")
+	awsCmd.PersistentFlags().String("aws-role-name", "", "Name of the role to assume on each one of the --aws-accounts")
+	awsCmd.PersistentFlags().String("aws-shared-credentials-file", "", "Path to the AWS credential path")
+	awsCmd.PersistentFlags().String("aws-profile", "", "Name of the Profile to use with the Credentials")
+	awsCmd.PersistentFlags().String("aws-role-arn", "", "ARN of the role to assume with the credentials")
+	awsCmd.PersistentFlags().String("aws-external-id", "", "External ID to assume the --aws-role-arn or the --aws-role-name")
+	awsCmd.PersistentFlags().String("aws-mfa-serial", "", "Serial number of the MFA device to assume the --aws-role-arn or the --aws-role-name, the token is read from the stdin")
+
+	// Filter flags
+	awsCmd.PersistentFlags().StringArrayVarP(&tags, "tags", "t", []string{}, "Tags to filter with format 'NAME:VALUE', the VALUE can be a glob (ex: team:platform-*), or an expression of them with 'NAME in (VALUE,...)', 'has(NAME)', AND (or ','), OR, NOT and parentheses (ex: 'env in (prod,staging) AND NOT owner:legacy'). If set more than once the resources have to match all of them")
 }
@@ -1,15 +1,14 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	kitlog "github.com/go-kit/kit/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"github.com/cycloidio/terracognita/azurerm"
 	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/terracognita"
 )
 
 var (
@@ -22,13 +21,7 @@ var (
 			if err != nil {
 				return err
 			}
-			viper.BindPFlag("client-id", cmd.Flags().Lookup("client-id"))
-			viper.BindPFlag("client-secret", cmd.Flags().Lookup("client-secret"))
-			viper.BindPFlag("environment", cmd.Flags().Lookup("environment"))
-			viper.BindPFlag("resource-group-name", cmd.Flags().Lookup("resource-group-name"))
-			viper.BindPFlag("subscription-id", cmd.Flags().Lookup("subscription-id"))
-			viper.BindPFlag("tenant-id", cmd.Flags().Lookup("tenant-id"))
-			viper.BindPFlag("tags", cmd.Flags().Lookup("tags"))
+			bindAzureRMFlags(cmd)
 
 			return nil
 		},
@@ -36,37 +29,16 @@ var (
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger := log.Get()
 			logger = kitlog.With(logger, "func", "cmd.azure.RunE")
-			// Validate required flags
-			if err := requiredStringFlags(
-				"client-id", "client-secret", "subscription-id", "tenant-id",
-			); err != nil {
-				return err
-			}
-			if len(viper.GetStringSlice("resource-group-name")) == 0 {
-				return fmt.Errorf("the flag 'resource-group-name' is required")
-			}
 
-			ctx := context.Background()
-
-			azureRMP, err := azurerm.NewProvider(
-				ctx,
-				viper.GetString("client-id"),
-				viper.GetString("client-secret"),
-				viper.GetString("environment"),
-				viper.GetStringSlice("resource-group-name"),
-				viper.GetString("subscription-id"),
-				viper.GetString("tenant-id"),
-			)
+			c, err := newAzureRMConfig()
 			if err != nil {
 				return err
 			}
 
-			tags, err := initializeTags("tags")
-			if err != nil {
-				return err
-			}
+			ctx, cancel := newContext()
+			defer cancel()
 
-			err = importProvider(ctx, logger, azureRMP, tags)
+			err = importProvider(ctx, logger, c)
 			if err != nil {
 				return err
 			}
@@ -76,18 +48,79 @@ var (
 	}
 )
 
+// bindAzureRMFlags binds the AzureRM flags of the cmd to viper
+func bindAzureRMFlags(cmd *cobra.Command) {
+	viper.BindPFlag("client-id", cmd.Flags().Lookup("client-id"))
+	viper.BindPFlag("client-secret", cmd.Flags().Lookup("client-secret"))
+	viper.BindPFlag("environment", cmd.Flags().Lookup("environment"))
+	viper.BindPFlag("resource-group-name", cmd.Flags().Lookup("resource-group-name"))
+	viper.BindPFlag("all-resource-groups", cmd.Flags().Lookup("all-resource-groups"))
+	viper.BindPFlag("subscription-id", cmd.Flags().Lookup("subscription-id"))
+	viper.BindPFlag("tenant-id", cmd.Flags().Lookup("tenant-id"))
+	viper.BindPFlag("tags", cmd.Flags().Lookup("tags"))
+}
+
+// newAzureRMConfig returns the terracognita.Config
+// with the AzureRM flags
+func newAzureRMConfig() (terracognita.Config, error) {
+	// Validate required flags
+	if err := requiredStringFlags(
+		"client-id", "client-secret", "tenant-id",
+	); err != nil {
+		return terracognita.Config{}, err
+	}
+	subscriptions := viper.GetStringSlice("subscription-id")
+	if len(subscriptions) == 0 {
+		return terracognita.Config{}, fmt.Errorf("the flag 'subscription-id' is required")
+	}
+	if len(viper.GetStringSlice("resource-group-name")) == 0 && !viper.GetBool("all-resource-groups") {
+		return terracognita.Config{}, fmt.Errorf("one of the flags 'resource-group-name' or 'all-resource-groups' is required")
+	}
+
+	tags, err := initializeExpr("tags")
+	if err != nil {
+		return terracognita.Config{}, err
+	}
+
+	c, err := newConfig(tags)
+	if err != nil {
+		return c, err
+	}
+
+	c.AzureRM = &terracognita.AzureRMConfig{
+		ClientID:           viper.GetString("client-id"),
+		ClientSecret:       viper.GetString("client-secret"),
+		Environment:        viper.GetString("environment"),
+		ResourceGroupNames: viper.GetStringSlice("resource-group-name"),
+		AllResourceGroups:  viper.GetBool("all-resource-groups"),
+		TenantID:           viper.GetString("tenant-id"),
+	}
+
+	// With more than one, or all, each
+	// one is imported on its own outputs
+	if len(subscriptions) == 1 && subscriptions[0] != terracognita.AllAzureRMSubscriptions {
+		c.AzureRM.SubscriptionID = subscriptions[0]
+	} else {
+		c.AzureRM.Subscriptions = subscriptions
+	}
+
+	return c, nil
+}
+
 func init() {
 	azurermCmd.AddCommand(azurermResourcesCmd)
+	azurermCmd.AddCommand(newDriftCmd("azurerm", bindAzureRMFlags, newAzureRMConfig))
 
 	// Required flags
-	azurermCmd.Flags().String("client-id", "", "Client ID (required)")
-	azurermCmd.Flags().String("client-secret", "", "Client Secret (required)")
-	azurermCmd.Flags().StringSlice("resource-group-name", nil, "Resource Group Names (required)")
-	azurermCmd.Flags().String("subscription-id", "", "Subscription ID (required)")
-	azurermCmd.Flags().String("tenant-id", "", "Tenant ID (required)")
+	azurermCmd.PersistentFlags().String("client-id", "", "Client ID (required)")
+	azurermCmd.PersistentFlags().String("client-secret", "", "Client Secret (required)")
+	azurermCmd.PersistentFlags().StringSlice("resource-group-name", nil, "Resource Group Names (required if no --all-resource-groups)")
+	azurermCmd.PersistentFlags().Bool("all-resource-groups", false, "Import from all the Resource Groups of the subscriptions")
+	azurermCmd.PersistentFlags().StringSlice("subscription-id", nil, "Subscription IDs, or 'all' for all the accessible ones. With more than one each subscription is written on a directory named as it next to the --hcl or --tfstate and has its own 'provider' block with the subscription as alias (required)")
+	azurermCmd.PersistentFlags().String("tenant-id", "", "Tenant ID (required)")
 
-	azurermCmd.Flags().StringSliceVarP(&tags, "tags", "t", []string{}, "List of tags to filter with format 'NAME:VALUE'")
+	azurermCmd.PersistentFlags().StringArrayVarP(&tags, "tags", "t", []string{}, "Tags to filter with format 'NAME:VALUE', the VALUE can be a glob (ex: team:platform-*), or an expression of them with 'NAME in (VALUE,...)', 'has(NAME)', AND (or ','), OR, NOT and parentheses (ex: 'env in (prod,staging) AND NOT owner:legacy'). If set more than once the resources have to match all of them")
 
 	// Optional flags
-	azurermCmd.Flags().String("environment", "public", "Environment")
+	azurermCmd.PersistentFlags().String("environment", "public", "Environment")
 }
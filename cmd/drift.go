@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	kitlog "github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/terracognita"
+)
+
+// List of the formats of the drift --format
+const (
+	driftFormatText = "text"
+	driftFormatJSON = "json"
+)
+
+// newDriftCmd returns the drift command for the provider, the bind binds
+// the flags of the provider and the config returns the terracognita.Config
+// with them, as the flags are inherited from the provider command
+func newDriftCmd(provider string, bind func(*cobra.Command), config func() (terracognita.Config, error)) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drift",
+		Short: fmt.Sprintf("Compares a TFState with the current %s resources", provider),
+		Long:  fmt.Sprintf("Compares a TFState with the current %s resources, reporting the changed attributes, the resources deleted and the ones of the same types that are not on the TFState", provider),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			bind(cmd)
+
+			viper.BindPFlag("state", cmd.Flags().Lookup("state"))
+			viper.BindPFlag("format", cmd.Flags().Lookup("format"))
+			viper.BindPFlag("fail-on-drift", cmd.Flags().Lookup("fail-on-drift"))
+
+			return nil
+		},
+		PostRunE: postRunEOutput,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.Get()
+			logger = kitlog.With(logger, "func", "cmd."+provider+".drift.RunE")
+
+			if err := requiredStringFlags("state"); err != nil {
+				return err
+			}
+
+			format := viper.GetString("format")
+			if format != driftFormatText && format != driftFormatJSON {
+				return fmt.Errorf("invalid --format %q, the valid ones are: %s, %s", format, driftFormatText, driftFormatJSON)
+			}
+
+			c, err := config()
+			if err != nil {
+				return err
+			}
+
+			// The report is written to Stdout so the progress is
+			// only rendered, to Stderr, if it's not for a terminal
+			if of := viper.GetString("output-format"); of != outputFormatTTY {
+				c.Events, err = newEventHandler(of, os.Stderr)
+				if err != nil {
+					return err
+				}
+			}
+
+			ctx, cancel := newContext()
+			defer cancel()
+
+			logger.Log("msg", "starting terracognita drift", "version", Version)
+
+			rep, err := terracognita.Drift(ctx, c, viper.GetString("state"))
+			if rep != nil {
+				var werr error
+				if format == driftFormatJSON {
+					werr = rep.Write(os.Stdout)
+				} else {
+					werr = rep.WriteText(os.Stdout)
+				}
+				if werr != nil {
+					return werr
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			if viper.GetBool("fail-on-drift") && rep.HasDrift() {
+				return errors.New("drift detected")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("state", "", "TFState file to compare with the current resources (required)")
+	cmd.Flags().String("format", driftFormatText, "Format of the drift output: text or json")
+	cmd.Flags().Bool("fail-on-drift", false, "Fail if any resource is changed, deleted, unmanaged or could not be compared")
+
+	return cmd
+}
@@ -1,14 +1,12 @@
 package cmd
 
 import (
-	"context"
-
 	kitlog "github.com/go-kit/kit/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"github.com/cycloidio/terracognita/google"
 	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/terracognita"
 )
 
 var (
@@ -21,11 +19,7 @@ var (
 			if err != nil {
 				return err
 			}
-			viper.BindPFlag("credentials", cmd.Flags().Lookup("credentials"))
-			viper.BindPFlag("project", cmd.Flags().Lookup("project"))
-			viper.BindPFlag("region", cmd.Flags().Lookup("region"))
-			viper.BindPFlag("labels", cmd.Flags().Lookup("labels"))
-			viper.BindPFlag("max-results", cmd.Flags().Lookup("max-results"))
+			bindGoogleFlags(cmd)
 
 			return nil
 		},
@@ -33,30 +27,16 @@ var (
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger := log.Get()
 			logger = kitlog.With(logger, "func", "cmd.google.RunE")
-			// Validate required flags
-			if err := requiredStringFlags("region", "project", "credentials"); err != nil {
-				return err
-			}
 
-			tags, err := initializeTags("labels")
+			c, err := newGoogleConfig()
 			if err != nil {
 				return err
 			}
 
-			ctx := context.Background()
+			ctx, cancel := newContext()
+			defer cancel()
 
-			googleP, err := google.NewProvider(
-				ctx,
-				viper.GetUint64("max-results"),
-				viper.GetString("project"),
-				viper.GetString("region"),
-				viper.GetString("credentials"),
-			)
-			if err != nil {
-				return err
-			}
-
-			err = importProvider(ctx, logger, googleP, tags)
+			err = importProvider(ctx, logger, c)
 			if err != nil {
 				return err
 			}
@@ -66,17 +46,74 @@ var (
 	}
 )
 
+// bindGoogleFlags binds the Google flags of the cmd to viper
+func bindGoogleFlags(cmd *cobra.Command) {
+	viper.BindPFlag("credentials", cmd.Flags().Lookup("credentials"))
+	viper.BindPFlag("project", cmd.Flags().Lookup("project"))
+	viper.BindPFlag("region", cmd.Flags().Lookup("region"))
+	viper.BindPFlag("regions", cmd.Flags().Lookup("regions"))
+	viper.BindPFlag("projects", cmd.Flags().Lookup("projects"))
+	viper.BindPFlag("parent", cmd.Flags().Lookup("parent"))
+	viper.BindPFlag("labels", cmd.Flags().Lookup("labels"))
+	viper.BindPFlag("max-results", cmd.Flags().Lookup("max-results"))
+}
+
+// newGoogleConfig returns the terracognita.Config
+// with the Google flags
+func newGoogleConfig() (terracognita.Config, error) {
+	// Validate required flags, the project is not needed if the
+	// projects or parent are set and the region if the regions are
+	required := []string{"credentials"}
+	projects := viper.GetStringSlice("projects")
+	if len(projects) == 0 && viper.GetString("parent") == "" {
+		required = append(required, "project")
+	}
+	regions := viper.GetStringSlice("regions")
+	if len(regions) == 0 {
+		required = append(required, "region")
+	}
+	if err := requiredStringFlags(required...); err != nil {
+		return terracognita.Config{}, err
+	}
+
+	tags, err := initializeExpr("labels")
+	if err != nil {
+		return terracognita.Config{}, err
+	}
+
+	c, err := newConfig(tags)
+	if err != nil {
+		return c, err
+	}
+
+	c.Google = &terracognita.GoogleConfig{
+		Credentials: viper.GetString("credentials"),
+		Project:     viper.GetString("project"),
+		Region:      viper.GetString("region"),
+		Regions:     regions,
+		Projects:    projects,
+		Parent:      viper.GetString("parent"),
+		MaxResults:  viper.GetUint64("max-results"),
+	}
+
+	return c, nil
+}
+
 func init() {
 	googleCmd.AddCommand(googleResourcesCmd)
+	googleCmd.AddCommand(newDriftCmd("google", bindGoogleFlags, newGoogleConfig))
 
 	// Required flags
-	googleCmd.Flags().String("credentials", "", "path to the JSON credential (required)")
-	googleCmd.Flags().String("project", "", "project (required)")
-	googleCmd.Flags().String("region", "", "region (required)")
+	googleCmd.PersistentFlags().String("credentials", "", "path to the JSON credential (required)")
+	googleCmd.PersistentFlags().String("project", "", "project (required if no --projects or --parent)")
+	googleCmd.PersistentFlags().String("region", "", "region (required if no --regions)")
+	googleCmd.PersistentFlags().StringSlice("regions", []string{}, "List of regions to search in. Each region has its own 'provider' block with the region as alias")
+	googleCmd.PersistentFlags().StringSlice("projects", []string{}, "List of projects to import from. Each project is written on a directory named as it next to the --hcl or --tfstate and has its own 'provider' block with the project as alias")
+	googleCmd.PersistentFlags().String("parent", "", "Folder (folders/<id>) or organization (organizations/<id>) to import from all its active projects, as with --projects")
 
 	// Filter flags
-	googleCmd.Flags().StringSliceVarP(&tags, "labels", "l", []string{}, "List of labels to filter with format 'NAME:VALUE'")
+	googleCmd.PersistentFlags().StringArrayVarP(&tags, "labels", "l", []string{}, "Labels to filter with format 'NAME:VALUE', the VALUE can be a glob (ex: team:platform-*), or an expression of them with 'NAME in (VALUE,...)', 'has(NAME)', AND (or ','), OR, NOT and parentheses (ex: 'env in (prod,staging) AND NOT owner:legacy'). If set more than once the resources have to match all of them")
 
 	// Optional flags
-	googleCmd.Flags().Uint64("max-results", 500, "max results to fetch when pagination is used")
+	googleCmd.PersistentFlags().Uint64("max-results", 500, "max results to fetch when pagination is used")
 }
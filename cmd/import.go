@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+
+	kitlog "github.com/go-kit/kit/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/terracognita"
+)
+
+var (
+	importCmd = &cobra.Command{
+		Use:   "import",
+		Short: "Terracognita reads from multiple providers and generates hcl resources and/or terraform state",
+		Long:  "Terracognita reads from all the providers configured on the --config file and generates the hcl resources and/or terraform state of all of them on the same outputs",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := preRunEOutput(cmd, args)
+			if err != nil {
+				return err
+			}
+			viper.BindPFlag("config", cmd.Flags().Lookup("config"))
+			viper.BindPFlag("tags", cmd.Flags().Lookup("tags"))
+
+			return nil
+		},
+		PostRunE: postRunEOutput,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.Get()
+			logger = kitlog.With(logger, "func", "cmd.import.RunE")
+			// Validate required flags
+			if err := requiredStringFlags("config"); err != nil {
+				return err
+			}
+
+			pc, err := terracognita.ReadProvidersConfig(viper.GetString("config"))
+			if err != nil {
+				return err
+			}
+
+			tags, err := initializeExpr("tags")
+			if err != nil {
+				return err
+			}
+
+			c, err := newConfig(tags)
+			if err != nil {
+				return err
+			}
+
+			c.AWS = pc.AWS
+			c.Google = pc.Google
+			c.AzureRM = pc.AzureRM
+			c.VSphere = pc.VSphere
+
+			if c.AWS == nil && c.Google == nil && c.AzureRM == nil && c.VSphere == nil {
+				return fmt.Errorf("no provider configured on the --config %s", viper.GetString("config"))
+			}
+
+			ctx, cancel := newContext()
+			defer cancel()
+
+			err = importProvider(ctx, logger, c)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	// Required flags
+	importCmd.Flags().String("config", "", "Path to the YAML/JSON file with the configuration of the providers to import from, more information on https://github.com/cycloidio/terracognita#multiple-providers (required)")
+
+	// Filter flags
+	importCmd.Flags().StringArrayVarP(&tags, "tags", "t", []string{}, "Tags (or labels) to filter with format 'NAME:VALUE', the VALUE can be a glob (ex: team:platform-*), or an expression of them with 'NAME in (VALUE,...)', 'has(NAME)', AND (or ','), OR, NOT and parentheses (ex: 'env in (prod,staging) AND NOT owner:legacy'). If set more than once the resources have to match all of them")
+}
@@ -2,37 +2,30 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"os/signal"
 	"path"
-	"path/filepath"
 	"strings"
+	"syscall"
 
 	"github.com/pkg/errors"
 
 	"github.com/adrg/xdg"
-	"github.com/cycloidio/mxwriter"
-	"github.com/cycloidio/terracognita/filter"
-	"github.com/cycloidio/terracognita/hcl"
+	"github.com/cycloidio/terracognita/event"
 	"github.com/cycloidio/terracognita/log"
-	"github.com/cycloidio/terracognita/provider"
-	"github.com/cycloidio/terracognita/state"
 	"github.com/cycloidio/terracognita/tag"
-	"github.com/cycloidio/terracognita/writer"
+	"github.com/cycloidio/terracognita/terracognita"
+	"github.com/cycloidio/terracognita/util"
 	kitlog "github.com/go-kit/kit/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v2"
 )
 
 var (
-	isHCLDir bool
-	noTags   []tag.Tag = nil
-	hclOut   io.ReadWriter
-	stateOut io.Writer
+	noTags tag.Expr = nil
 
 	closeOut = make([]io.Closer, 0, 0)
 
@@ -83,102 +76,52 @@ func requiredStringFlags(names ...string) error {
 }
 
 func preRunEOutput(cmd *cobra.Command, args []string) error {
-	// Initializes/Validates the HCL and TFSTATE flags
-	if module := viper.GetString("module"); module != "" {
+	// On dry run nothing is written
+	// so there is nothing to initialize
+	if viper.GetBool("dry-run") {
+		return nil
+	}
 
-		// We check if there is any error checking it
-		// if its NotExist we do not care as we'll create it
-		// but if it exists and it's not a Dir then we fail
-		fi, err := os.Stat(module)
-		if err != nil && !os.IsNotExist(err) {
-			return err
-		}
-		if err == nil && !fi.IsDir() {
-			return fmt.Errorf("the --module must be a directory: %s", module)
-		}
+	if viper.GetString("tfstate") == "" && viper.GetString("hcl") == "" && viper.GetString("module") == "" {
+		return fmt.Errorf("one of --module, --hcl  or --tfstate are required")
+	}
 
-		// It means is a existing directory
-		// so we'll ask for confirmation before deleting the
-		// existent content
-		if err == nil {
-			fmt.Printf("We are about to remove all content from %q, are you sure? Yes/No (Y/N):\n", module)
-			var s string
-			fmt.Scanf("%s\n", &s)
-			s = strings.ToLower(s)
-			if s != "yes" && s != "y" {
-				return errors.New("the import was stopped")
-			}
+	if viper.GetBool("import-blocks") {
+		if viper.GetString("tfstate") != "" {
+			return fmt.Errorf("the --import-blocks are generated instead of the --tfstate so both can not be used")
 		}
-
-		// Clean the module dir
-		err = os.RemoveAll(module)
-		if err != nil {
-			return err
+		if viper.GetString("hcl") == "" && viper.GetString("module") == "" {
+			return fmt.Errorf("one of --module or --hcl is required with --import-blocks")
 		}
+	}
 
-		// Recreate it just if it was not created
-		// RemoveAll will not return error if
-		// it does not exists
-		err = os.MkdirAll(module, 0700)
-		if err != nil {
-			return err
-		}
-
-		hclOut = mxwriter.NewMux()
-	} else if hcl := viper.GetString("hcl"); hcl != "" {
-		// We check if there is any error checking it
-		// if its NotExist we do not care as we'll create it
-		fi, err := os.Stat(hcl)
-		if err != nil && !os.IsNotExist(err) {
-			return err
-		}
-		hasExt := filepath.Ext(hcl) != ""
-		if (err == nil && !fi.IsDir()) || hasExt {
-			isHCLDir = false
-		} else {
-			isHCLDir = true
-			// It means is a existing directory
-			// so we'll ask for confirmation before deleting the
-			// existent content
-			if err == nil {
-				fmt.Printf("We are about to remove all content from %q, are you sure? Yes/No (Y/N):\n", hcl)
-				var s string
-				fmt.Scanf("%s\n", &s)
-				s = strings.ToLower(s)
-				if s != "yes" && s != "y" {
-					return errors.New("the import was stopped")
-				}
-			}
-
-			// Clean the module dir
-			err = os.RemoveAll(hcl)
-			if err != nil {
-				return err
-			}
+	// The directories are emptied before importing
+	// so we ask for confirmation if they already exist
+	if module := viper.GetString("module"); module != "" {
+		return confirmRemoveDir(module)
+	} else if hcl := viper.GetString("hcl"); hcl != "" && terracognita.IsHCLDir(hcl) {
+		return confirmRemoveDir(hcl)
+	}
 
-			// Recreate it just if it was not created
-			// RemoveAll will not return error if
-			// it does not exists
-			err = os.MkdirAll(hcl, 0700)
-			if err != nil {
-				return err
-			}
-		}
+	return nil
+}
 
-		hclOut = mxwriter.NewMux()
-	}
-	if viper.GetString("tfstate") != "" {
-		f, err := os.OpenFile(viper.GetString("tfstate"), os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
-		if err != nil {
-			return fmt.Errorf("could not OpenFile %s because: %s", viper.GetString("tfstate"), err)
-		}
-		stateOut = f
-		closeOut = append(closeOut, f)
+// confirmRemoveDir asks for confirmation before deleting
+// the content of d if it's an existing directory
+func confirmRemoveDir(d string) error {
+	fi, err := os.Stat(d)
+	if err != nil || !fi.IsDir() {
+		return nil
 	}
 
-	if viper.GetString("tfstate") == "" && viper.GetString("hcl") == "" && viper.GetString("module") == "" {
-		return fmt.Errorf("one of --module, --hcl  or --tfstate are required")
+	fmt.Printf("We are about to remove all content from %q, are you sure? Yes/No (Y/N):\n", d)
+	var s string
+	fmt.Scanf("%s\n", &s)
+	s = strings.ToLower(s)
+	if s != "yes" && s != "y" {
+		return errors.New("the import was stopped")
 	}
+
 	return nil
 }
 
@@ -190,161 +133,174 @@ func postRunEOutput(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if m := viper.GetString("module"); m != "" {
-		dm, err := mxwriter.NewDemux(hclOut)
-		if err != nil {
-			return err
-		}
-
-		moduleName := filepath.Base(m)
-		mdir := fmt.Sprintf("module-%s", moduleName)
+	return nil
+}
 
-		err = os.Mkdir(filepath.Join(m, mdir), 0700)
-		if err != nil {
-			return err
-		}
+// newConfig returns the terracognita.Config with the
+// common flags, the provider has to be set after
+func newConfig(tags tag.Expr) (terracognita.Config, error) {
+	c := terracognita.Config{
+		Include:  include,
+		Exclude:  exclude,
+		Targets:  targets,
+		TagsExpr: tags,
+
+		IDPattern:   viper.GetString("id-pattern"),
+		NamePattern: viper.GetString("name-pattern"),
+
+		HCL:              viper.GetString("hcl"),
+		TFState:          viper.GetString("tfstate"),
+		ImportBlocks:     viper.GetBool("import-blocks"),
+		ExistingState:    viper.GetString("existing-state"),
+		ManagedStates:    viper.GetStringSlice("managed-state"),
+		Module:           viper.GetString("module"),
+		Interpolate:      viper.GetBool("interpolate"),
+		HCLProviderBlock: viper.GetBool("hcl-provider-block"),
 
-		for _, k := range dm.Keys() {
-			var (
-				filep string
-			)
-			if k == writer.ModuleCategoryKey {
-				filep = filepath.Join(m, "module.tf")
-			} else {
-				filep = filepath.Join(m, mdir, fmt.Sprintf("%s.tf", k))
-			}
+		Journal: viper.GetString("journal"),
+		Report:  viper.GetString("report"),
+		Graph:   viper.GetString("graph"),
+
+		Parallelism:     viper.GetInt("parallelism"),
+		DryRun:          viper.GetBool("dry-run"),
+		Strict:          viper.GetBool("strict"),
+		MaxErrors:       viper.GetInt("max-errors"),
+		MaxErrorRatio:   viper.GetFloat64("max-error-ratio"),
+		ResourceTimeout: viper.GetDuration("resource-timeout"),
+		MaxRetries:      viper.GetInt("max-retries"),
+		RetryMaxWait:    viper.GetDuration("retry-max-wait"),
+	}
 
-			f, err := os.OpenFile(filep, os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
-			if err != nil {
-				return fmt.Errorf("could not OpenFile %s because: %s", filep, err)
-			}
-			io.Copy(f, dm.Read(k))
-			f.Close()
-		}
-	} else if hcl := viper.GetString("hcl"); hcl != "" {
-		dm, err := mxwriter.NewDemux(hclOut)
+	if rls := viper.GetStringSlice("rate-limit"); len(rls) != 0 {
+		rl, err := util.ParseRateLimits(rls)
 		if err != nil {
-			return err
-		}
-		if isHCLDir {
-			for _, k := range dm.Keys() {
-				filep := filepath.Join(hcl, fmt.Sprintf("%s.tf", k))
-
-				f, err := os.OpenFile(filep, os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
-				if err != nil {
-					return fmt.Errorf("could not OpenFile %s because: %s", filep, err)
-				}
-				io.Copy(f, dm.Read(k))
-				f.Close()
-			}
-		} else {
-			f, err := os.OpenFile(viper.GetString("hcl"), os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
-			if err != nil {
-				return fmt.Errorf("could not OpenFile %s because: %s", viper.GetString("hcl"), err)
-			}
-			io.Copy(f, hclOut)
-			f.Close()
+			return c, err
 		}
+		c.RateLimits = rl
 	}
 
-	return nil
-}
-
-// getWriterOptions will initialize the common writer.Options from the flags
-func getWriterOptions() (*writer.Options, error) {
-	var module string
-	var mv = make(map[string]struct{})
-	if m := viper.GetString("module"); m != "" {
-		module = filepath.Base(m)
-
-		if pmv := viper.GetString("module-variables"); pmv != "" {
-			b, err := ioutil.ReadFile(pmv)
-			if err != nil {
-				return nil, fmt.Errorf("could not ReadFile on path %q: %w", pmv, err)
-			}
+	where, err := initializeExpr("where")
+	if err != nil {
+		return c, err
+	}
+	c.Where = where
 
-			var values map[string][]string
-			switch filepath.Ext(pmv) {
-			case ".yml", ".yaml":
-				err := yaml.Unmarshal(b, &values)
-				if err != nil {
-					return nil, fmt.Errorf("invalid YAML on module-variables file %s: %w", pmv, err)
-				}
-			case ".json":
-				err = json.Unmarshal(b, &values)
-				if err != nil {
-					return nil, fmt.Errorf("invalid JSON on module-variables file %s: %w", pmv, err)
-				}
-			default:
-				return nil, fmt.Errorf("invalid module-variables %s, only supported extensions are yaml/yml/json", pmv)
-			}
+	if jp := viper.GetString("resume"); jp != "" {
+		c.Journal = jp
+		c.Resume = true
+	}
 
-			for k, v := range values {
-				for _, vv := range v {
-					mv[fmt.Sprintf("%s.%s", k, vv)] = struct{}{}
-				}
-			}
+	if pmv := viper.GetString("module-variables"); pmv != "" && c.Module != "" {
+		mv, err := terracognita.ReadModuleVariables(pmv)
+		if err != nil {
+			return c, err
 		}
+		c.ModuleVariables = mv
 	}
 
-	return &writer.Options{
-		Interpolate:      viper.GetBool("interpolate"),
-		Module:           module,
-		ModuleVariables:  mv,
-		HCLProviderBlock: viper.GetBool("hcl-provider-block"),
-	}, nil
+	return c, nil
 }
 
-func importProvider(ctx context.Context, logger kitlog.Logger, p provider.Provider, tags []tag.Tag) error {
-	f := &filter.Filter{
-		Include: include,
-		Exclude: exclude,
-		Targets: targets,
-		Tags:    tags,
+// importProvider runs the import of the c
+// rendering the progress with the --output-format
+func importProvider(ctx context.Context, logger kitlog.Logger, c terracognita.Config) error {
+	format := viper.GetString("output-format")
+	if format == outputFormatTTY {
+		fmt.Fprintf(logsOut, "Starting Terracognita with version %s\n", Version)
+	}
+	logger.Log("msg", "starting terracognita", "version", Version)
+
+	out := logsOut
+	if c.DryRun {
+		// The list of resources is the result of
+		// the dry run so it's always printed
+		out = os.Stdout
 	}
 
-	var hclW, stateW writer.Writer
-	options, err := getWriterOptions()
+	h, err := newEventHandler(format, out)
 	if err != nil {
 		return err
 	}
+	c.Events = h
+
+	_, err = terracognita.Run(ctx, c)
+	return err
+}
+
+// List of the formats of the --output-format
+const (
+	outputFormatTTY   = "tty"
+	outputFormatPlain = "plain"
+	outputFormatJSON  = "json"
+)
 
-	if hclOut != nil {
-		logger.Log("msg", "initializing HCL writer")
-		hclW = hcl.NewWriter(hclOut, p, options)
+// newEventHandler returns the event.Handler that
+// renders the import progress on the format to w
+func newEventHandler(format string, w io.Writer) (event.Handler, error) {
+	switch format {
+	case outputFormatTTY:
+		return event.NewTTY(w), nil
+	case outputFormatPlain:
+		return event.NewPlain(w), nil
+	case outputFormatJSON:
+		return event.NewJSON(w), nil
+	default:
+		return nil, fmt.Errorf("invalid --output-format %q, the valid ones are: %s, %s, %s", format, outputFormatTTY, outputFormatPlain, outputFormatJSON)
 	}
+}
 
-	if stateOut != nil {
-		logger.Log("msg", "initializing TFState writer")
-		stateW = state.NewWriter(stateOut, options)
+// newContext returns a context that is canceled on SIGINT or SIGTERM
+// and, if the --timeout is defined, when it's reached.
+// After the first signal the next ones are not captured
+// so they kill the process
+func newContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	cancel := stop
+	if t := viper.GetDuration("timeout"); t > 0 {
+		var tcancel context.CancelFunc
+		ctx, tcancel = context.WithTimeout(ctx, t)
+		cancel = func() {
+			tcancel()
+			stop()
+		}
 	}
 
-	logger.Log("msg", "importing")
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
 
-	fmt.Fprintf(logsOut, "Starting Terracognita with version %s\n", Version)
-	logger.Log("msg", "starting terracognita", "version", Version)
-	err = provider.Import(ctx, p, hclW, stateW, f, logsOut)
-	if err != nil {
-		return errors.Wrap(err, "could not import from "+p.String())
+	return ctx, cancel
+}
+
+// initializeExpr returns the expression of the flagName, which can be the tags,
+// as different providers have diferent for them (google names them lables) we
+// need to know the actual name of the flag, or the --where. The values of the flag
+// are joined with AND so they have to match all of them, and it's nil if there are none
+func initializeExpr(flagName string) (tag.Expr, error) {
+	// The ENV is one string that can not
+	// be split as the expressions have spaces
+	var ts []string
+	if s, ok := viper.Get(flagName).(string); ok {
+		ts = []string{s}
+	} else {
+		ts = viper.GetStringSlice(flagName)
+	}
+	if len(ts) == 0 || (len(ts) == 1 && strings.TrimSpace(ts[0]) == "") {
+		return nil, nil
 	}
 
-	return nil
-}
+	es := make([]string, 0, len(ts))
+	for _, t := range ts {
+		es = append(es, "("+t+")")
+	}
 
-// initializeTags returns the list of tags for the flagName, as different
-// providers have diferent for them (google names them lables) we need to
-// know the actual name of the flag
-func initializeTags(flagName string) ([]tag.Tag, error) {
-	// Initialize the tags
-	tags := make([]tag.Tag, 0, len(viper.GetStringSlice(flagName)))
-	for _, t := range viper.GetStringSlice(flagName) {
-		tg, err := tag.New(t)
-		if err != nil {
-			return nil, fmt.Errorf("invalid format for --%s with value %q: %w", flagName, t, err)
-		}
-		tags = append(tags, tg)
+	e, err := tag.Parse(strings.Join(es, " AND "))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s: %w", flagName, err)
 	}
-	return tags, nil
+	return e, nil
 }
 
 func init() {
@@ -353,6 +309,7 @@ func init() {
 	RootCmd.AddCommand(googleCmd)
 	RootCmd.AddCommand(azurermCmd)
 	RootCmd.AddCommand(vsphereCmd)
+	RootCmd.AddCommand(importCmd)
 	RootCmd.AddCommand(versionCmd)
 
 	RootCmd.PersistentFlags().String("hcl", "", "HCL output file or directory. If it's a directory it'll be emptied before importing")
@@ -361,21 +318,84 @@ func init() {
 	RootCmd.PersistentFlags().String("tfstate", "", "TFState output file")
 	_ = viper.BindPFlag("tfstate", RootCmd.PersistentFlags().Lookup("tfstate"))
 
+	RootCmd.PersistentFlags().Bool("import-blocks", false, "Generate, instead of the --tfstate, an imports.tf with the Terraform (1.5+) 'import {}' block of each resource. It's written on the directory of the --hcl or on the --module")
+	_ = viper.BindPFlag("import-blocks", RootCmd.PersistentFlags().Lookup("import-blocks"))
+
+	RootCmd.PersistentFlags().String("existing-state", "", "TFState file of a previous import, the resources on it are not imported again and keep the same address so only the new ones are imported. It can be the same file as the --tfstate")
+	_ = viper.BindPFlag("existing-state", RootCmd.PersistentFlags().Lookup("existing-state"))
+
+	RootCmd.PersistentFlags().StringSlice("managed-state", []string{}, "List of TFState files, or directories with them, of resources already managed by other Terraform configurations, those resources are not imported")
+	_ = viper.BindPFlag("managed-state", RootCmd.PersistentFlags().Lookup("managed-state"))
+
 	RootCmd.PersistentFlags().String("module", "", "Generates the output in module format into the directory specified. With this flag (--module) the --hcl is ignored and will be generated inside of the module")
 	_ = viper.BindPFlag("module", RootCmd.PersistentFlags().Lookup("module"))
 
 	RootCmd.PersistentFlags().String("module-variables", "", "Path to a file containing the list of attributes to use as variables when building the module. The format is a JSON/YAML, more information on https://github.com/cycloidio/terracognita#modules")
 	_ = viper.BindPFlag("module-variables", RootCmd.PersistentFlags().Lookup("module-variables"))
 
-	RootCmd.PersistentFlags().StringSliceVarP(&include, "include", "i", []string{}, "List of resources to import, this names are the ones on TF (ex: aws_instance). If not set then means that all the resources will be imported")
+	RootCmd.PersistentFlags().StringSliceVarP(&include, "include", "i", []string{}, "List of resources to import, this names are the ones on TF (ex: aws_instance) or patterns of them: globs (ex: aws_iam_*), regular expressions between '/' (ex: /^aws_(iam|s3)_/) or negations with '!' (ex: !aws_iam_user_*). If not set then means that all the resources will be imported")
 	_ = viper.BindPFlag("include", RootCmd.PersistentFlags().Lookup("include"))
 
-	RootCmd.PersistentFlags().StringSliceVarP(&exclude, "exclude", "e", []string{}, "List of resources to not import, this names are the ones on TF (ex: aws_instance). If not set then means that none the resources will be excluded")
+	RootCmd.PersistentFlags().StringSliceVarP(&exclude, "exclude", "e", []string{}, "List of resources to not import, this names are the ones on TF (ex: aws_instance) or patterns of them as on the --include. If not set then means that none the resources will be excluded")
 	_ = viper.BindPFlag("exclude", RootCmd.PersistentFlags().Lookup("exclude"))
 
 	RootCmd.PersistentFlags().StringSliceVar(&targets, "target", []string{}, "List of resources to import via ID, those IDs are the ones documented on Terraform that are needed to Import. The format is 'aws_instance.ID'")
 	_ = viper.BindPFlag("target", RootCmd.PersistentFlags().Lookup("target"))
 
+	RootCmd.PersistentFlags().String("id-pattern", "", "Regular expression that the ID of the resources has to match to be imported (ex: ^payments-)")
+	_ = viper.BindPFlag("id-pattern", RootCmd.PersistentFlags().Lookup("id-pattern"))
+
+	RootCmd.PersistentFlags().String("name-pattern", "", "Regular expression that the name of the resources has to match to be imported, which is the 'name' attribute or, on AWS, the 'Name' tag if the resource has none (ex: ^payments-)")
+	_ = viper.BindPFlag("name-pattern", RootCmd.PersistentFlags().Lookup("name-pattern"))
+
+	RootCmd.PersistentFlags().StringArray("where", []string{}, "Expression of the attributes that the resources have to match once read to be imported, with the same format as the tags and '=' and '!=' (ex: 'instance_type = t2.* AND vpc_id != vpc-123'). If set more than once the resources have to match all of them")
+	_ = viper.BindPFlag("where", RootCmd.PersistentFlags().Lookup("where"))
+
+	RootCmd.PersistentFlags().Int("parallelism", 1, "Number of resource types listed and of resources read at the same time")
+	_ = viper.BindPFlag("parallelism", RootCmd.PersistentFlags().Lookup("parallelism"))
+
+	RootCmd.PersistentFlags().String("journal", "", "Journal output file, each imported resource is recorded on it so the import can be resumed with --resume")
+	_ = viper.BindPFlag("journal", RootCmd.PersistentFlags().Lookup("journal"))
+
+	RootCmd.PersistentFlags().String("resume", "", "Journal file of a previous import to resume from, the resources on it are not imported again and the new ones are appended to it")
+	_ = viper.BindPFlag("resume", RootCmd.PersistentFlags().Lookup("resume"))
+
+	RootCmd.PersistentFlags().String("report", "", "Report output file, it has in JSON the outcome of each resource found and if the import was complete")
+	_ = viper.BindPFlag("report", RootCmd.PersistentFlags().Lookup("report"))
+
+	RootCmd.PersistentFlags().String("graph", "", "Graph output file of the imported resources and the references between them, the format depends on the extension: .dot (Graphviz), .mmd (Mermaid) or .json")
+	_ = viper.BindPFlag("graph", RootCmd.PersistentFlags().Lookup("graph"))
+
+	RootCmd.PersistentFlags().Bool("dry-run", false, "Only list the resources that would be imported, with their type and ID, without reading them nor writing any output")
+	_ = viper.BindPFlag("dry-run", RootCmd.PersistentFlags().Lookup("dry-run"))
+
+	RootCmd.PersistentFlags().Bool("strict", false, "Stop the import on the first resource that fails to be imported")
+	_ = viper.BindPFlag("strict", RootCmd.PersistentFlags().Lookup("strict"))
+
+	RootCmd.PersistentFlags().Int("max-errors", 0, "Maximum number of resources that can fail to be imported before stopping the import. If 0 there is no maximum")
+	_ = viper.BindPFlag("max-errors", RootCmd.PersistentFlags().Lookup("max-errors"))
+
+	RootCmd.PersistentFlags().Float64("max-error-ratio", 0, "Maximum ratio, from 0 to 1, of resources that can fail to be imported, if it's higher the import fails. If 0 there is no maximum")
+	_ = viper.BindPFlag("max-error-ratio", RootCmd.PersistentFlags().Lookup("max-error-ratio"))
+
+	RootCmd.PersistentFlags().Duration("timeout", 0, "Maximum time the import can take, once reached the import stops and the resources already imported are written. If 0 there is no maximum")
+	_ = viper.BindPFlag("timeout", RootCmd.PersistentFlags().Lookup("timeout"))
+
+	RootCmd.PersistentFlags().Duration("resource-timeout", 0, "Maximum time the import of one resource can take, once reached the resource is considered failed and the import continues. If 0 there is no maximum")
+	_ = viper.BindPFlag("resource-timeout", RootCmd.PersistentFlags().Lookup("resource-timeout"))
+
+	RootCmd.PersistentFlags().Int("max-retries", util.MaxRetriesDefault, "Number of times that the listing and the read of the resources are retried when they fail with a throttling or temporary error of the provider. If 0 they are not retried")
+	_ = viper.BindPFlag("max-retries", RootCmd.PersistentFlags().Lookup("max-retries"))
+
+	RootCmd.PersistentFlags().Duration("retry-max-wait", util.RetryMaxWaitDefault, "Maximum wait between the retries, which grows exponentially, also when the provider asks to wait more (ex: Retry-After)")
+	_ = viper.BindPFlag("retry-max-wait", RootCmd.PersistentFlags().Lookup("retry-max-wait"))
+
+	RootCmd.PersistentFlags().StringSlice("rate-limit", []string{}, "Maximum requests to a service of the provider, with the format 'service=rate[/unit]' (ex: iam=5/s,route53=3/s), the unit can be s, m or h and the service '*' for all the ones without limit. They are set over the defaults of each provider and 0 removes the limit")
+	_ = viper.BindPFlag("rate-limit", RootCmd.PersistentFlags().Lookup("rate-limit"))
+
+	RootCmd.PersistentFlags().String("output-format", outputFormatTTY, "Format of the import progress output: tty, plain (one line per event) or json (one JSON object per event)")
+	_ = viper.BindPFlag("output-format", RootCmd.PersistentFlags().Lookup("output-format"))
+
 	RootCmd.PersistentFlags().BoolP("verbose", "v", false, "Activate the verbose mode")
 	_ = viper.BindPFlag("verbose", RootCmd.PersistentFlags().Lookup("verbose"))
 
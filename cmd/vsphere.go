@@ -1,14 +1,12 @@
 package cmd
 
 import (
-	"context"
-
 	kitlog "github.com/go-kit/kit/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/cycloidio/terracognita/log"
-	"github.com/cycloidio/terracognita/vsphere"
+	"github.com/cycloidio/terracognita/terracognita"
 )
 
 var (
@@ -21,11 +19,7 @@ var (
 			if err != nil {
 				return err
 			}
-			viper.BindPFlag("soap-url", cmd.Flags().Lookup("soap-url"))
-			viper.BindPFlag("username", cmd.Flags().Lookup("username"))
-			viper.BindPFlag("password", cmd.Flags().Lookup("password"))
-			viper.BindPFlag("vsphereserver", cmd.Flags().Lookup("vsphereserver"))
-			viper.BindPFlag("insecure", cmd.Flags().Lookup("insecure"))
+			bindVSphereFlags(cmd)
 
 			return nil
 		},
@@ -33,26 +27,16 @@ var (
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger := log.Get()
 			logger = kitlog.With(logger, "func", "cmd.vsphere.RunE")
-			// Validate required flags
-			if err := requiredStringFlags("soap-url", "username", "password"); err != nil {
-				return err
-			}
-
-			ctx := context.Background()
 
-			vsphereProvider, err := vsphere.NewProvider(
-				ctx,
-				viper.GetString("soap-url"),
-				viper.GetString("username"),
-				viper.GetString("password"),
-				viper.GetString("vsphereserver"),
-				viper.GetBool("insecure"),
-			)
+			c, err := newVSphereConfig()
 			if err != nil {
 				return err
 			}
 
-			err = importProvider(ctx, logger, vsphereProvider, noTags)
+			ctx, cancel := newContext()
+			defer cancel()
+
+			err = importProvider(ctx, logger, c)
 			if err != nil {
 				return err
 			}
@@ -62,13 +46,47 @@ var (
 	}
 )
 
+// bindVSphereFlags binds the vSphere flags of the cmd to viper
+func bindVSphereFlags(cmd *cobra.Command) {
+	viper.BindPFlag("soap-url", cmd.Flags().Lookup("soap-url"))
+	viper.BindPFlag("username", cmd.Flags().Lookup("username"))
+	viper.BindPFlag("password", cmd.Flags().Lookup("password"))
+	viper.BindPFlag("vsphereserver", cmd.Flags().Lookup("vsphereserver"))
+	viper.BindPFlag("insecure", cmd.Flags().Lookup("insecure"))
+}
+
+// newVSphereConfig returns the terracognita.Config
+// with the vSphere flags
+func newVSphereConfig() (terracognita.Config, error) {
+	// Validate required flags
+	if err := requiredStringFlags("soap-url", "username", "password"); err != nil {
+		return terracognita.Config{}, err
+	}
+
+	c, err := newConfig(noTags)
+	if err != nil {
+		return c, err
+	}
+
+	c.VSphere = &terracognita.VSphereConfig{
+		SoapURL:       viper.GetString("soap-url"),
+		Username:      viper.GetString("username"),
+		Password:      viper.GetString("password"),
+		VSphereServer: viper.GetString("vsphereserver"),
+		Insecure:      viper.GetBool("insecure"),
+	}
+
+	return c, nil
+}
+
 func init() {
 	vsphereCmd.AddCommand(vsphereResourcesCmd)
+	vsphereCmd.AddCommand(newDriftCmd("vsphere", bindVSphereFlags, newVSphereConfig))
 
 	// Required flags
-	vsphereCmd.Flags().String("soap-url", "", "URL of a vCenter or ESXi instance (required)")
-	vsphereCmd.Flags().String("username", "", "Username (required)")
-	vsphereCmd.Flags().String("password", "", "Password (required)")
-	vsphereCmd.Flags().String("vsphereserver", "", "This is the vCenter Server FQDN or IP Address for vSphere API operations (required)")
-	vsphereCmd.Flags().Bool("insecure", true, "Insecure")
+	vsphereCmd.PersistentFlags().String("soap-url", "", "URL of a vCenter or ESXi instance (required)")
+	vsphereCmd.PersistentFlags().String("username", "", "Username (required)")
+	vsphereCmd.PersistentFlags().String("password", "", "Password (required)")
+	vsphereCmd.PersistentFlags().String("vsphereserver", "", "This is the vCenter Server FQDN or IP Address for vSphere API operations (required)")
+	vsphereCmd.PersistentFlags().Bool("insecure", true, "Insecure")
 }
@@ -0,0 +1,3 @@
+// Package drift has the Report of the differences between
+// a TFState and the current resources of a Provider
+package drift
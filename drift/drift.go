@@ -0,0 +1,227 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Status is the drift of a resource
+type Status string
+
+// List of all the Status a resource can have
+const (
+	// StatusInSync is for the resources that
+	// have the same attributes as on the state
+	StatusInSync Status = "in_sync"
+
+	// StatusChanged is for the resources that have
+	// different attributes than on the state
+	StatusChanged Status = "changed"
+
+	// StatusDeleted is for the resources on the
+	// state that do not exist anymore
+	StatusDeleted Status = "deleted"
+
+	// StatusUnmanaged is for the resources that
+	// exist but are not on the state
+	StatusUnmanaged Status = "unmanaged"
+
+	// StatusError is for the resources that
+	// could not be read to be compared
+	StatusError Status = "error"
+)
+
+// Change is the change of one attribute of a resource
+type Change struct {
+	Attribute string `json:"attribute"`
+
+	// Expected is the value on the state
+	Expected string `json:"expected"`
+
+	// Actual is the current value
+	Actual string `json:"actual"`
+}
+
+// Resource is the drift of one resource
+type Resource struct {
+	// Type is the type of the resource (ex: aws_instance)
+	Type string `json:"type"`
+
+	// ID is the ID of the resource, it's empty
+	// if the resources of the Type could not
+	// be listed
+	ID string `json:"id,omitempty"`
+
+	// Address is the address of the resource on the
+	// state (ex: aws_instance.front), it's empty
+	// if it's StatusUnmanaged
+	Address string `json:"address,omitempty"`
+
+	Status  Status   `json:"status"`
+	Changes []Change `json:"changes,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// Report holds the drift of all the resources compared.
+// It's concurrently safe
+type Report struct {
+	provider  string
+	resources []Resource
+	mu        sync.Mutex
+}
+
+// New returns a new Report for the provider
+func New(provider string) *Report {
+	return &Report{
+		provider: provider,
+	}
+}
+
+// Add adds the res to the Report
+func (r *Report) Add(res Resource) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.resources = append(r.resources, res)
+}
+
+// AddCompared adds the resource of type t, id and address with
+// the Changes between the expected and the actual attributes,
+// so it's StatusInSync if there are none or StatusChanged if not
+func (r *Report) AddCompared(t, id, address string, expected, actual map[string]string) {
+	res := Resource{
+		Type:    t,
+		ID:      id,
+		Address: address,
+		Status:  StatusInSync,
+		Changes: Diff(expected, actual),
+	}
+	if len(res.Changes) != 0 {
+		res.Status = StatusChanged
+	}
+
+	r.Add(res)
+}
+
+// Diff returns the Changes, sorted by Attribute, between
+// the expected and the actual attributes. The ones
+// that are not on one of them are compared as empty
+func Diff(expected, actual map[string]string) []Change {
+	var changes []Change
+	for k, ev := range expected {
+		if av := actual[k]; av != ev {
+			changes = append(changes, Change{Attribute: k, Expected: ev, Actual: av})
+		}
+	}
+	for k, av := range actual {
+		if _, ok := expected[k]; !ok && av != "" {
+			changes = append(changes, Change{Attribute: k, Actual: av})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Attribute < changes[j].Attribute
+	})
+
+	return changes
+}
+
+// Resources returns all the resources of the Report
+// sorted by Type and ID
+func (r *Report) Resources() []Resource {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := append([]Resource(nil), r.resources...)
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Type != res[j].Type {
+			return res[i].Type < res[j].Type
+		}
+		return res[i].ID < res[j].ID
+	})
+
+	return res
+}
+
+// HasDrift checks if any of the resources is
+// not StatusInSync
+func (r *Report) HasDrift() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, res := range r.resources {
+		if res.Status != StatusInSync {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Summary returns the number of resources for each Status
+func (r *Report) Summary() map[Status]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := make(map[Status]int)
+	for _, res := range r.resources {
+		s[res.Status]++
+	}
+
+	return s
+}
+
+// report is the JSON representation of the Report
+type report struct {
+	Provider  string         `json:"provider"`
+	Drift     bool           `json:"drift"`
+	Summary   map[Status]int `json:"summary"`
+	Resources []Resource     `json:"resources"`
+}
+
+// Write writes the Report as JSON to w
+func (r *Report) Write(w io.Writer) error {
+	rep := report{
+		Provider:  r.provider,
+		Drift:     r.HasDrift(),
+		Summary:   r.Summary(),
+		Resources: r.Resources(),
+	}
+	if rep.Resources == nil {
+		rep.Resources = make([]Resource, 0)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(rep)
+}
+
+// WriteText writes the Report as human-readable text to w,
+// only the resources that are not StatusInSync are written
+func (r *Report) WriteText(w io.Writer) error {
+	for _, res := range r.Resources() {
+		switch res.Status {
+		case StatusChanged:
+			fmt.Fprintf(w, "~ %s (%s) changed:\n", res.Address, res.ID)
+			for _, c := range res.Changes {
+				fmt.Fprintf(w, "    %s: %q => %q\n", c.Attribute, c.Expected, c.Actual)
+			}
+		case StatusDeleted:
+			fmt.Fprintf(w, "- %s (%s) deleted\n", res.Address, res.ID)
+		case StatusUnmanaged:
+			fmt.Fprintf(w, "+ %s (%s) unmanaged\n", res.Type, res.ID)
+		case StatusError:
+			fmt.Fprintf(w, "! %s (%s) error: %s\n", res.Type, res.ID, res.Error)
+		}
+	}
+
+	s := r.Summary()
+	_, err := fmt.Fprintf(w, "Drift of %s: %d in sync, %d changed, %d deleted, %d unmanaged, %d errors\n",
+		r.provider, s[StatusInSync], s[StatusChanged], s[StatusDeleted], s[StatusUnmanaged], s[StatusError])
+
+	return err
+}
@@ -0,0 +1,76 @@
+package drift_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/drift"
+)
+
+func TestDiff(t *testing.T) {
+	changes := drift.Diff(
+		map[string]string{"id": "1", "name": "admin", "path": "/"},
+		map[string]string{"id": "1", "name": "root", "tags.%": "1"},
+	)
+
+	assert.Equal(t, []drift.Change{
+		{Attribute: "name", Expected: "admin", Actual: "root"},
+		{Attribute: "path", Expected: "/", Actual: ""},
+		{Attribute: "tags.%", Expected: "", Actual: "1"},
+	}, changes)
+}
+
+func newReport() *drift.Report {
+	rep := drift.New("aws")
+	rep.AddCompared("aws_iam_user", "1", "aws_iam_user.admin", map[string]string{"name": "admin"}, map[string]string{"name": "root"})
+	rep.AddCompared("aws_iam_user", "2", "aws_iam_user.dev", map[string]string{"name": "dev"}, map[string]string{"name": "dev"})
+	rep.Add(drift.Resource{Type: "aws_iam_user", ID: "3", Address: "aws_iam_user.old", Status: drift.StatusDeleted})
+	rep.Add(drift.Resource{Type: "aws_iam_user", ID: "4", Status: drift.StatusUnmanaged})
+
+	return rep
+}
+
+func TestWriteText(t *testing.T) {
+	var b bytes.Buffer
+
+	err := newReport().WriteText(&b)
+	require.NoError(t, err)
+
+	assert.Equal(t, `~ aws_iam_user.admin (1) changed:
+    name: "admin" => "root"
+- aws_iam_user.old (3) deleted
++ aws_iam_user (4) unmanaged
+Drift of aws: 1 in sync, 1 changed, 1 deleted, 1 unmanaged, 0 errors
+`, b.String())
+}
+
+func TestWrite(t *testing.T) {
+	var b bytes.Buffer
+
+	rep := drift.New("aws")
+	rep.AddCompared("aws_iam_user", "1", "aws_iam_user.admin", map[string]string{"name": "admin"}, map[string]string{"name": "admin"})
+
+	err := rep.Write(&b)
+	require.NoError(t, err)
+
+	assert.False(t, rep.HasDrift())
+	assert.Equal(t, `{
+  "provider": "aws",
+  "drift": false,
+  "summary": {
+    "in_sync": 1
+  },
+  "resources": [
+    {
+      "type": "aws_iam_user",
+      "id": "1",
+      "address": "aws_iam_user.admin",
+      "status": "in_sync"
+    }
+  ]
+}
+`, b.String())
+}
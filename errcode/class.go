@@ -0,0 +1,113 @@
+package errcode
+
+import "errors"
+
+// Class is the classification of an error
+// depending on how it affects the import
+type Class int
+
+// List of all the Classes
+const (
+	// ClassSkipped is for the errors that mean that the
+	// resource was intentionally not imported
+	ClassSkipped Class = iota
+
+	// ClassRecoverable is for the errors that only affect
+	// the resource, so the import can continue without it
+	ClassRecoverable
+
+	// ClassFatal is for the errors after which the
+	// import can not continue
+	ClassFatal
+)
+
+var (
+	skipped = []error{
+		ErrProviderResourceDoNotMatchTag,
+		ErrProviderResourceDoNotMatchPattern,
+		ErrProviderResourceDoNotMatchWhere,
+		ErrProviderResourceAutogenerated,
+	}
+
+	fatal = []error{
+		ErrProviderResourceNotSupported,
+		ErrWriterRequiredKey,
+		ErrWriterRequiredValue,
+		ErrWriterInvalidKey,
+		ErrWriterInvalidTypeValue,
+		ErrWriterAlreadyExistsKey,
+		ErrFilterTargetsInvalid,
+		ErrFilterPatternInvalid,
+		ErrTagInvalidForamt,
+		ErrTagInvalidExpression,
+		ErrImportTooManyFailures,
+	}
+
+	// codes are all the errors that can be
+	// returned by Code
+	codes = []error{
+		ErrProviderResourceNotSupported,
+		ErrProviderResourceNotRead,
+		ErrProviderResourceDoNotMatchTag,
+		ErrProviderResourceDoNotMatchPattern,
+		ErrProviderResourceDoNotMatchWhere,
+		ErrProviderResourceAutogenerated,
+		ErrCacheKeyNotFound,
+		ErrCacheKeyAlreadyExisting,
+		ErrWriterRequiredKey,
+		ErrWriterRequiredValue,
+		ErrWriterInvalidKey,
+		ErrWriterInvalidTypeValue,
+		ErrWriterAlreadyExistsKey,
+		ErrFilterTargetsInvalid,
+		ErrFilterPatternInvalid,
+		ErrTagInvalidForamt,
+		ErrTagInvalidExpression,
+		ErrProviderAPI,
+		ErrImportTooManyFailures,
+	}
+)
+
+// String returns the name of the Class
+func (c Class) String() string {
+	switch c {
+	case ClassSkipped:
+		return "skipped"
+	case ClassRecoverable:
+		return "recoverable"
+	case ClassFatal:
+		return "fatal"
+	default:
+		return "unknown"
+	}
+}
+
+// Classify returns the Class of the err. The errors
+// that are not known are considered recoverable
+func Classify(err error) Class {
+	for _, e := range skipped {
+		if errors.Is(err, e) {
+			return ClassSkipped
+		}
+	}
+
+	for _, e := range fatal {
+		if errors.Is(err, e) {
+			return ClassFatal
+		}
+	}
+
+	return ClassRecoverable
+}
+
+// Code returns the error code of the err, if it
+// does not have any it returns nil
+func Code(err error) error {
+	for _, e := range codes {
+		if errors.Is(err, e) {
+			return e
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package errcode_test
+
+import (
+	"testing"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		class errcode.Class
+	}{
+		{name: "Skipped", err: errors.WithStack(errcode.ErrProviderResourceDoNotMatchTag), class: errcode.ClassSkipped},
+		{name: "Recoverable", err: errors.Wrap(errcode.ErrProviderResourceNotRead, "id"), class: errcode.ClassRecoverable},
+		{name: "RecoverableUnknown", err: errors.New("failed"), class: errcode.ClassRecoverable},
+		{name: "Fatal", err: errors.Wrap(errcode.ErrWriterAlreadyExistsKey, "aws_instance.front"), class: errcode.ClassFatal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.class, errcode.Classify(tt.err))
+		})
+	}
+}
+
+func TestCode(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		assert.Equal(t, errcode.ErrProviderAPI, errcode.Code(errors.Wrap(errcode.ErrProviderAPI, "access denied")))
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		assert.Nil(t, errcode.Code(errors.New("failed")))
+	})
+}
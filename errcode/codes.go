@@ -4,10 +4,12 @@ import "errors"
 
 // List of all the error Codes used
 var (
-	ErrProviderResourceNotSupported  = errors.New("the resource type is not supported")
-	ErrProviderResourceNotRead       = errors.New("the resource did not return an ID")
-	ErrProviderResourceDoNotMatchTag = errors.New("the resource does not match the required tags")
-	ErrProviderResourceAutogenerated = errors.New("the resource is autogenerated and should not be imported")
+	ErrProviderResourceNotSupported      = errors.New("the resource type is not supported")
+	ErrProviderResourceNotRead           = errors.New("the resource did not return an ID")
+	ErrProviderResourceDoNotMatchTag     = errors.New("the resource does not match the required tags")
+	ErrProviderResourceDoNotMatchPattern = errors.New("the resource does not match the required ID or name patterns")
+	ErrProviderResourceDoNotMatchWhere   = errors.New("the resource does not match the where expression")
+	ErrProviderResourceAutogenerated     = errors.New("the resource is autogenerated and should not be imported")
 
 	ErrCacheKeyNotFound        = errors.New("the key used to search was not found")
 	ErrCacheKeyAlreadyExisting = errors.New("the key already exists on the cache")
@@ -19,8 +21,12 @@ var (
 	ErrWriterAlreadyExistsKey = errors.New("the key already exists")
 
 	ErrFilterTargetsInvalid = errors.New("the filter targets has an invalid format")
+	ErrFilterPatternInvalid = errors.New("the filter pattern is invalid")
 
-	ErrTagInvalidForamt = errors.New("invalid format for tag, the expected format is 'NAME:VALUE'")
+	ErrTagInvalidForamt     = errors.New("invalid format for tag, the expected format is 'NAME:VALUE'")
+	ErrTagInvalidExpression = errors.New("invalid tags expression")
+
+	ErrImportTooManyFailures = errors.New("too many resources failed to be imported")
 
 	// ErrProviderAPI will be raised when an error occurs provider side while
 	// using its APIs (authorization error, unavailable operation, ...)
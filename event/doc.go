@@ -0,0 +1,4 @@
+// Package event has the Events sent during an import
+// so the progress can be followed, and the Handlers
+// that render them
+package event
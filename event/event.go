@@ -0,0 +1,138 @@
+package event
+
+import "time"
+
+// Type is the type of an Event
+type Type string
+
+// List of all the Types of Events
+const (
+	// TypeImportStarted is sent when the import starts
+	// with the filters used as Message
+	TypeImportStarted Type = "import_started"
+
+	// TypeImportDone is sent when the import is done, with the Total of
+	// resources tried to import and the Error if it failed
+	TypeImportDone Type = "import_done"
+
+	// TypeImportFailures is sent before the TypeImportDone, if any
+	// resource failed to be imported, with the Failures and the
+	// Total of resources tried to import
+	TypeImportFailures Type = "import_failures"
+
+	// TypeResourceTypeStarted is sent when the resources of
+	// the ResourceType are going to be imported
+	TypeResourceTypeStarted Type = "resource_type_started"
+
+	// TypeResourceTypeDone is sent when all the resources of
+	// the ResourceType have been imported, with the Total
+	TypeResourceTypeDone Type = "resource_type_done"
+
+	// TypeResourceDiscovered is sent for each resource
+	// listed from the provider, before it's read
+	TypeResourceDiscovered Type = "resource_discovered"
+
+	// TypeResourceStarted is sent when a resource starts to
+	// be imported with the Current of the Total of the ResourceType
+	TypeResourceStarted Type = "resource_started"
+
+	// TypeResourceImported is sent when a resource
+	// has been imported with the Address it has
+	TypeResourceImported Type = "resource_imported"
+
+	// TypeResourceSkipped is sent when a resource has
+	// been intentionally not imported with the Reason
+	TypeResourceSkipped Type = "resource_skipped"
+
+	// TypeResourceFailed is sent when a resource failed to be
+	// imported with the Error and the Reason as the error code.
+	// If the ID is empty it means that the ResourceType
+	// could not be listed
+	TypeResourceFailed Type = "resource_failed"
+
+	// TypeWriterSyncStarted is sent when the Writer
+	// starts to write the output
+	TypeWriterSyncStarted Type = "writer_sync_started"
+
+	// TypeWriterSyncDone is sent when the Writer
+	// has written the output
+	TypeWriterSyncDone Type = "writer_sync_done"
+)
+
+// ReasonManaged is the Reason of the TypeResourceSkipped of the
+// resources already managed by other Terraform configurations
+const ReasonManaged = "managed"
+
+// ReasonFilteredByWhere is the Reason of the TypeResourceSkipped of
+// the resources that once read do not match the where expression
+const ReasonFilteredByWhere = "filtered_by_where"
+
+// List of the Writers names
+const (
+	WriterHCL     = "hcl"
+	WriterTFState = "tfstate"
+)
+
+// Event is something that happened during the import,
+// depending on the Type it has some of the attributes
+type Event struct {
+	Type Type      `json:"type"`
+	Time time.Time `json:"time"`
+
+	// ResourceType is the type of the resource (ex: aws_instance)
+	ResourceType string `json:"resource_type,omitempty"`
+	ID           string `json:"id,omitempty"`
+	Address      string `json:"address,omitempty"`
+
+	// Writer is the name of the writer that is synced
+	Writer string `json:"writer,omitempty"`
+
+	Current int `json:"current,omitempty"`
+	Total   int `json:"total,omitempty"`
+
+	// DryRun is set on the TypeImportStarted and TypeImportDone
+	// when the resources are only listed
+	DryRun bool `json:"dry_run,omitempty"`
+
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	Error   string `json:"error,omitempty"`
+
+	// Failures are the resources that failed
+	// to be imported on the TypeImportFailures
+	Failures []Failure `json:"failures,omitempty"`
+}
+
+// Failure is a resource that failed to be imported with the
+// Reason as the error code. If the ID is empty it means
+// that the ResourceType could not be listed
+type Failure struct {
+	ResourceType string `json:"resource_type"`
+	ID           string `json:"id,omitempty"`
+	Reason       string `json:"reason"`
+	Error        string `json:"error"`
+}
+
+// Handler handles the Events of an import, it has
+// to be concurrently safe as the Events can be
+// sent from different goroutines
+type Handler interface {
+	Handle(e Event)
+}
+
+// HandlerFunc is a function that implements the Handler
+type HandlerFunc func(e Event)
+
+// Handle calls the fn with e
+func (fn HandlerFunc) Handle(e Event) { fn(e) }
+
+// Handlers is a list of Handlers that
+// are all called with each Event
+type Handlers []Handler
+
+// Handle calls all the Handlers with e
+func (hs Handlers) Handle(e Event) {
+	for _, h := range hs {
+		h.Handle(e)
+	}
+}
@@ -0,0 +1,255 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// TTY renders the Events to a terminal, with the progress of each type on the
+// same line and a summary of the managed, filtered by the where expression
+// and failures at the end
+type TTY struct {
+	w  io.Writer
+	mu sync.Mutex
+
+	// failures has the number of failures
+	// by resource type and reason
+	failures map[string]map[string]int
+	failed   int
+
+	// managed has the number of resources skipped by resource
+	// type as they are managed by other Terraform configurations
+	managed      map[string]int
+	managedTotal int
+
+	// filtered has the number of resources skipped by
+	// resource type as they do not match the where expression
+	filtered      map[string]int
+	filteredTotal int
+
+	// pending is true when the last line
+	// written has not been ended
+	pending bool
+
+	// dryRun is true when the import only lists the
+	// resources, so each discovered one is written
+	dryRun bool
+}
+
+// NewTTY returns a TTY renderer that writes to w
+func NewTTY(w io.Writer) *TTY {
+	t := &TTY{w: w}
+	t.reset()
+	return t
+}
+
+// Handle writes the e to the terminal
+func (t *TTY) Handle(e Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch e.Type {
+	case TypeImportStarted:
+		// The same TTY can render the imports of more than one
+		// Provider so each one has its own summary
+		t.reset()
+		fmt.Fprintf(t.w, "Importing with filters: %s", e.Message)
+		t.pending = true
+		t.dryRun = e.DryRun
+	case TypeResourceDiscovered:
+		if t.dryRun {
+			t.newLine()
+			fmt.Fprintf(t.w, "%s\t%s\n", e.ResourceType, e.ID)
+		}
+	case TypeResourceStarted:
+		fmt.Fprintf(t.w, "\rImporting %s [%d/%d]", e.ResourceType, e.Current, e.Total)
+		t.pending = true
+	case TypeResourceTypeDone:
+		if e.Total > 0 {
+			fmt.Fprintf(t.w, "\rImporting %s [%d/%d] Done!\n", e.ResourceType, e.Total, e.Total)
+			t.pending = false
+		}
+	case TypeResourceSkipped:
+		switch e.Reason {
+		case ReasonManaged:
+			t.managed[e.ResourceType]++
+			t.managedTotal++
+		case ReasonFilteredByWhere:
+			t.filtered[e.ResourceType]++
+			t.filteredTotal++
+		}
+	case TypeImportFailures:
+		for _, f := range e.Failures {
+			if _, ok := t.failures[f.ResourceType]; !ok {
+				t.failures[f.ResourceType] = make(map[string]int)
+			}
+			t.failures[f.ResourceType][f.Reason]++
+			t.failed++
+		}
+	case TypeWriterSyncStarted:
+		fmt.Fprintf(t.w, "\rWriting %s ...", writerNames[e.Writer])
+		t.pending = true
+	case TypeWriterSyncDone:
+		fmt.Fprintf(t.w, "\rWriting %s Done!\n", writerNames[e.Writer])
+		t.pending = false
+	case TypeImportDone:
+		t.newLine()
+		if e.DryRun {
+			fmt.Fprintf(t.w, "Found %d resources\n", e.Total)
+		}
+		t.skippedSummary("already managed", t.managed, t.managedTotal)
+		t.skippedSummary("not matching the where expression", t.filtered, t.filteredTotal)
+		t.summary(e.Total)
+	}
+}
+
+// reset clears the summaries of the previous import
+func (t *TTY) reset() {
+	t.failures = make(map[string]map[string]int)
+	t.failed = 0
+	t.managed = make(map[string]int)
+	t.managedTotal = 0
+	t.filtered = make(map[string]int)
+	t.filteredTotal = 0
+}
+
+// newLine ends the current line if
+// it was left without ending
+func (t *TTY) newLine() {
+	if t.pending {
+		fmt.Fprintln(t.w)
+		t.pending = false
+	}
+}
+
+// writerNames are the names used to
+// print the Writers on the TTY
+var writerNames = map[string]string{
+	WriterHCL:     "HCL",
+	WriterTFState: "TFState",
+}
+
+// skippedSummary writes the total of resources skipped
+// for the reason and the number of them by resource type
+func (t *TTY) skippedSummary(reason string, skipped map[string]int, total int) {
+	if total == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(skipped))
+	for rt := range skipped {
+		keys = append(keys, rt)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(t.w, "Skipped %d resources %s:\n", total, reason)
+	for _, rt := range keys {
+		fmt.Fprintf(t.w, "  %s: %d\n", rt, skipped[rt])
+	}
+}
+
+// summary writes the number of failures
+// grouped by resource type and reason
+func (t *TTY) summary(total int) {
+	if t.failed == 0 {
+		return
+	}
+
+	tkeys := make([]string, 0, len(t.failures))
+	for rt := range t.failures {
+		tkeys = append(tkeys, rt)
+	}
+	sort.Strings(tkeys)
+
+	fmt.Fprintf(t.w, "Failed to import %d of %d resources:\n", t.failed, total)
+	for _, rt := range tkeys {
+		rkeys := make([]string, 0, len(t.failures[rt]))
+		for r := range t.failures[rt] {
+			rkeys = append(rkeys, r)
+		}
+		sort.Strings(rkeys)
+
+		for _, r := range rkeys {
+			fmt.Fprintf(t.w, "  %s: %d (%s)\n", rt, t.failures[rt][r], r)
+		}
+	}
+}
+
+// Plain renders each Event on one line, and the Failures of the
+// TypeImportFailures on one line each after it, which is
+// useful when the output is not a terminal (ex: CI logs)
+type Plain struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+// NewPlain returns a Plain renderer that writes to w
+func NewPlain(w io.Writer) *Plain {
+	return &Plain{w: w}
+}
+
+// Handle writes the e as one line, and its Failures
+func (p *Plain) Handle(e Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	line := fmt.Sprintf("%s %s", e.Time.Format("2006-01-02T15:04:05Z07:00"), e.Type)
+	for _, kv := range [][2]string{
+		{"resource_type", e.ResourceType},
+		{"id", e.ID},
+		{"address", e.Address},
+		{"writer", e.Writer},
+		{"reason", e.Reason},
+		{"message", e.Message},
+		{"error", e.Error},
+	} {
+		if kv[1] != "" {
+			line += fmt.Sprintf(" %s=%q", kv[0], kv[1])
+		}
+	}
+	if e.Current != 0 {
+		line += fmt.Sprintf(" current=%d", e.Current)
+	}
+	if e.Total != 0 {
+		line += fmt.Sprintf(" total=%d", e.Total)
+	}
+	if e.DryRun {
+		line += " dry_run=true"
+	}
+	if len(e.Failures) != 0 {
+		line += fmt.Sprintf(" failures=%d", len(e.Failures))
+	}
+
+	fmt.Fprintln(p.w, line)
+
+	for _, f := range e.Failures {
+		line := fmt.Sprintf("  resource_type=%q", f.ResourceType)
+		if f.ID != "" {
+			line += fmt.Sprintf(" id=%q", f.ID)
+		}
+		fmt.Fprintf(p.w, "%s reason=%q error=%q\n", line, f.Reason, f.Error)
+	}
+}
+
+// JSON renders each Event as a JSON object on one line
+type JSON struct {
+	enc *json.Encoder
+	mu  sync.Mutex
+}
+
+// NewJSON returns a JSON renderer that writes to w
+func NewJSON(w io.Writer) *JSON {
+	return &JSON{enc: json.NewEncoder(w)}
+}
+
+// Handle writes the e as JSON
+func (j *JSON) Handle(e Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	// The Event can always be encoded
+	_ = j.enc.Encode(e)
+}
@@ -0,0 +1,210 @@
+package event_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cycloidio/terracognita/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTTY(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var b bytes.Buffer
+		h := event.NewTTY(&b)
+
+		for _, e := range []event.Event{
+			{Type: event.TypeImportStarted, Message: "Tags: []"},
+			{Type: event.TypeResourceTypeStarted, ResourceType: "aws_instance"},
+			{Type: event.TypeResourceDiscovered, ResourceType: "aws_instance", ID: "i-1"},
+			{Type: event.TypeResourceDiscovered, ResourceType: "aws_instance", ID: "i-2"},
+			{Type: event.TypeResourceStarted, ResourceType: "aws_instance", ID: "i-1", Current: 1, Total: 2},
+			{Type: event.TypeResourceStarted, ResourceType: "aws_instance", ID: "i-2", Current: 2, Total: 2},
+			{Type: event.TypeResourceImported, ResourceType: "aws_instance", ID: "i-1", Address: "aws_instance.front"},
+			{Type: event.TypeResourceFailed, ResourceType: "aws_instance", ID: "i-2", Reason: "unknown", Error: "failed"},
+			{Type: event.TypeResourceTypeDone, ResourceType: "aws_instance", Total: 2},
+			{Type: event.TypeWriterSyncStarted, Writer: event.WriterHCL},
+			{Type: event.TypeWriterSyncDone, Writer: event.WriterHCL},
+			{Type: event.TypeImportFailures, Total: 2, Failures: []event.Failure{{ResourceType: "aws_instance", ID: "i-2", Reason: "unknown", Error: "failed"}}},
+			{Type: event.TypeImportDone, Total: 2},
+		} {
+			h.Handle(e)
+		}
+
+		assert.Equal(t, "Importing with filters: Tags: []"+
+			"\rImporting aws_instance [1/2]"+
+			"\rImporting aws_instance [2/2]"+
+			"\rImporting aws_instance [2/2] Done!\n"+
+			"\rWriting HCL ..."+
+			"\rWriting HCL Done!\n"+
+			"Failed to import 1 of 2 resources:\n"+
+			"  aws_instance: 1 (unknown)\n", b.String())
+	})
+	t.Run("SuccessWithManaged", func(t *testing.T) {
+		var b bytes.Buffer
+		h := event.NewTTY(&b)
+
+		for _, e := range []event.Event{
+			{Type: event.TypeImportStarted, Message: "Tags: []"},
+			{Type: event.TypeResourceTypeStarted, ResourceType: "aws_instance"},
+			{Type: event.TypeResourceSkipped, ResourceType: "aws_instance", ID: "i-1", Reason: event.ReasonManaged},
+			{Type: event.TypeResourceSkipped, ResourceType: "aws_instance", ID: "i-2", Reason: event.ReasonManaged},
+			{Type: event.TypeResourceTypeDone, ResourceType: "aws_instance"},
+			{Type: event.TypeResourceTypeStarted, ResourceType: "aws_s3_bucket"},
+			{Type: event.TypeResourceSkipped, ResourceType: "aws_s3_bucket", ID: "b", Reason: event.ReasonManaged},
+			{Type: event.TypeResourceSkipped, ResourceType: "aws_s3_bucket", ID: "c", Reason: "filtered_by_tag"},
+			{Type: event.TypeResourceTypeDone, ResourceType: "aws_s3_bucket"},
+			{Type: event.TypeImportDone},
+		} {
+			h.Handle(e)
+		}
+
+		assert.Equal(t, "Importing with filters: Tags: []\n"+
+			"Skipped 3 resources already managed:\n"+
+			"  aws_instance: 2\n"+
+			"  aws_s3_bucket: 1\n", b.String())
+	})
+	t.Run("SuccessWithFilteredByWhere", func(t *testing.T) {
+		var b bytes.Buffer
+		h := event.NewTTY(&b)
+
+		for _, e := range []event.Event{
+			{Type: event.TypeImportStarted, Message: "Tags: []"},
+			{Type: event.TypeResourceTypeStarted, ResourceType: "aws_instance"},
+			{Type: event.TypeResourceSkipped, ResourceType: "aws_instance", ID: "i-1", Reason: event.ReasonManaged},
+			{Type: event.TypeResourceSkipped, ResourceType: "aws_instance", ID: "i-2", Reason: event.ReasonFilteredByWhere},
+			{Type: event.TypeResourceSkipped, ResourceType: "aws_instance", ID: "i-3", Reason: event.ReasonFilteredByWhere},
+			{Type: event.TypeResourceTypeDone, ResourceType: "aws_instance"},
+			{Type: event.TypeImportDone},
+		} {
+			h.Handle(e)
+		}
+
+		assert.Equal(t, "Importing with filters: Tags: []\n"+
+			"Skipped 1 resources already managed:\n"+
+			"  aws_instance: 1\n"+
+			"Skipped 2 resources not matching the where expression:\n"+
+			"  aws_instance: 2\n", b.String())
+	})
+	t.Run("SuccessWithMultipleImports", func(t *testing.T) {
+		var b bytes.Buffer
+		h := event.NewTTY(&b)
+
+		// Each import has its own summary
+		for _, e := range []event.Event{
+			{Type: event.TypeImportStarted, Message: "Tags: []"},
+			{Type: event.TypeResourceSkipped, ResourceType: "aws_instance", ID: "i-1", Reason: event.ReasonManaged},
+			{Type: event.TypeImportFailures, Total: 2, Failures: []event.Failure{{ResourceType: "aws_instance", ID: "i-2", Reason: "unknown", Error: "failed"}}},
+			{Type: event.TypeImportDone, Total: 2},
+			{Type: event.TypeImportStarted, Message: "Tags: []"},
+			{Type: event.TypeImportFailures, Total: 3, Failures: []event.Failure{{ResourceType: "google_compute_instance", ID: "vm", Reason: "unknown", Error: "failed"}}},
+			{Type: event.TypeImportDone, Total: 3},
+		} {
+			h.Handle(e)
+		}
+
+		assert.Equal(t, "Importing with filters: Tags: []\n"+
+			"Skipped 1 resources already managed:\n"+
+			"  aws_instance: 1\n"+
+			"Failed to import 1 of 2 resources:\n"+
+			"  aws_instance: 1 (unknown)\n"+
+			"Importing with filters: Tags: []\n"+
+			"Failed to import 1 of 3 resources:\n"+
+			"  google_compute_instance: 1 (unknown)\n", b.String())
+	})
+	t.Run("SuccessWithDryRun", func(t *testing.T) {
+		var b bytes.Buffer
+		h := event.NewTTY(&b)
+
+		for _, e := range []event.Event{
+			{Type: event.TypeImportStarted, Message: "Tags: []", DryRun: true},
+			{Type: event.TypeResourceTypeStarted, ResourceType: "aws_instance"},
+			{Type: event.TypeResourceDiscovered, ResourceType: "aws_instance", ID: "i-1"},
+			{Type: event.TypeImportDone, Total: 1, DryRun: true},
+		} {
+			h.Handle(e)
+		}
+
+		assert.Equal(t, "Importing with filters: Tags: []\n"+
+			"aws_instance\ti-1\n"+
+			"Found 1 resources\n", b.String())
+	})
+}
+
+func TestPlain(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var b bytes.Buffer
+		h := event.NewPlain(&b)
+
+		h.Handle(event.Event{
+			Type:         event.TypeResourceFailed,
+			Time:         time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			ResourceType: "aws_instance",
+			ID:           "i-1",
+			Reason:       "unknown",
+			Error:        "failed",
+		})
+
+		assert.Equal(t, `2020-01-02T03:04:05Z resource_failed resource_type="aws_instance" id="i-1" reason="unknown" error="failed"
+`, b.String())
+	})
+	t.Run("SuccessWithFailures", func(t *testing.T) {
+		var b bytes.Buffer
+		h := event.NewPlain(&b)
+
+		h.Handle(event.Event{
+			Type:  event.TypeImportFailures,
+			Time:  time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			Total: 3,
+			Failures: []event.Failure{
+				{ResourceType: "aws_instance", ID: "i-1", Reason: "unknown", Error: "failed"},
+				{ResourceType: "aws_s3_bucket", Reason: "unknown", Error: "could not list"},
+			},
+		})
+
+		assert.Equal(t, `2020-01-02T03:04:05Z import_failures total=3 failures=2
+  resource_type="aws_instance" id="i-1" reason="unknown" error="failed"
+  resource_type="aws_s3_bucket" reason="unknown" error="could not list"
+`, b.String())
+	})
+}
+
+func TestJSON(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var b bytes.Buffer
+		h := event.NewJSON(&b)
+
+		h.Handle(event.Event{
+			Type:         event.TypeResourceStarted,
+			Time:         time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			ResourceType: "aws_instance",
+			ID:           "i-1",
+			Current:      1,
+			Total:        2,
+		})
+		h.Handle(event.Event{
+			Type:   event.TypeWriterSyncDone,
+			Time:   time.Date(2020, 1, 2, 3, 4, 6, 0, time.UTC),
+			Writer: event.WriterHCL,
+		})
+
+		assert.Equal(t, `{"type":"resource_started","time":"2020-01-02T03:04:05Z","resource_type":"aws_instance","id":"i-1","current":1,"total":2}
+{"type":"writer_sync_done","time":"2020-01-02T03:04:06Z","writer":"hcl"}
+`, b.String())
+	})
+	t.Run("SuccessWithFailures", func(t *testing.T) {
+		var b bytes.Buffer
+		h := event.NewJSON(&b)
+
+		h.Handle(event.Event{
+			Type:     event.TypeImportFailures,
+			Time:     time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			Total:    2,
+			Failures: []event.Failure{{ResourceType: "aws_instance", ID: "i-1", Reason: "unknown", Error: "failed"}},
+		})
+
+		assert.Equal(t, `{"type":"import_failures","time":"2020-01-02T03:04:05Z","total":2,"failures":[{"resource_type":"aws_instance","id":"i-1","reason":"unknown","error":"failed"}]}
+`, b.String())
+	})
+}
@@ -2,6 +2,7 @@ package filter
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/cycloidio/terracognita/errcode"
@@ -18,8 +19,27 @@ type Filter struct {
 	Exclude []string
 	Targets []string
 
+	// TagsExpr is an expression of tags that the
+	// resources have to match, along with the Tags
+	TagsExpr tag.Expr
+
+	// IDPattern and NamePattern are regular expressions
+	// that the ID and the name of the resources have to
+	// match (see MatchID and MatchName)
+	IDPattern   string
+	NamePattern string
+
+	// Where is an expression of the attributes that the
+	// resources have to match once read, with the same
+	// format as the tags (ex: instance_type = "t2.*")
+	Where tag.Expr
+
 	exclude map[string]struct{}
 	include map[string]struct{}
+
+	// includeNone is set when the patterns of the
+	// Include were expanded to none of the types
+	includeNone bool
 }
 
 // IsExcluded checks if the v is on the Exclude list
@@ -43,6 +63,10 @@ func (f *Filter) IsExcluded(v ...string) bool {
 
 // IsIncluded checks if the v is on the Include list
 func (f *Filter) IsIncluded(v ...string) bool {
+	if f.includeNone {
+		return false
+	}
+
 	if len(f.Include) == 0 {
 		return true
 	}
@@ -60,6 +84,39 @@ func (f *Filter) IsIncluded(v ...string) bool {
 	return true
 }
 
+// HasTags checks if the resources are filtered by tags
+func (f *Filter) HasTags() bool {
+	return len(f.Tags) != 0 || f.TagsExpr != nil
+}
+
+// MatchTags checks if the tags match all the Tags and the TagsExpr
+func (f *Filter) MatchTags(tags map[string]string) bool {
+	for _, t := range f.Tags {
+		if v, ok := tags[t.Name]; !ok || v != t.Value {
+			return false
+		}
+	}
+
+	return f.TagsExpr == nil || f.TagsExpr.Match(tags)
+}
+
+// MatchWhere checks if the attributes match the Where,
+// if it's not set all the attributes match
+func (f *Filter) MatchWhere(attributes map[string]string) bool {
+	return f.Where == nil || f.Where.Match(attributes)
+}
+
+// TagConditions returns the conditions of the tags that the resources
+// matching the Tags and TagsExpr meet, so the providers can use them
+// to filter the resources before reading them
+func (f *Filter) TagConditions() []tag.Condition {
+	cs := make([]tag.Condition, 0, len(f.Tags))
+	for _, t := range f.Tags {
+		cs = append(cs, tag.Condition{Name: t.Name, Values: []string{t.Value}})
+	}
+	return append(cs, tag.Conditions(f.TagsExpr)...)
+}
+
 // Validate validates that the data inside of the filters is right
 func (f *Filter) Validate() error {
 	// Validate that the Targets have the right format
@@ -72,6 +129,12 @@ func (f *Filter) Validate() error {
 		}
 	}
 
+	for _, p := range []string{f.IDPattern, f.NamePattern} {
+		if _, err := regexp.Compile(p); err != nil {
+			return errors.Wrapf(errcode.ErrFilterPatternInvalid, "%q: %s", p, err)
+		}
+	}
+
 	return nil
 }
 
@@ -105,10 +168,14 @@ func (f *Filter) TargetsTypesWithIDs() map[string][]string {
 func (f *Filter) String() string {
 	return fmt.Sprintf(`
 	Tags:    %s,
+	TagsExpr: %v,
 	Include: %s,
 	Exclude: %s,
 	Targets: %s,
-`, f.Tags, f.Include, f.Exclude, f.Targets)
+	IDPattern: %s,
+	NamePattern: %s,
+	Where: %v,
+`, f.Tags, f.TagsExpr, f.Include, f.Exclude, f.Targets, f.IDPattern, f.NamePattern, f.Where)
 }
 
 // calculateExcludeMap makes a map of the Exclude so
@@ -5,6 +5,7 @@ import (
 
 	"github.com/cycloidio/terracognita/errcode"
 	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/tag"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -48,6 +49,57 @@ func TestIsIncluded(t *testing.T) {
 	})
 }
 
+func TestMatchTags(t *testing.T) {
+	e, err := tag.Parse("env in (prod,staging) AND NOT owner:legacy")
+	require.NoError(t, err)
+
+	f := filter.Filter{Tags: []tag.Tag{{Name: "team", Value: "a"}}, TagsExpr: e}
+	t.Run("True", func(t *testing.T) {
+		assert.True(t, f.MatchTags(map[string]string{"team": "a", "env": "prod"}))
+	})
+	t.Run("FalseTags", func(t *testing.T) {
+		assert.False(t, f.MatchTags(map[string]string{"team": "b", "env": "prod"}))
+	})
+	t.Run("FalseTagsExpr", func(t *testing.T) {
+		assert.False(t, f.MatchTags(map[string]string{"team": "a", "env": "prod", "owner": "legacy"}))
+	})
+	t.Run("TrueWithoutTags", func(t *testing.T) {
+		f := filter.Filter{}
+		assert.False(t, f.HasTags())
+		assert.True(t, f.MatchTags(nil))
+	})
+}
+
+func TestMatchWhere(t *testing.T) {
+	e, err := tag.Parse(`instance_type = "t2.*" AND vpc_id != vpc-123`)
+	require.NoError(t, err)
+
+	f := filter.Filter{Where: e}
+	t.Run("True", func(t *testing.T) {
+		assert.True(t, f.MatchWhere(map[string]string{"instance_type": "t2.micro", "vpc_id": "vpc-456"}))
+	})
+	t.Run("False", func(t *testing.T) {
+		assert.False(t, f.MatchWhere(map[string]string{"instance_type": "t2.micro", "vpc_id": "vpc-123"}))
+	})
+	t.Run("TrueWithoutWhere", func(t *testing.T) {
+		f := filter.Filter{}
+		assert.True(t, f.MatchWhere(nil))
+	})
+}
+
+func TestTagConditions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		e, err := tag.Parse("has(cost-center) AND (env:prod OR env:staging)")
+		require.NoError(t, err)
+
+		f := filter.Filter{Tags: []tag.Tag{{Name: "team", Value: "a"}}, TagsExpr: e}
+		assert.Equal(t, []tag.Condition{
+			{Name: "team", Values: []string{"a"}},
+			{Name: "cost-center"},
+		}, f.TagConditions())
+	})
+}
+
 func TestTargetsTypesWithIDs(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		f := filter.Filter{Targets: []string{"aws_instance.2", "aws_instance.3", "aws_iam_user.2", "aws_instance.2"}}
@@ -74,4 +126,116 @@ func TestValidate(t *testing.T) {
 		err := f.Validate()
 		assert.Error(t, errors.Cause(err), errcode.ErrFilterTargetsInvalid)
 	})
+	t.Run("ErrorInvalidNamePattern", func(t *testing.T) {
+		f := filter.Filter{NamePattern: "^payments-("}
+		err := f.Validate()
+		assert.Equal(t, errcode.ErrFilterPatternInvalid, errors.Cause(err))
+	})
+}
+
+func TestMatchID(t *testing.T) {
+	t.Run("True", func(t *testing.T) {
+		f := filter.Filter{IDPattern: "^i-0a"}
+		assert.True(t, f.MatchID("i-0a1b"))
+	})
+	t.Run("TrueWithoutPattern", func(t *testing.T) {
+		f := filter.Filter{}
+		assert.True(t, f.MatchID("i-0a1b"))
+	})
+	t.Run("False", func(t *testing.T) {
+		f := filter.Filter{IDPattern: "^i-0a"}
+		assert.False(t, f.MatchID("i-1a0a"))
+	})
+}
+
+func TestMatchName(t *testing.T) {
+	t.Run("True", func(t *testing.T) {
+		f := filter.Filter{NamePattern: "payments-(api|db)$"}
+		assert.True(t, f.MatchName("prod-payments-db"))
+	})
+	t.Run("False", func(t *testing.T) {
+		f := filter.Filter{NamePattern: "payments-(api|db)$"}
+		assert.False(t, f.MatchName("payments-web"))
+	})
+}
+
+func TestNamePrefix(t *testing.T) {
+	tests := []struct {
+		Pattern string
+		Prefix  string
+	}{
+		{Pattern: "^payments-", Prefix: "payments-"},
+		{Pattern: "^payments-.*$", Prefix: "payments-"},
+		{Pattern: `^pay\.ments-(api|db)`, Prefix: "pay.ments-"},
+		{Pattern: "^pay(ments|roll)", Prefix: "pay"},
+		{Pattern: "payments-", Prefix: ""},
+		{Pattern: "^(?i)payments-", Prefix: ""},
+		{Pattern: "^payments|^billing", Prefix: ""},
+		{Pattern: "", Prefix: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Pattern, func(t *testing.T) {
+			f := filter.Filter{NamePattern: tt.Pattern}
+			assert.Equal(t, tt.Prefix, f.NamePrefix())
+		})
+	}
+}
+
+func TestIsPattern(t *testing.T) {
+	for p, ok := range map[string]bool{
+		"aws_instance":     false,
+		"aws_iam_*":        true,
+		"aws_iam_use?":     true,
+		"aws_[ei]*":        true,
+		"!aws_iam_user":    true,
+		"/^aws_(iam|s3)_/": true,
+		"/":                false,
+	} {
+		assert.Equal(t, ok, filter.IsPattern(p), p)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	types := []string{"aws_instance", "aws_iam_user", "aws_iam_user_policy", "aws_iam_role", "aws_s3_bucket"}
+
+	t.Run("SuccessWithGlob", func(t *testing.T) {
+		f := filter.Filter{Include: []string{"aws_iam_*", "!aws_iam_user_*"}, Exclude: []string{"aws_s3_*"}}
+		err := f.Expand(types)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"aws_iam_user", "aws_iam_role"}, f.Include)
+		assert.Equal(t, []string{"aws_s3_bucket"}, f.Exclude)
+		assert.True(t, f.IsIncluded("aws_iam_role"))
+		assert.False(t, f.IsIncluded("aws_instance"))
+	})
+	t.Run("SuccessWithRegexp", func(t *testing.T) {
+		f := filter.Filter{Include: []string{"/^aws_(instance|s3_bucket)$/"}}
+		err := f.Expand(types)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"aws_instance", "aws_s3_bucket"}, f.Include)
+	})
+	t.Run("SuccessWithNegationFirst", func(t *testing.T) {
+		f := filter.Filter{Include: []string{"!aws_iam_*"}}
+		err := f.Expand(types)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"aws_instance", "aws_s3_bucket"}, f.Include)
+	})
+	t.Run("SuccessWithTypes", func(t *testing.T) {
+		// The types are not validated
+		f := filter.Filter{Include: []string{"aws_potato", "aws_s3_*"}}
+		err := f.Expand(types)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"aws_potato", "aws_s3_bucket"}, f.Include)
+	})
+	t.Run("SuccessWithoutMatches", func(t *testing.T) {
+		f := filter.Filter{Include: []string{"aws_lambda_*"}}
+		err := f.Expand(types)
+		require.NoError(t, err)
+		assert.Empty(t, f.Include)
+		assert.False(t, f.IsIncluded("aws_instance"))
+	})
+	t.Run("ErrorWithInvalidPattern", func(t *testing.T) {
+		f := filter.Filter{Exclude: []string{"aws_[iam"}}
+		err := f.Expand(types)
+		assert.True(t, errors.Is(err, errcode.ErrFilterPatternInvalid))
+	})
 }
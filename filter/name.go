@@ -0,0 +1,75 @@
+package filter
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"sync"
+)
+
+// MatchID checks if the id matches the IDPattern,
+// if it's not set all the IDs match
+func (f *Filter) MatchID(id string) bool {
+	return matchRegexp(f.IDPattern, id)
+}
+
+// MatchName checks if the name matches the NamePattern,
+// if it's not set all the names match
+func (f *Filter) MatchName(name string) bool {
+	return matchRegexp(f.NamePattern, name)
+}
+
+// NamePrefix returns the literal prefix that all the names that match
+// the NamePattern have (ex: 'payments-' of '^payments-.*$') so the
+// providers can use it to filter the resources before reading them.
+// It's empty if the NamePattern is not anchored to the start or it
+// does not start with a literal
+func (f *Filter) NamePrefix() string {
+	if f.NamePattern == "" {
+		return ""
+	}
+
+	re, err := syntax.Parse(f.NamePattern, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	re = re.Simplify()
+
+	if re.Op != syntax.OpConcat || len(re.Sub) < 2 || re.Sub[0].Op != syntax.OpBeginText {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, sre := range re.Sub[1:] {
+		if sre.Op != syntax.OpLiteral || sre.Flags&syntax.FoldCase != 0 {
+			break
+		}
+		b.WriteString(string(sre.Rune))
+	}
+
+	return b.String()
+}
+
+// regexps are the compiled regular
+// expressions used by matchRegexp
+var regexps sync.Map
+
+// matchRegexp checks if the s matches the regular expression re,
+// an empty or invalid re matches all of them as it's validated
+// before by Validate
+func matchRegexp(re, s string) bool {
+	if re == "" {
+		return true
+	}
+
+	v, ok := regexps.Load(re)
+	if !ok {
+		cre, err := regexp.Compile(re)
+		if err != nil {
+			return true
+		}
+		v, _ = regexps.LoadOrStore(re, cre)
+	}
+
+	return v.(*regexp.Regexp).MatchString(s)
+}
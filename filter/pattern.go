@@ -0,0 +1,156 @@
+package filter
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/pkg/errors"
+)
+
+// negation is the prefix of the patterns that remove
+// the types matched instead of adding them
+const negation = "!"
+
+// IsPattern checks if the p is a pattern of types and not a type: a glob
+// (ex: aws_iam_*), a regular expression between '/' (ex: /^aws_(iam|s3)_/)
+// or a negation of any of them or of a type (ex: !aws_iam_user)
+func IsPattern(p string) bool {
+	if strings.HasPrefix(p, negation) {
+		return true
+	}
+	return isRegexp(p) || strings.ContainsAny(p, "*?[")
+}
+
+// Match checks if the type t matches the pattern p,
+// the negation of the p is ignored
+func Match(p, t string) (bool, error) {
+	p = strings.TrimPrefix(p, negation)
+	if isRegexp(p) {
+		re, err := regexp.Compile(p[1 : len(p)-1])
+		if err != nil {
+			return false, errors.Wrapf(errcode.ErrFilterPatternInvalid, "%q: %s", p, err)
+		}
+		return re.MatchString(t), nil
+	}
+
+	ok, err := path.Match(p, t)
+	if err != nil {
+		return false, errors.Wrapf(errcode.ErrFilterPatternInvalid, "%q: %s", p, err)
+	}
+	return ok, nil
+}
+
+// HasPatterns checks if the Include or the Exclude have any pattern
+func (f *Filter) HasPatterns() bool {
+	for _, ps := range [][]string{f.Include, f.Exclude} {
+		for _, p := range ps {
+			if IsPattern(p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Expand replaces the patterns of the Include and Exclude with the types that
+// they match, the types that are not patterns are left as they are so they can
+// be validated. The patterns are applied on order, the negated ones remove the
+// types matched until them, and if the first one is negated it starts from all
+// the types. If the Include had patterns and none of the types matched
+// them, nothing is included
+func (f *Filter) Expand(types []string) error {
+	include, err := expand(f.Include, types)
+	if err != nil {
+		return err
+	}
+	f.includeNone = len(f.Include) != 0 && len(include) == 0
+
+	exclude, err := expand(f.Exclude, types)
+	if err != nil {
+		return err
+	}
+
+	f.Include = include
+	f.Exclude = exclude
+	f.include = nil
+	f.exclude = nil
+
+	return nil
+}
+
+// expand returns the types matched by the ps
+func expand(ps, types []string) ([]string, error) {
+	var res []string
+	if len(ps) != 0 && strings.HasPrefix(ps[0], negation) {
+		res = append(res, types...)
+	}
+
+	for _, p := range ps {
+		np := strings.TrimPrefix(p, negation)
+
+		var matched []string
+		if !IsPattern(np) {
+			matched = []string{np}
+		} else {
+			for _, t := range types {
+				ok, err := Match(np, t)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					matched = append(matched, t)
+				}
+			}
+		}
+
+		if np != p {
+			res = remove(res, matched)
+		} else {
+			res = appendUnique(res, matched...)
+		}
+	}
+
+	return res, nil
+}
+
+// isRegexp checks if the p is a regular expression between '/'
+func isRegexp(p string) bool {
+	return len(p) > 1 && strings.HasPrefix(p, "/") && strings.HasSuffix(p, "/")
+}
+
+// appendUnique appends the vs to the s that are not already on it
+func appendUnique(s []string, vs ...string) []string {
+	for _, v := range vs {
+		var found bool
+		for _, e := range s {
+			if e == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			s = append(s, v)
+		}
+	}
+	return s
+}
+
+// remove returns the s without the vs
+func remove(s, vs []string) []string {
+	res := make([]string, 0, len(s))
+	for _, e := range s {
+		var found bool
+		for _, v := range vs {
+			if e == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			res = append(res, e)
+		}
+	}
+	return res
+}
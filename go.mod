@@ -13,6 +13,7 @@ require (
 	github.com/gertd/go-pluralize v0.1.7
 	github.com/go-kit/kit v0.9.0
 	github.com/golang/mock v1.6.0
+	github.com/hashicorp/aws-sdk-go-base/v2 v2.0.0-beta.14
 	github.com/hashicorp/go-azure-helpers v0.40.0
 	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
 	github.com/hashicorp/hcl/v2 v2.13.0
@@ -100,7 +101,6 @@ require (
 	github.com/googleapis/gax-go/v2 v2.1.1 // indirect
 	github.com/grpc-ecosystem/go-grpc-middleware v1.3.0 // indirect
 	github.com/hashicorp/aws-cloudformation-resource-schema-sdk-go v0.16.0 // indirect
-	github.com/hashicorp/aws-sdk-go-base/v2 v2.0.0-beta.14 // indirect
 	github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2 v2.0.0-beta.15 // indirect
 	github.com/hashicorp/awspolicyequivalence v1.5.0 // indirect
 	github.com/hashicorp/errwrap v1.1.0 // indirect
@@ -29,7 +29,7 @@ func cacheComputeInstances(ctx context.Context, g *google, rt string, filters *f
 		}
 
 		err = g.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -65,7 +65,7 @@ func cacheDNSManagedZones(ctx context.Context, g *google, rt string, filters *fi
 		}
 
 		err = g.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -99,7 +99,7 @@ func cacheStorageBuckets(ctx context.Context, g *google, rt string, filters *fil
 			return nil, errors.Wrap(err, "unable to get storage buckets")
 		}
 		err = g.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
@@ -132,7 +132,7 @@ func cacheSQLDatabaseInstances(ctx context.Context, g *google, rt string, filter
 			return nil, errors.Wrap(err, "unable to get sql database instances")
 		}
 		err = g.cache.Set(rt, rs)
-		if err != nil {
+		if err != nil && errors.Cause(err) != errcode.ErrCacheKeyAlreadyExisting {
 			return nil, err
 		}
 	}
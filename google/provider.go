@@ -3,12 +3,15 @@ package google
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/cycloidio/terracognita/cache"
 	"github.com/cycloidio/terracognita/errcode"
 	"github.com/cycloidio/terracognita/filter"
 	"github.com/cycloidio/terracognita/log"
 	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/util"
 	"github.com/hashicorp/go-cty/cty"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -21,6 +24,13 @@ import (
 // version of the Terraform provider, this is automatically changed with the 'make update-terraform-provider'
 const version = "4.9.0"
 
+// rateLimitReasons are the reasons of the errors that
+// are throttling even if the code is 403 and not 429
+var rateLimitReasons = map[string]struct{}{
+	"rateLimitExceeded":     struct{}{},
+	"userRateLimitExceeded": struct{}{},
+}
+
 // skippableCodes is a list of codes
 // which won't make Terracognita failed
 // but they will be printed on the output
@@ -30,20 +40,108 @@ var skippableCodes = map[string]struct{}{
 	"accessNotConfigured": struct{}{},
 }
 
+// regionalTypes are the resource types that are read from the
+// region of the provider, the rest are of the whole project
+// so they are the same on all the regions
+var regionalTypes = map[ResourceType]struct{}{
+	ComputeInstance:             struct{}{},
+	ComputeInstanceGroup:        struct{}{},
+	ComputeInstanceIAMPolicy:    struct{}{},
+	ComputeForwardingRule:       struct{}{},
+	ComputeDisk:                 struct{}{},
+	ComputeAddress:              struct{}{},
+	ComputeAttachedDisk:         struct{}{},
+	ComputeAutoscaler:           struct{}{},
+	ComputeGlobalAddress:        struct{}{},
+	ComputeInstanceGroupManager: struct{}{},
+	ComputeNetworkEndpointGroup: struct{}{},
+	ComputeServiceAttachment:    struct{}{},
+	ComputeSubnetwork:           struct{}{},
+	ComputeTargetInstance:       struct{}{},
+	ComputeTargetPool:           struct{}{},
+	FilestoreInstance:           struct{}{},
+	ContainerCluster:            struct{}{},
+	ContainerNodePool:           struct{}{},
+	RedisInstance:               struct{}{},
+}
+
 type google struct {
 	tfGoogleClient interface{}
 	tfProvider     *schema.Provider
+	grpcClient     *provider.GRPCClient
 	gcpr           *GCPReader
 
+	// alias is the alias of the provider when importing
+	// from more than one project or region
+	alias string
+
+	// global defines if the resources that are
+	// not regional are read or not
+	global bool
+
+	configuration map[string]interface{}
+
 	cache cache.Cache
 }
 
+// Options are the options to initialize
+// a Google Provider with NewProviderWithOptions
+type Options struct {
+	MaxResults uint64
+	Project    string
+	Region     string
+
+	// Credentials is the path to the JSON credentials
+	Credentials string
+
+	// Alias of the provider so it can be used along with
+	// other ones, like the ones of other projects or regions.
+	// The aliased providers have the Project and Region
+	// on the 'provider' block
+	Alias string
+
+	// SkipGlobal skips the resources that are not regional, as
+	// when importing from more than one region of the same project
+	// they are only read from one
+	SkipGlobal bool
+
+	// RateLimiter limits the requests to each GCP service, it can
+	// be shared by the providers of the same project. If it's nil
+	// one with the RateLimitsDefault is used
+	RateLimiter *util.RateLimiter
+}
+
+// RateLimitsDefault are the requests per second to the GCP
+// services, by default the quotas are per project and minute
+var RateLimitsDefault = map[string]float64{
+	"compute":  20,
+	"iam":      10,
+	"sqladmin": 3,
+	"dns":      10,
+}
+
 // NewProvider returns a Gooogle Provider
 func NewProvider(ctx context.Context, maxResults uint64, project, region, credentials string) (provider.Provider, error) {
-	cfg := tfgoogle.Config{
-		Credentials: credentials,
+	return NewProviderWithOptions(ctx, Options{
+		MaxResults:  maxResults,
 		Project:     project,
 		Region:      region,
+		Credentials: credentials,
+	})
+}
+
+// Alias returns the alias of the provider of the
+// project or region n (ex: my-project is my_project)
+func Alias(n string) string {
+	return strings.ReplaceAll(n, "-", "_")
+}
+
+// NewProviderWithOptions returns a Google Provider initialized with the opts
+func NewProviderWithOptions(ctx context.Context, opts Options) (provider.Provider, error) {
+	cfg := tfgoogle.Config{
+		Credentials: opts.Credentials,
+		Project:     opts.Project,
+		Region:      opts.Region,
 	}
 
 	tfgoogle.ConfigureBasePaths(&cfg)
@@ -56,15 +154,32 @@ func NewProvider(ctx context.Context, maxResults uint64, project, region, creden
 	tfp.SetMeta(&cfg)
 
 	log.Get().Log("func", "google.NewProvider", "msg", "loading GCP client")
-	reader, err := NewGcpReader(ctx, maxResults, project, region, credentials)
+	rl := opts.RateLimiter
+	if rl == nil {
+		rl = util.NewRateLimiter(RateLimitsDefault, nil)
+	}
+
+	reader, err := NewGcpReader(ctx, opts.MaxResults, opts.Project, opts.Region, opts.Credentials, rl)
 	if err != nil {
 		return nil, fmt.Errorf("unable to initialize GCPReader: %v", err)
 	}
 
+	// The default provider has the project and region from the
+	// ENV, the aliased ones need them to differ between them
+	configuration := make(map[string]interface{})
+	if opts.Alias != "" {
+		configuration["project"] = opts.Project
+		configuration["region"] = opts.Region
+	}
+
 	return &google{
 		tfGoogleClient: &cfg,
 		tfProvider:     tfp,
+		grpcClient:     provider.NewGRPCClient(tfp),
 		gcpr:           reader,
+		alias:          opts.Alias,
+		global:         !opts.SkipGlobal,
+		configuration:  configuration,
 		cache:          cache.New(),
 	}, nil
 }
@@ -80,10 +195,21 @@ func (g *google) String() string                        { return "google" }
 func (g *google) TagKey() string                        { return "labels" }
 func (g *google) Source() string                        { return "hashicorp/google" }
 func (g *google) Version() string                       { return version }
-func (g *google) Configuration() map[string]interface{} { return make(map[string]interface{}) }
+func (g *google) Alias() string                         { return g.alias }
+func (g *google) Configuration() map[string]interface{} { return g.configuration }
 
 func (g *google) ResourceTypes() []string {
-	return ResourceTypeStrings()
+	if g.global {
+		return ResourceTypeStrings()
+	}
+
+	var types []string
+	for _, t := range ResourceTypeValues() {
+		if _, ok := regionalTypes[t]; ok {
+			types = append(types, t.String())
+		}
+	}
+	return types
 }
 
 func (g *google) Resources(ctx context.Context, t string, f *filter.Filter) ([]provider.Resource, error) {
@@ -97,6 +223,12 @@ func (g *google) Resources(ctx context.Context, t string, f *filter.Filter) ([]p
 		return nil, errors.Errorf("the resource %q it's not implemented", t)
 	}
 
+	// They are read from the provider of
+	// the region that has the global ones
+	if _, ok := regionalTypes[rt]; !g.global && !ok {
+		return nil, nil
+	}
+
 	resources, err := rfn(ctx, g, t, f)
 	if err != nil {
 		// we filter the error from GCP and return a custom error
@@ -129,5 +261,30 @@ func (g *google) TFClient() interface{} {
 func (g *google) TFProvider() *schema.Provider {
 	return g.tfProvider
 }
+
+func (g *google) GRPCClient() *provider.GRPCClient {
+	return g.grpcClient
+}
 func (g *google) FixResource(t string, v cty.Value) (cty.Value, error) { return v, nil }
 func (g *google) FilterByTags(tags interface{}) error                  { return nil }
+
+// Retryable retries the GCP errors that are throttling (also the
+// 403 with rate limit reasons) or temporary of the server
+func (g *google) Retryable(err error) (bool, time.Duration) {
+	var reqErr *googleapi.Error
+	if !errors.As(err, &reqErr) {
+		return false, 0
+	}
+
+	if util.IsRetryableHTTPStatus(reqErr.Code) {
+		return true, util.RetryAfter(reqErr.Header)
+	}
+
+	for _, gerr := range reqErr.Errors {
+		if _, ok := rateLimitReasons[gerr.Reason]; ok {
+			return true, util.RetryAfter(reqErr.Header)
+		}
+	}
+
+	return false, 0
+}
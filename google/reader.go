@@ -2,11 +2,17 @@ package google
 
 import (
 	"context"
+	"net/http"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 
+	"github.com/cycloidio/terracognita/util"
+
 	"google.golang.org/api/cloudbilling/v1"
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v3"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/container/v1"
 	"google.golang.org/api/dns/v1"
@@ -18,6 +24,7 @@ import (
 	"google.golang.org/api/redis/v1"
 	sqladmin "google.golang.org/api/sqladmin/v1beta4"
 	"google.golang.org/api/storage/v1"
+	htransport "google.golang.org/api/transport/http"
 )
 
 //go:generate go run ./cmd
@@ -38,56 +45,61 @@ type GCPReader struct {
 	project      string
 	region       string
 	zones        []string
+	zonesMu      sync.Mutex
 	maxResults   uint64
 }
 
 // NewGcpReader returns a GCPReader with a catalog of services
-// ready to be used
-func NewGcpReader(ctx context.Context, maxResults uint64, project, region, credentials string) (*GCPReader, error) {
+// ready to be used. If the rl is not nil each request waits for it
+func NewGcpReader(ctx context.Context, maxResults uint64, project, region, credentials string, rl *util.RateLimiter) (*GCPReader, error) {
 	if maxResults > 500 {
 		return nil, errors.New("max-results must be between 0 and 500, inclusive")
 	}
-	comp, err := compute.NewService(ctx, option.WithCredentialsFile(credentials))
+	opts, err := clientOptions(ctx, credentials, rl)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the HTTP client")
+	}
+	comp, err := compute.NewService(ctx, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create compute service")
 	}
-	storage, err := storage.NewService(ctx, option.WithCredentialsFile(credentials))
+	storage, err := storage.NewService(ctx, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create storage service")
 	}
-	sql, err := sqladmin.NewService(ctx, option.WithCredentialsFile(credentials))
+	sql, err := sqladmin.NewService(ctx, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create sqladmin service")
 	}
-	d, err := dns.NewService(ctx, option.WithCredentialsFile(credentials))
+	d, err := dns.NewService(ctx, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create sqladmin service")
 	}
-	i, err := iam.NewService(ctx, option.WithCredentialsFile(credentials))
+	i, err := iam.NewService(ctx, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create iam service")
 	}
-	bill, err := cloudbilling.NewService(ctx, option.WithCredentialsFile(credentials))
+	bill, err := cloudbilling.NewService(ctx, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create cloud billing service")
 	}
-	file, err := file.NewService(ctx, option.WithCredentialsFile(credentials))
+	file, err := file.NewService(ctx, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create filestore service")
 	}
-	container, err := container.NewService(ctx, option.WithCredentialsFile(credentials))
+	container, err := container.NewService(ctx, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create container service")
 	}
-	redis, err := redis.NewService(ctx, option.WithCredentialsFile(credentials))
+	redis, err := redis.NewService(ctx, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create redis service")
 	}
-	logging, err := logging.NewService(ctx, option.WithCredentialsFile(credentials))
+	logging, err := logging.NewService(ctx, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create logging service")
 	}
-	monitoring, err := monitoring.NewService(ctx, option.WithCredentialsFile(credentials))
+	monitoring, err := monitoring.NewService(ctx, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create logging service")
 	}
@@ -110,7 +122,44 @@ func NewGcpReader(ctx context.Context, maxResults uint64, project, region, crede
 	}, nil
 }
 
+// clientOptions returns the options of the clients of the services with the
+// credentials and, if the rl is not nil, an HTTP client that waits for it
+func clientOptions(ctx context.Context, credentials string, rl *util.RateLimiter) ([]option.ClientOption, error) {
+	if rl == nil {
+		return []option.ClientOption{option.WithCredentialsFile(credentials)}, nil
+	}
+
+	t, err := htransport.NewTransport(ctx,
+		&rateLimitTransport{base: http.DefaultTransport, rl: rl},
+		option.WithCredentialsFile(credentials),
+		option.WithScopes(compute.CloudPlatformScope),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return []option.ClientOption{option.WithHTTPClient(&http.Client{Transport: t})}, nil
+}
+
+// rateLimitTransport waits for the rl before each request, the service
+// is the one of the host (ex: compute of compute.googleapis.com)
+type rateLimitTransport struct {
+	base http.RoundTripper
+	rl   *util.RateLimiter
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	service := strings.TrimSuffix(req.URL.Hostname(), ".googleapis.com")
+	if err := t.rl.Wait(req.Context(), service); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
 func (r *GCPReader) getZones() ([]string, error) {
+	r.zonesMu.Lock()
+	defer r.zonesMu.Unlock()
+
 	if len(r.zones) > 0 {
 		return r.zones, nil
 	}
@@ -130,3 +179,48 @@ func (r *GCPReader) getZones() ([]string, error) {
 	r.zones = zones
 	return zones, nil
 }
+
+// Projects returns the IDs of the active projects inside of the parent,
+// which is a folder (folders/<id>) or an organization (organizations/<id>),
+// and of all its subfolders
+func Projects(ctx context.Context, credentials, parent string) ([]string, error) {
+	crm, err := cloudresourcemanager.NewService(ctx, option.WithCredentialsFile(credentials))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create cloud resource manager service")
+	}
+
+	var projects []string
+	parents := []string{parent}
+	for len(parents) != 0 {
+		p := parents[0]
+		parents = parents[1:]
+
+		err = crm.Projects.List().Parent(p).Pages(ctx, func(list *cloudresourcemanager.ListProjectsResponse) error {
+			for _, pr := range list.Projects {
+				if pr.State == "ACTIVE" {
+					projects = append(projects, pr.ProjectId)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to list the projects of %s", p)
+		}
+
+		err = crm.Folders.List().Parent(p).Pages(ctx, func(list *cloudresourcemanager.ListFoldersResponse) error {
+			for _, f := range list.Folders {
+				if f.State == "ACTIVE" {
+					parents = append(parents, f.Name)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to list the folders of %s", p)
+		}
+	}
+
+	sort.Strings(projects)
+
+	return projects, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/cycloidio/terracognita/filter"
 	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/tag"
 	"github.com/pkg/errors"
 )
 
@@ -154,11 +155,16 @@ var (
 	}
 )
 
+// initializeFilter returns the GCP filter of the tag conditions of the filters that have
+// one value without globs, the resources are then checked with all the tags filters once read
 func initializeFilter(filters *filter.Filter) string {
 	var b bytes.Buffer
-	for _, t := range filters.Tags {
+	for _, c := range filters.TagConditions() {
+		if len(c.Values) != 1 || tag.IsGlob(c.Values[0]) {
+			continue
+		}
 		// if multiple tags, we suppose it's a "AND" operation
-		b.WriteString(fmt.Sprintf("(labels.%s=%s) ", t.Name, t.Value))
+		b.WriteString(fmt.Sprintf("(labels.%s=%s) ", c.Name, c.Values[0]))
 	}
 	return b.String()
 }
@@ -0,0 +1,4 @@
+// Package graph has the Graph of the imported resources
+// and the references between them so it can be
+// exported as DOT, Mermaid or JSON
+package graph
@@ -0,0 +1,159 @@
+package graph
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/cycloidio/terracognita/interpolator"
+)
+
+// Node is an imported resource
+type Node struct {
+	// Address is the HCL address of the resource (ex: aws_instance.front)
+	Address  string `json:"address"`
+	Type     string `json:"type"`
+	ID       string `json:"id"`
+	Category string `json:"category,omitempty"`
+}
+
+// Edge is a reference from the resource on the From
+// address to the one on the To with the Attribute
+// of the From that has the reference
+type Edge struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Attribute string `json:"attribute"`
+}
+
+// Graph has the Nodes of the imported resources
+// and the Edges between them.
+// It's concurrently safe
+type Graph struct {
+	nodes map[string]Node
+	edges []Edge
+
+	// attributes are the attributes of each
+	// Node used to calculate the Edges
+	attributes map[string]map[string]string
+
+	mu sync.Mutex
+}
+
+var (
+	// regexReference matches an interpolation like ${aws_instance.front.id}
+	regexReference = regexp.MustCompile(`^\$\{([^.]+)\.([^.]+)\.`)
+)
+
+// New returns a new empty Graph
+func New() *Graph {
+	return &Graph{
+		nodes:      make(map[string]Node),
+		attributes: make(map[string]map[string]string),
+	}
+}
+
+// AddNode adds the n with the attributes attrs which are
+// used to calculate the Edges when Interpolate is called.
+// If a Node with the same Address exists it's replaced
+func (g *Graph) AddNode(n Node, attrs map[string]string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.nodes[n.Address] = n
+	g.attributes[n.Address] = attrs
+}
+
+// Interpolate calculates the Edges between the Nodes using the i,
+// which has to have all the resources already added.
+// Each attribute of a Node that references another Node
+// is an Edge, the references to the same type are ignored
+// the same way as they are on the TFState dependencies
+func (g *Graph) Interpolate(i *interpolator.Interpolator) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.edges = make([]Edge, 0)
+	for address, attrs := range g.attributes {
+		n := g.nodes[address]
+		for ak, av := range attrs {
+			ref, ok := i.Interpolate(ak, av)
+			if !ok {
+				continue
+			}
+
+			match := regexReference.FindStringSubmatch(ref)
+			if match == nil {
+				continue
+			}
+
+			to := fmt.Sprintf("%s.%s", match[1], match[2])
+			if match[1] == n.Type || to == address {
+				continue
+			}
+			if _, ok := g.nodes[to]; !ok {
+				continue
+			}
+
+			g.edges = append(g.edges, Edge{From: address, To: to, Attribute: ak})
+		}
+	}
+
+	sort.Slice(g.edges, func(i, j int) bool {
+		ei, ej := g.edges[i], g.edges[j]
+		if ei.From != ej.From {
+			return ei.From < ej.From
+		}
+		if ei.To != ej.To {
+			return ei.To < ej.To
+		}
+		return ei.Attribute < ej.Attribute
+	})
+}
+
+// Nodes returns all the Nodes sorted by Address
+func (g *Graph) Nodes() []Node {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	nodes := make([]Node, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Address < nodes[j].Address
+	})
+
+	return nodes
+}
+
+// Edges returns all the Edges sorted by From, To and Attribute
+func (g *Graph) Edges() []Edge {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	edges := make([]Edge, len(g.edges))
+	copy(edges, g.edges)
+
+	return edges
+}
+
+// isolated returns the Addresses of the Nodes
+// that do not have any Edge, sorted
+func isolated(nodes []Node, edges []Edge) []string {
+	linked := make(map[string]struct{})
+	for _, e := range edges {
+		linked[e.From] = struct{}{}
+		linked[e.To] = struct{}{}
+	}
+
+	res := make([]string, 0)
+	for _, n := range nodes {
+		if _, ok := linked[n.Address]; !ok {
+			res = append(res, n.Address)
+		}
+	}
+
+	return res
+}
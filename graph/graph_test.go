@@ -0,0 +1,125 @@
+package graph_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/graph"
+	"github.com/cycloidio/terracognita/interpolator"
+)
+
+func newGraph() *graph.Graph {
+	g := graph.New()
+	i := interpolator.New("aws")
+
+	g.AddNode(graph.Node{Address: "aws_vpc.main", Type: "aws_vpc", ID: "vpc-1", Category: "vpc"}, map[string]string{"id": "vpc-1"})
+	i.AddResourceAttributes("aws_vpc.main", map[string]string{"id": "vpc-1"})
+
+	g.AddNode(graph.Node{Address: "aws_subnet.front", Type: "aws_subnet", ID: "subnet-1", Category: "vpc"}, map[string]string{"id": "subnet-1", "vpc_id": "vpc-1"})
+	i.AddResourceAttributes("aws_subnet.front", map[string]string{"id": "subnet-1"})
+
+	g.AddNode(graph.Node{Address: "aws_iam_user.admin", Type: "aws_iam_user", ID: "admin", Category: "iam"}, map[string]string{"id": "admin"})
+	i.AddResourceAttributes("aws_iam_user.admin", map[string]string{"id": "admin"})
+
+	g.Interpolate(i)
+
+	return g
+}
+
+func TestInterpolate(t *testing.T) {
+	g := newGraph()
+
+	assert.Equal(t, []graph.Edge{
+		{From: "aws_subnet.front", To: "aws_vpc.main", Attribute: "vpc_id"},
+	}, g.Edges())
+	assert.Len(t, g.Nodes(), 3)
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Format   graph.Format
+		Expected string
+	}{
+		{
+			Name:   "DOT",
+			Format: graph.FormatDOT,
+			Expected: `digraph {
+	"aws_iam_user.admin" [label="aws_iam_user.admin", type="aws_iam_user", id="admin", category="iam"];
+	"aws_subnet.front" [label="aws_subnet.front", type="aws_subnet", id="subnet-1", category="vpc"];
+	"aws_vpc.main" [label="aws_vpc.main", type="aws_vpc", id="vpc-1", category="vpc"];
+	"aws_subnet.front" -> "aws_vpc.main" [label="vpc_id"];
+}
+`,
+		},
+		{
+			Name:   "Mermaid",
+			Format: graph.FormatMermaid,
+			Expected: `graph LR
+	n0["aws_iam_user.admin"]
+	n1["aws_subnet.front"]
+	n2["aws_vpc.main"]
+	n1 -->|vpc_id| n2
+`,
+		},
+		{
+			Name:   "JSON",
+			Format: graph.FormatJSON,
+			Expected: `{
+  "nodes": [
+    {
+      "address": "aws_iam_user.admin",
+      "type": "aws_iam_user",
+      "id": "admin",
+      "category": "iam"
+    },
+    {
+      "address": "aws_subnet.front",
+      "type": "aws_subnet",
+      "id": "subnet-1",
+      "category": "vpc"
+    },
+    {
+      "address": "aws_vpc.main",
+      "type": "aws_vpc",
+      "id": "vpc-1",
+      "category": "vpc"
+    }
+  ],
+  "edges": [
+    {
+      "from": "aws_subnet.front",
+      "to": "aws_vpc.main",
+      "attribute": "vpc_id"
+    }
+  ],
+  "isolated": [
+    "aws_iam_user.admin"
+  ]
+}
+`,
+		},
+	}
+
+	g := newGraph()
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := g.Write(&b, tt.Format)
+			require.NoError(t, err)
+			assert.Equal(t, tt.Expected, b.String())
+		})
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	f, err := graph.FormatFromPath("out/graph.mmd")
+	require.NoError(t, err)
+	assert.Equal(t, graph.FormatMermaid, f)
+
+	_, err = graph.FormatFromPath("out/graph.png")
+	assert.Error(t, err)
+}
@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// Format is the format in which the Graph is written
+type Format string
+
+// List of all the Formats supported
+const (
+	FormatDOT     Format = "dot"
+	FormatMermaid Format = "mmd"
+	FormatJSON    Format = "json"
+)
+
+// FormatFromPath returns the Format of the file on path
+// p from its extension (.dot, .mmd or .json)
+func FormatFromPath(p string) (Format, error) {
+	switch ext := filepath.Ext(p); ext {
+	case ".dot", ".gv":
+		return FormatDOT, nil
+	case ".mmd":
+		return FormatMermaid, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return "", errors.Errorf("invalid graph extension %q, the supported ones are .dot, .mmd and .json", ext)
+	}
+}
+
+// Write writes the Graph to w on the format f
+func (g *Graph) Write(w io.Writer, f Format) error {
+	nodes := g.Nodes()
+	edges := g.Edges()
+
+	switch f {
+	case FormatDOT:
+		return writeDOT(w, nodes, edges)
+	case FormatMermaid:
+		return writeMermaid(w, nodes, edges)
+	case FormatJSON:
+		return writeJSON(w, nodes, edges)
+	default:
+		return errors.Errorf("invalid graph format %q", f)
+	}
+}
+
+func writeDOT(w io.Writer, nodes []Node, edges []Edge) error {
+	fmt.Fprintln(w, "digraph {")
+	for _, n := range nodes {
+		fmt.Fprintf(w, "\t%q [label=%q, type=%q, id=%q, category=%q];\n", n.Address, n.Address, n.Type, n.ID, n.Category)
+	}
+	for _, e := range edges {
+		fmt.Fprintf(w, "\t%q -> %q [label=%q];\n", e.From, e.To, e.Attribute)
+	}
+	_, err := fmt.Fprintln(w, "}")
+
+	return errors.WithStack(err)
+}
+
+func writeMermaid(w io.Writer, nodes []Node, edges []Edge) error {
+	// The addresses are not valid Mermaid IDs
+	// so each Node has an ID by position
+	ids := make(map[string]string, len(nodes))
+
+	fmt.Fprintln(w, "graph LR")
+	for i, n := range nodes {
+		ids[n.Address] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(w, "\t%s[\"%s\"]\n", ids[n.Address], n.Address)
+	}
+	for _, e := range edges {
+		fmt.Fprintf(w, "\t%s -->|%s| %s\n", ids[e.From], e.Attribute, ids[e.To])
+	}
+
+	return nil
+}
+
+func writeJSON(w io.Writer, nodes []Node, edges []Edge) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	err := enc.Encode(struct {
+		Nodes    []Node   `json:"nodes"`
+		Edges    []Edge   `json:"edges"`
+		Isolated []string `json:"isolated"`
+	}{
+		Nodes:    nodes,
+		Edges:    edges,
+		Isolated: isolated(nodes, edges),
+	})
+
+	return errors.WithStack(err)
+}
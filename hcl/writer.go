@@ -19,6 +19,7 @@ import (
 	"github.com/cycloidio/terracognita/util"
 	"github.com/cycloidio/terracognita/writer"
 	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/pkg/errors"
 	"github.com/zclconf/go-cty/cty"
 	cjson "github.com/zclconf/go-cty/cty/json"
@@ -28,6 +29,10 @@ const (
 	defaultCategory      = "hcl"
 	variablesCategoryKey = "variables"
 	isMap                = true
+
+	// moduleProvidersCategoryKey is the category of the module
+	// on which the aliased providers it expects are declared
+	moduleProvidersCategoryKey = "providers"
 )
 
 // Writer is a Writer implementation that writes to
@@ -40,7 +45,10 @@ type Writer struct {
 	categories []string
 	writer     io.Writer
 	opts       *writer.Options
-	provider   provider.Provider
+
+	// tfKey is the category on which the
+	// 'terraform {}' block is written
+	tfKey string
 }
 
 // NewWriter rerturns an Writer initialization
@@ -48,25 +56,14 @@ func NewWriter(w io.Writer, pv provider.Provider, opts *writer.Options) *Writer
 	cfg := make(map[string]map[string]interface{})
 
 	wr := &Writer{
-		Config:   cfg,
-		writer:   w,
-		opts:     opts,
-		provider: pv,
+		Config: cfg,
+		writer: w,
+		opts:   opts,
 	}
 
 	tfcfg := map[string]interface{}{
-		"required_version": ">= 1.0",
-		"required_providers": map[string]interface{}{
-			// We use the =tc= prefix as we want this to be an
-			// object attribute and not a block. By default
-			// on the formater we have we replace all the '= {` for
-			// just '{' so this would be included too and it would
-			// be invalid configuration
-			fmt.Sprintf("=tc=%s", pv.String()): map[string]interface{}{
-				"source":  pv.Source(),
-				"version": fmt.Sprintf("=%s", pv.Version()),
-			},
-		},
+		"required_version":   ">= 1.0",
+		"required_providers": make(map[string]interface{}),
 	}
 	var cat string
 	if opts.HasModule() {
@@ -94,16 +91,92 @@ func NewWriter(w io.Writer, pv provider.Provider, opts *writer.Options) *Writer
 		wr.categories = append(wr.categories, tfKey)
 	}
 	wr.Config[tfKey]["terraform"] = tfcfg
+	wr.tfKey = tfKey
+
+	wr.AddProvider(pv)
 
-	if opts.HCLProviderBlock {
-		pvcfg := map[string]interface{}{
-			pv.String(): make(map[string]interface{}),
+	return wr
+}
+
+// AddProvider adds the pv to the 'required_providers' and, if the
+// HCLProviderBlock option is set, its 'provider {}' block so the
+// resources of more than one provider can be written on the same HCL
+func (w *Writer) AddProvider(pv provider.Provider) {
+	tfcfg := w.Config[w.tfKey]["terraform"].(map[string]interface{})
+	// We use the =tc= prefix as we want this to be an
+	// object attribute and not a block. By default
+	// on the formater we have we replace all the '= {` for
+	// just '{' so this would be included too and it would
+	// be invalid configuration
+	tfcfg["required_providers"].(map[string]interface{})[fmt.Sprintf("=tc=%s", pv.String())] = map[string]interface{}{
+		"source":  pv.Source(),
+		"version": fmt.Sprintf("=%s", pv.Version()),
+	}
+
+	if w.opts.HCLProviderBlock {
+		if _, ok := w.Config[w.tfKey]["provider"]; !ok {
+			w.Config[w.tfKey]["provider"] = make(map[string]interface{})
+		}
+		pcfg := make(map[string]interface{})
+		if alias := provider.Alias(pv); alias != "" {
+			pcfg["alias"] = alias
 		}
-		wr.Config[tfKey]["provider"] = pvcfg
-		wr.setProviderConfig(tfKey)
+		w.Config[w.tfKey]["provider"].(map[string]interface{})[providerKey(pv)] = pcfg
+		w.setProviderConfig(w.tfKey, pv)
 	}
 
-	return wr
+	if alias := provider.Alias(pv); alias != "" && w.opts.HasModule() {
+		w.addModuleProvider(pv, alias)
+	}
+}
+
+// addModuleProvider passes the aliased provider pv to the module, as they
+// are not inherited, and declares it on the 'configuration_aliases' of
+// the module so its resources can reference it
+func (w *Writer) addModuleProvider(pv provider.Provider, alias string) {
+	ref := fmt.Sprintf("${%s.%s}", pv.String(), alias)
+
+	mcfg := w.Config[writer.ModuleCategoryKey]["module"].(map[string]interface{})[w.opts.Module].(map[string]interface{})
+	if _, ok := mcfg["=tc=providers"]; !ok {
+		mcfg["=tc=providers"] = make(map[string]interface{})
+	}
+	mcfg["=tc=providers"].(map[string]interface{})[ref] = ref
+
+	if _, ok := w.Config[moduleProvidersCategoryKey]; !ok {
+		w.Config[moduleProvidersCategoryKey] = map[string]interface{}{
+			"terraform": map[string]interface{}{
+				"required_providers": make(map[string]interface{}),
+			},
+		}
+		w.categories = append(w.categories, moduleProvidersCategoryKey)
+	}
+
+	rps := w.Config[moduleProvidersCategoryKey]["terraform"].(map[string]interface{})["required_providers"].(map[string]interface{})
+	key := fmt.Sprintf("=tc=%s", pv.String())
+	if _, ok := rps[key]; !ok {
+		rps[key] = map[string]interface{}{
+			"source":                pv.Source(),
+			"configuration_aliases": make([]interface{}, 0),
+		}
+	}
+	rp := rps[key].(map[string]interface{})
+	rp["configuration_aliases"] = append(rp["configuration_aliases"].([]interface{}), ref)
+}
+
+// isResourceCategory checks if the category c
+// is one of the ones with the resources
+func (w *Writer) isResourceCategory(c string) bool {
+	return c != writer.ModuleCategoryKey && c != variablesCategoryKey && c != moduleProvidersCategoryKey && c != w.opts.TerraformCategoryKey
+}
+
+// providerKey returns the key of the pv on the 'provider' blocks, which
+// is its name followed by the alias, if it has one, as the same
+// provider can be configured more than once with different aliases
+func providerKey(pv provider.Provider) string {
+	if alias := provider.Alias(pv); alias != "" {
+		return fmt.Sprintf("%s.%s", pv.String(), alias)
+	}
+	return pv.String()
 }
 
 // Write expects a key similar to "aws_instance.your_name"
@@ -172,7 +245,7 @@ func (w *Writer) Has(key string) (bool, error) {
 	name := strings.Join(keys[1:], "")
 
 	for k, v := range w.Config {
-		if k == writer.ModuleCategoryKey || k == variablesCategoryKey || k == w.opts.TerraformCategoryKey {
+		if !w.isResourceCategory(k) {
 			continue
 		}
 		if _, ok := v["resource"].(map[string]map[string]interface{})[keys[0]][name]; ok {
@@ -256,7 +329,13 @@ func (w *Writer) Sync() error {
 			for _, resourceType := range resourceKeys {
 				resources := resourceMap[resourceType]
 				if blockType == "variable" || blockType == "module" || blockType == "provider" {
-					block := hclwrite.NewBlock(blockType, []string{resourceType})
+					label := resourceType
+					if blockType == "provider" {
+						// The aliased providers have the alias
+						// on the key, see providerKey
+						label = strings.Split(resourceType, ".")[0]
+					}
+					block := hclwrite.NewBlock(blockType, []string{label})
 					bbody := block.Body()
 
 					attrKeys := getValueKeys(resources)
@@ -349,7 +428,7 @@ func getValueKeys(val cty.Value) []string {
 func (w *Writer) setVariables() {
 	variables := make(map[string]interface{})
 	for c, cfg := range w.Config {
-		if c == writer.ModuleCategoryKey || c == variablesCategoryKey || c == w.opts.TerraformCategoryKey {
+		if !w.isResourceCategory(c) {
 			continue
 		}
 		for k, v := range cfg["resource"].(map[string]map[string]interface{}) {
@@ -376,6 +455,11 @@ func (w *Writer) setVariables() {
 func walkVariables(cfg map[string]interface{}, validVariables map[string]struct{}, k string, variables map[string]interface{}) map[string]interface{} {
 	for key, value := range cfg {
 		currentKey := fmt.Sprintf("%s.%s", k, key)
+		// The 'provider' of the resources (type.name.provider)
+		// has to be a reference so it can not be a variable
+		if key == "provider" && strings.Count(currentKey, ".") == 2 {
+			continue
+		}
 		switch v := value.(type) {
 		case map[string]interface{}:
 			if ok, nk := hasKey(validVariables, currentKey, isMap); ok {
@@ -544,7 +628,7 @@ func (w *Writer) Interpolate(i *interpolator.Interpolator) {
 	// who's interpolated with who
 	relations := make(map[string]struct{}, 0)
 	for k, v := range w.Config {
-		if k == writer.ModuleCategoryKey || k == variablesCategoryKey || k == w.opts.TerraformCategoryKey {
+		if !w.isResourceCategory(k) {
 			continue
 		}
 		resources := v["resource"]
@@ -667,10 +751,16 @@ func extractResourceTypeAndName(value string) (string, string) {
 
 // setProviderConfig will set the required fields to the provider configuration
 // under the given category
-func (w *Writer) setProviderConfig(cat string) {
-	pcfg := w.provider.Configuration()
-	for k, s := range w.provider.TFProvider().Schema {
+func (w *Writer) setProviderConfig(cat string, pv provider.Provider) {
+	pcfg := pv.Configuration()
+	alias := provider.Alias(pv)
+	for k, s := range pv.TFProvider().Schema {
 		if s.Required {
+			// Each aliased provider has its own variables
+			vk := k
+			if alias != "" {
+				vk = fmt.Sprintf("%s_%s", k, alias)
+			}
 			if _, ok := w.Config[cat]["variable"]; !ok {
 				w.Config[cat]["variable"] = make(map[string]interface{})
 			}
@@ -686,8 +776,16 @@ func (w *Writer) setProviderConfig(cat string) {
 					varVal["default"] = v
 				}
 			}
-			w.Config[cat]["variable"].(map[string]interface{})[k] = varVal
-			w.Config[cat]["provider"].(map[string]interface{})[w.provider.String()].(map[string]interface{})[k] = fmt.Sprintf("${var.%s}", k)
+			w.Config[cat]["variable"].(map[string]interface{})[vk] = varVal
+			w.Config[cat]["provider"].(map[string]interface{})[providerKey(pv)].(map[string]interface{})[k] = fmt.Sprintf("${var.%s}", vk)
+		} else if v, ok := pcfg[k]; ok {
+			// The optional blocks that are configured, like the
+			// 'assume_role' of AWS, are written as they are, and
+			// also all the configuration of the aliased providers
+			// as it's what differentiates them (ex: the region)
+			if _, ok := s.Elem.(*schema.Resource); ok || alias != "" {
+				w.Config[cat]["provider"].(map[string]interface{})[providerKey(pv)].(map[string]interface{})[k] = v
+			}
 		}
 	}
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/cycloidio/terracognita/mock"
 	"github.com/cycloidio/terracognita/writer"
 	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	aws "github.com/hashicorp/terraform-provider-aws/provider"
 	azurerm "github.com/hashicorp/terraform-provider-azurerm/provider"
 	"github.com/pkg/errors"
@@ -177,6 +178,15 @@ func TestNewHCLWriter(t *testing.T) {
 	})
 }
 
+// aliasedProvider is a mock.Provider
+// that implements the provider.Aliaser
+type aliasedProvider struct {
+	*mock.Provider
+	alias string
+}
+
+func (p aliasedProvider) Alias() string { return p.alias }
+
 func TestHCLWriter_Write(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		var (
@@ -369,6 +379,194 @@ resource "type" "name" {
 
 		assert.Equal(t, strings.Join(strings.Fields(ehcl), " "), strings.Join(strings.Fields(string(b)), " "))
 	})
+	t.Run("SuccessWithMultipleProviders", func(t *testing.T) {
+		var (
+			ctrl  = gomock.NewController(t)
+			p     = mock.NewProvider(ctrl)
+			gp    = mock.NewProvider(ctrl)
+			mx    = mxwriter.NewMux()
+			value = map[string]interface{}{
+				"key":         "value",
+				"tc_category": "some-category",
+			}
+			ehcl = `
+provider "aws" { }
+
+provider "google" { }
+
+terraform {
+	required_providers {
+		aws = {
+			source = "hashicorp/aws"
+			version = "=4.9.0"
+		}
+		google = {
+			source = "hashicorp/google"
+			version = "=4.9.0"
+		}
+	}
+	required_version = ">= 1.0"
+}
+
+resource "type" "name" {
+  key = "value"
+}
+
+`
+		)
+
+		p.EXPECT().String().Return("aws").Times(2)
+		p.EXPECT().Source().Return("hashicorp/aws")
+		p.EXPECT().Version().Return("4.9.0")
+		p.EXPECT().TFProvider().Return(aws.Provider())
+		p.EXPECT().Configuration().Return(map[string]interface{}{
+			"region": "eu-west-1",
+		})
+
+		gp.EXPECT().String().Return("google").Times(2)
+		gp.EXPECT().Source().Return("hashicorp/google")
+		gp.EXPECT().Version().Return("4.9.0")
+		gp.EXPECT().TFProvider().Return(&schema.Provider{})
+		gp.EXPECT().Configuration().Return(map[string]interface{}{})
+
+		hw := hcl.NewWriter(mx, p, &writer.Options{HCLProviderBlock: true, Interpolate: true})
+		hw.AddProvider(gp)
+
+		err := hw.Write("type.name", value)
+		require.NoError(t, err)
+
+		err = hw.Sync()
+		require.NoError(t, err)
+
+		b, err := ioutil.ReadAll(mx)
+		require.NoError(t, err)
+
+		assert.Equal(t, strings.Join(strings.Fields(ehcl), " "), strings.Join(strings.Fields(string(b)), " "))
+	})
+	t.Run("SuccessWithAssumeRole", func(t *testing.T) {
+		var (
+			ctrl  = gomock.NewController(t)
+			p     = mock.NewProvider(ctrl)
+			mx    = mxwriter.NewMux()
+			value = map[string]interface{}{
+				"key":         "value",
+				"tc_category": "some-category",
+			}
+			ehcl = `
+provider "aws" {
+	assume_role {
+		role_arn = "arn:aws:iam::123456789012:role/terracognita"
+	}
+}
+
+terraform {
+	required_providers {
+		aws = {
+			source = "hashicorp/aws"
+			version = "=4.9.0"
+		}
+	}
+	required_version = ">= 1.0"
+}
+
+resource "type" "name" {
+  key = "value"
+}
+
+`
+		)
+
+		p.EXPECT().String().Return("aws").Times(3)
+		p.EXPECT().Source().Return("hashicorp/aws")
+		p.EXPECT().Version().Return("4.9.0")
+		p.EXPECT().TFProvider().Return(aws.Provider())
+		p.EXPECT().Configuration().Return(map[string]interface{}{
+			"region": "eu-west-1",
+			"assume_role": map[string]interface{}{
+				"role_arn": "arn:aws:iam::123456789012:role/terracognita",
+			},
+		})
+
+		hw := hcl.NewWriter(mx, p, &writer.Options{HCLProviderBlock: true, Interpolate: true})
+
+		err := hw.Write("type.name", value)
+		require.NoError(t, err)
+
+		err = hw.Sync()
+		require.NoError(t, err)
+
+		b, err := ioutil.ReadAll(mx)
+		require.NoError(t, err)
+
+		assert.Equal(t, strings.Join(strings.Fields(ehcl), " "), strings.Join(strings.Fields(string(b)), " "))
+	})
+	t.Run("SuccessWithAliasedProvider", func(t *testing.T) {
+		var (
+			ctrl  = gomock.NewController(t)
+			p     = mock.NewProvider(ctrl)
+			ap    = aliasedProvider{Provider: mock.NewProvider(ctrl), alias: "us_east_1"}
+			mx    = mxwriter.NewMux()
+			value = map[string]interface{}{
+				"key":         "value",
+				"provider":    "${aws.us_east_1}",
+				"tc_category": "some-category",
+			}
+			ehcl = `
+provider "aws" { }
+
+provider "aws" {
+	alias = "us_east_1"
+	region = "us-east-1"
+}
+
+terraform {
+	required_providers {
+		aws = {
+			source = "hashicorp/aws"
+			version = "=4.9.0"
+		}
+	}
+	required_version = ">= 1.0"
+}
+
+resource "type" "name" {
+  key = "value"
+  provider = aws.us_east_1
+}
+
+`
+		)
+
+		p.EXPECT().String().Return("aws").Times(2)
+		p.EXPECT().Source().Return("hashicorp/aws")
+		p.EXPECT().Version().Return("4.9.0")
+		p.EXPECT().TFProvider().Return(aws.Provider())
+		p.EXPECT().Configuration().Return(map[string]interface{}{
+			"region": "eu-west-1",
+		})
+
+		ap.EXPECT().String().Return("aws").Times(3)
+		ap.EXPECT().Source().Return("hashicorp/aws")
+		ap.EXPECT().Version().Return("4.9.0")
+		ap.EXPECT().TFProvider().Return(aws.Provider())
+		ap.EXPECT().Configuration().Return(map[string]interface{}{
+			"region": "us-east-1",
+		})
+
+		hw := hcl.NewWriter(mx, p, &writer.Options{HCLProviderBlock: true, Interpolate: true})
+		hw.AddProvider(ap)
+
+		err := hw.Write("type.name", value)
+		require.NoError(t, err)
+
+		err = hw.Sync()
+		require.NoError(t, err)
+
+		b, err := ioutil.ReadAll(mx)
+		require.NoError(t, err)
+
+		assert.Equal(t, strings.Join(strings.Fields(ehcl), " "), strings.Join(strings.Fields(string(b)), " "))
+	})
 	t.Run("SuccessWithoutProviderBlock", func(t *testing.T) {
 		var (
 			ctrl  = gomock.NewController(t)
@@ -732,6 +930,96 @@ variable "type_name_key" {
 
 		assert.Equal(t, strings.Join(strings.Fields(ehcl), " "), strings.Join(strings.Fields(string(b)), " "))
 	})
+	t.Run("ModuleWithAliasedProviders", func(t *testing.T) {
+		var (
+			ctrl  = gomock.NewController(t)
+			p     = aliasedProvider{Provider: mock.NewProvider(ctrl), alias: "eu_west_1"}
+			ap    = aliasedProvider{Provider: mock.NewProvider(ctrl), alias: "us_east_1"}
+			mx    = mxwriter.NewMux()
+			value = map[string]interface{}{
+				"key":      "value",
+				"provider": "${aws.us_east_1}",
+			}
+			ehcl = `
+terraform {
+	required_providers {
+		aws = {
+			configuration_aliases = [aws.eu_west_1, aws.us_east_1]
+			source = "hashicorp/aws"
+		}
+	}
+}
+
+resource "type" "name" {
+	key = var.type_name_key
+	provider = aws.us_east_1
+}
+
+module "test" {
+	providers = {
+		aws.eu_west_1 = aws.eu_west_1
+		aws.us_east_1 = aws.us_east_1
+	}
+	source = "./module-test"
+	type_name_key = "value"
+}
+
+provider "aws" {
+	alias = "eu_west_1"
+	region = "eu-west-1"
+}
+
+provider "aws" {
+	alias = "us_east_1"
+	region = "us-east-1"
+}
+
+terraform {
+	required_providers {
+		aws = {
+			source = "hashicorp/aws"
+			version = "=4.9.0"
+		}
+	}
+	required_version = ">= 1.0"
+}
+
+variable "type_name_key" {
+	default = "value"
+}
+`
+		)
+
+		p.EXPECT().String().Return("aws").Times(5)
+		p.EXPECT().Source().Return("hashicorp/aws").Times(2)
+		p.EXPECT().Version().Return("4.9.0")
+		p.EXPECT().TFProvider().Return(aws.Provider())
+		p.EXPECT().Configuration().Return(map[string]interface{}{
+			"region": "eu-west-1",
+		})
+
+		ap.EXPECT().String().Return("aws").Times(5)
+		ap.EXPECT().Source().Return("hashicorp/aws")
+		ap.EXPECT().Version().Return("4.9.0")
+		ap.EXPECT().TFProvider().Return(aws.Provider())
+		ap.EXPECT().Configuration().Return(map[string]interface{}{
+			"region": "us-east-1",
+		})
+
+		hw := hcl.NewWriter(mx, p, &writer.Options{Interpolate: true, HCLProviderBlock: true, Module: "test"})
+		hw.AddProvider(ap)
+
+		err := hw.Write("type.name", value)
+		require.NoError(t, err)
+
+		err = hw.Sync()
+		require.NoError(t, err)
+
+		b, err := ioutil.ReadAll(mx)
+		require.NoError(t, err)
+
+		assert.Equal(t, strings.Join(strings.Fields(ehcl), " "), strings.Join(strings.Fields(string(b)), " "))
+	})
 	t.Run("Slice", func(t *testing.T) {
 		var (
 			ctrl  = gomock.NewController(t)
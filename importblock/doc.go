@@ -0,0 +1,4 @@
+// Package importblock has the Writer of the Terraform
+// 'import {}' blocks, which can be used instead of the
+// TFState to import the resources with Terraform 1.5+
+package importblock
@@ -0,0 +1,127 @@
+package importblock
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/interpolator"
+	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// Writer is a Writer implementation that generates an 'import {}'
+// block for each resource, it expects the same values as the
+// state.Writer so it can be used instead of it
+type Writer struct {
+	// Config has the ID of the resources by key
+	Config map[string]string
+	writer io.Writer
+	opts   *writer.Options
+}
+
+// NewWriter returns a Writer initialization
+func NewWriter(w io.Writer, opts *writer.Options) *Writer {
+	return &Writer{
+		Config: make(map[string]string),
+		writer: w,
+		opts:   opts,
+	}
+}
+
+// Write expects a key similar to "aws_instance.your_name" and the
+// value to be the provider.Resource, repeated keys will report an error
+func (w *Writer) Write(key string, value interface{}) error {
+	if key == "" {
+		return errcode.ErrWriterRequiredKey
+	}
+
+	if value == nil {
+		return errcode.ErrWriterRequiredValue
+	}
+
+	if _, ok := w.Config[key]; ok {
+		return errors.Wrapf(errcode.ErrWriterAlreadyExistsKey, "with key %q", key)
+	}
+
+	if len(strings.Split(key, ".")) != 2 {
+		return errors.Wrapf(errcode.ErrWriterInvalidKey, "with key %q", key)
+	}
+
+	r, ok := value.(provider.Resource)
+	if !ok {
+		return errors.Wrapf(errcode.ErrWriterInvalidTypeValue, "expected provider.Resource, found %T", value)
+	}
+
+	// The ID is the one used to import the resource
+	// so it's the one Terraform needs to import it too
+	w.Config[key] = r.ID()
+
+	log.Get().Log("func", "importblock.Write", "msg", "writing to internal config", "key", key, "id", r.ID())
+
+	return nil
+}
+
+// Has checks if the given key it's already present or not
+func (w *Writer) Has(key string) (bool, error) {
+	_, ok := w.Config[key]
+	return ok, nil
+}
+
+// Sync writes one 'import {}' block for each resource,
+// sorted by key, to the internal w
+func (w *Writer) Sync() error {
+	keys := make([]string, 0, len(w.Config))
+	for k := range w.Config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	for i, k := range keys {
+		if i != 0 {
+			body.AppendNewline()
+		}
+
+		b := body.AppendNewBlock("import", nil).Body()
+		b.SetAttributeTraversal("to", w.address(k))
+		b.SetAttributeValue("id", cty.StringVal(w.Config[k]))
+	}
+
+	log.Get().Log("func", "importblock.Sync", "msg", "writing the import blocks")
+
+	if _, err := f.WriteTo(w.writer); err != nil {
+		return fmt.Errorf("could not write the import blocks: %w", err)
+	}
+
+	return nil
+}
+
+// address returns the address of the resource with the key,
+// prefixed with the module if it has one
+func (w *Writer) address(key string) hcl.Traversal {
+	parts := strings.Split(key, ".")
+	if w.opts.HasModule() {
+		parts = append([]string{"module", w.opts.Module}, parts...)
+	}
+
+	t := hcl.Traversal{hcl.TraverseRoot{Name: parts[0]}}
+	for _, p := range parts[1:] {
+		t = append(t, hcl.TraverseAttr{Name: p})
+	}
+
+	return t
+}
+
+// Interpolate does nothing as the import
+// blocks do not have references
+func (w *Writer) Interpolate(i *interpolator.Interpolator) {}
@@ -0,0 +1,126 @@
+package importblock_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/importblock"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/writer"
+)
+
+func TestWrite(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			res  = mock.NewResource(ctrl)
+			iw   = importblock.NewWriter(nil, &writer.Options{})
+		)
+		defer ctrl.Finish()
+
+		res.EXPECT().ID().Return("i-1").Times(2)
+
+		err := iw.Write("aws_instance.front", res)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{"aws_instance.front": "i-1"}, iw.Config)
+
+		ok, err := iw.Has("aws_instance.front")
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = iw.Has("aws_instance.back")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+	t.Run("ErrRequiredKey", func(t *testing.T) {
+		iw := importblock.NewWriter(nil, &writer.Options{})
+
+		err := iw.Write("", nil)
+		assert.Equal(t, errcode.ErrWriterRequiredKey, errors.Cause(err))
+	})
+	t.Run("ErrRequiredValue", func(t *testing.T) {
+		iw := importblock.NewWriter(nil, &writer.Options{})
+
+		err := iw.Write("aws_instance.front", nil)
+		assert.Equal(t, errcode.ErrWriterRequiredValue, errors.Cause(err))
+	})
+	t.Run("ErrInvalidTypeValue", func(t *testing.T) {
+		iw := importblock.NewWriter(nil, &writer.Options{})
+
+		err := iw.Write("aws_instance.front", 0)
+		assert.Equal(t, errcode.ErrWriterInvalidTypeValue, errors.Cause(err))
+	})
+	t.Run("ErrInvalidKey", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			res  = mock.NewResource(ctrl)
+			iw   = importblock.NewWriter(nil, &writer.Options{})
+		)
+		defer ctrl.Finish()
+
+		err := iw.Write("aws_instance", res)
+		assert.Equal(t, errcode.ErrWriterInvalidKey, errors.Cause(err))
+	})
+}
+
+func TestSync(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			res1 = mock.NewResource(ctrl)
+			res2 = mock.NewResource(ctrl)
+			b    = &bytes.Buffer{}
+			iw   = importblock.NewWriter(b, &writer.Options{})
+		)
+		defer ctrl.Finish()
+
+		res1.EXPECT().ID().Return("i-1").AnyTimes()
+		res2.EXPECT().ID().Return(`arn:aws:iam::1:user/"admin"`).AnyTimes()
+
+		require.NoError(t, iw.Write("aws_instance.front", res1))
+		require.NoError(t, iw.Write("aws_iam_user.admin", res2))
+
+		err := iw.Sync()
+		require.NoError(t, err)
+
+		assert.Equal(t, `import {
+  to = aws_iam_user.admin
+  id = "arn:aws:iam::1:user/\"admin\""
+}
+
+import {
+  to = aws_instance.front
+  id = "i-1"
+}
+`, b.String())
+	})
+	t.Run("SuccessWithModule", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			res  = mock.NewResource(ctrl)
+			b    = &bytes.Buffer{}
+			iw   = importblock.NewWriter(b, &writer.Options{Module: "test"})
+		)
+		defer ctrl.Finish()
+
+		res.EXPECT().ID().Return("i-1").AnyTimes()
+
+		require.NoError(t, iw.Write("aws_instance.front", res))
+
+		err := iw.Sync()
+		require.NoError(t, err)
+
+		assert.Equal(t, `import {
+  to = module.test.aws_instance.front
+  id = "i-1"
+}
+`, b.String())
+	})
+}
@@ -14,8 +14,10 @@ type Interpolator struct {
 	// is gonna be used on
 	provider string
 	// resources is a map with all the resources available to interpolate
-	// the key is the 'aws_instance.front' (KEY+NAME) and the value
-	// is all the attributes it has available to be referenced
+	// the key is the type without the provider 'instance' and then the
+	// full resource 'aws_instance.front' (TYPE+NAME) so the same type of
+	// different providers do not collide, the value is all the attributes
+	// it has available to be referenced
 	resources map[string]map[string]map[string]string
 
 	// values holds all the possible values on the resources, the
@@ -45,10 +47,7 @@ func (i *Interpolator) AddResourceAttributes(r string, a map[string]string) {
 	if _, ok := i.resources[sr[0]]; !ok {
 		i.resources[sr[0]] = make(map[string]map[string]string)
 	}
-	if _, ok := i.resources[sr[0]][sr[1]]; !ok {
-		i.resources[sr[0]][sr[1]] = make(map[string]string)
-	}
-	i.resources[sr[0]][sr[1]] = a
+	i.resources[sr[0]][r] = a
 	for k, v := range a {
 		i.values[v] = fmt.Sprintf("${%s.%s}", r, k)
 	}
@@ -109,19 +108,35 @@ func (i *Interpolator) Interpolate(k, v string) (string, bool) {
 // are on the 'virtual_machine' we try to find the attribute by seeking what's missing on it, in this case the 'id', so we try to
 // match it wit the attribute `.id`
 func (i *Interpolator) checkAttributes(sk []string, v string, ngi int, ng string, rns map[string]map[string]string) string {
-	for rn, attrs := range rns {
+	rs := i.sortResources(rns)
+	for _, r := range rs {
 		att := strings.Join(sk[(len(sk)-(ngi)):len(sk)], "_")
-		if av, ok := attrs[att]; ok && strings.ToLower(av) == strings.ToLower(v) {
-			return fmt.Sprintf("${%s.%s.%s}", fmt.Sprintf("%s_%s", i.provider, ng), rn, att)
+		if av, ok := rns[r][att]; ok && strings.ToLower(av) == strings.ToLower(v) {
+			return fmt.Sprintf("${%s.%s}", r, att)
 		}
 	}
 	// Then if no exact we try to find first one with the same value on the resource
-	for rn, attrs := range rns {
-		for ak, av := range attrs {
+	for _, r := range rs {
+		for ak, av := range rns[r] {
 			if strings.ToLower(av) == strings.ToLower(v) {
-				return fmt.Sprintf("${%s.%s.%s}", fmt.Sprintf("%s_%s", i.provider, ng), rn, ak)
+				return fmt.Sprintf("${%s.%s}", r, ak)
 			}
 		}
 	}
 	return ""
 }
+
+// sortResources returns the resources of rns with the ones
+// of the provider of the Interpolator first, so the references
+// to other providers are only used if there is no match on it
+func (i *Interpolator) sortResources(rns map[string]map[string]string) []string {
+	rs := make([]string, 0, len(rns))
+	for r := range rns {
+		rs = append(rs, r)
+	}
+	prefix := i.provider + "_"
+	sort.SliceStable(rs, func(i, j int) bool {
+		return strings.HasPrefix(rs[i], prefix) && !strings.HasPrefix(rs[j], prefix)
+	})
+	return rs
+}
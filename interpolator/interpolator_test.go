@@ -37,3 +37,39 @@ func TestInterpolate(t *testing.T) {
 	assert.Equal(t, s, "")
 	assert.False(t, ok)
 }
+
+func TestInterpolateMultipleProviders(t *testing.T) {
+	i := interpolator.New("aws")
+	i.AddResourceAttributes("aws_route_table.front", map[string]string{
+		"id": "rtb-1",
+	})
+	i.AddResourceAttributes("azurerm_route_table.front", map[string]string{
+		"id":   "/subscriptions/1/routeTables/front",
+		"name": "front",
+	})
+	i.AddResourceAttributes("google_compute_network.main", map[string]string{
+		"id": "network-1",
+	})
+
+	s, ok := i.Interpolate("route_table_id", "rtb-1")
+	assert.Equal(t, "${aws_route_table.front.id}", s)
+	assert.True(t, ok)
+
+	s, ok = i.Interpolate("route_table_id", "/subscriptions/1/routeTables/front")
+	assert.Equal(t, "${azurerm_route_table.front.id}", s)
+	assert.True(t, ok)
+
+	s, ok = i.Interpolate("network", "network-1")
+	assert.Equal(t, "${google_compute_network.main.id}", s)
+	assert.True(t, ok)
+
+	// With the same value the resources of the
+	// provider of the Interpolator are used first
+	i.AddResourceAttributes("aws_route_table.back", map[string]string{
+		"name": "front",
+	})
+
+	s, ok = i.Interpolate("route_table_name", "front")
+	assert.Equal(t, "${aws_route_table.back.name}", s)
+	assert.True(t, ok)
+}
@@ -0,0 +1,4 @@
+// Package journal keeps the record of the resources
+// already imported so an interrupted import can be
+// resumed without reading them again
+package journal
@@ -0,0 +1,150 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Entry is the record of a resource listed
+// from the provider once it has been read
+type Entry struct {
+	// Type is the type of the listed resource (ex: aws_instance)
+	Type string `json:"type"`
+
+	// ID is the ID of the listed resource
+	ID string `json:"id,omitempty"`
+
+	// Resources are all the resources read from the
+	// listed one, it can be empty if none was imported
+	Resources []Resource `json:"resources,omitempty"`
+
+	// Done means that all the resources of the
+	// Type have been imported
+	Done bool `json:"done,omitempty"`
+}
+
+// Resource is the already read resource. The Name is empty
+// if it was recorded before being written, in which case
+// it's given one once loaded
+type Resource struct {
+	Type       string            `json:"type"`
+	ID         string            `json:"id"`
+	Name       string            `json:"name,omitempty"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// Journal writes each Entry as a JSON line to the
+// internal writer and keeps the ones already
+// written so they can be checked.
+// It's concurrently safe
+type Journal struct {
+	w       io.Writer
+	entries map[string][]Entry
+	ids     map[string]map[string]struct{}
+	done    map[string]struct{}
+	mu      sync.Mutex
+}
+
+// New returns a Journal that writes to w
+func New(w io.Writer) *Journal {
+	return &Journal{
+		w:       w,
+		entries: make(map[string][]Entry),
+		ids:     make(map[string]map[string]struct{}),
+		done:    make(map[string]struct{}),
+	}
+}
+
+// Load returns a Journal that writes to w with all
+// the entries already written on r
+func Load(r io.Reader, w io.Writer) (*Journal, error) {
+	j := New(w)
+
+	s := bufio.NewScanner(r)
+	// The attributes of a resource can be big
+	// so we allow lines of up to 64MB
+	s.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for s.Scan() {
+		if len(s.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			return nil, errors.Wrapf(err, "invalid journal entry %q", s.Text())
+		}
+		j.add(e)
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.Wrap(err, "unable to read the journal")
+	}
+
+	return j, nil
+}
+
+// Write adds the e to the Journal
+func (j *Journal) Write(e Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal the journal entry")
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err = j.w.Write(append(b, '\n')); err != nil {
+		return errors.Wrap(err, "unable to write the journal entry")
+	}
+	j.add(e)
+
+	return nil
+}
+
+// Done marks the type t as fully imported
+func (j *Journal) Done(t string) error {
+	return j.Write(Entry{Type: t, Done: true})
+}
+
+// IsDone checks if the type t was fully imported
+func (j *Journal) IsDone(t string) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_, ok := j.done[t]
+	return ok
+}
+
+// Has checks if the resource with type t and
+// id has already been imported
+func (j *Journal) Has(t, id string) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_, ok := j.ids[t][id]
+	return ok
+}
+
+// Entries returns all the entries of the type t
+// on the same order they were written
+func (j *Journal) Entries(t string) []Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return append([]Entry(nil), j.entries[t]...)
+}
+
+// add stores the e, it expects the lock to be held
+func (j *Journal) add(e Entry) {
+	if e.Done {
+		j.done[e.Type] = struct{}{}
+		return
+	}
+	if _, ok := j.ids[e.Type]; !ok {
+		j.ids[e.Type] = make(map[string]struct{})
+	}
+	j.ids[e.Type][e.ID] = struct{}{}
+	j.entries[e.Type] = append(j.entries[e.Type], e)
+}
@@ -0,0 +1,76 @@
+package journal_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cycloidio/terracognita/journal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var b bytes.Buffer
+		j := journal.New(&b)
+
+		err := j.Write(journal.Entry{
+			Type: "aws_instance",
+			ID:   "1",
+			Resources: []journal.Resource{
+				{Type: "aws_instance", ID: "1", Name: "front", Attributes: map[string]string{"id": "1"}},
+			},
+		})
+		require.NoError(t, err)
+		err = j.Done("aws_instance")
+		require.NoError(t, err)
+
+		assert.Equal(t, `{"type":"aws_instance","id":"1","resources":[{"type":"aws_instance","id":"1","name":"front","attributes":{"id":"1"}}]}
+{"type":"aws_instance","done":true}
+`, b.String())
+		assert.True(t, j.Has("aws_instance", "1"))
+		assert.False(t, j.Has("aws_instance", "2"))
+		assert.True(t, j.IsDone("aws_instance"))
+		assert.Len(t, j.Entries("aws_instance"), 1)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var (
+			b bytes.Buffer
+			r = strings.NewReader(`{"type":"aws_instance","id":"1","resources":[{"type":"aws_instance","id":"1","name":"front","attributes":{"id":"1"}}]}
+{"type":"aws_instance","id":"2"}
+{"type":"aws_instance","done":true}
+
+{"type":"aws_iam_user","id":"3"}
+`)
+		)
+
+		j, err := journal.Load(r, &b)
+		require.NoError(t, err)
+
+		assert.True(t, j.IsDone("aws_instance"))
+		assert.False(t, j.IsDone("aws_iam_user"))
+		assert.True(t, j.Has("aws_instance", "2"))
+		assert.True(t, j.Has("aws_iam_user", "3"))
+		assert.Equal(t, []journal.Entry{
+			{
+				Type: "aws_instance",
+				ID:   "1",
+				Resources: []journal.Resource{
+					{Type: "aws_instance", ID: "1", Name: "front", Attributes: map[string]string{"id": "1"}},
+				},
+			},
+			{Type: "aws_instance", ID: "2"},
+		}, j.Entries("aws_instance"))
+		assert.Empty(t, b.String())
+	})
+	t.Run("ErrorWithInvalidEntry", func(t *testing.T) {
+		var b bytes.Buffer
+
+		_, err := journal.Load(strings.NewReader("{invalid"), &b)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	kitlog "github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/drift"
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/event"
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/tag"
+	"github.com/cycloidio/terracognita/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// DriftOptions are the options of a Drift
+type DriftOptions struct {
+	// MaxRetries and RetryMaxWait are the retries of the
+	// listing and the read of the resources, see ImportOptions
+	MaxRetries   int
+	RetryMaxWait time.Duration
+}
+
+// Drift compares the resources of the es with the current ones of the Provider p,
+// only of the types that are on the es and filtered by f. If the f has Targets only
+// those are compared. Each resource of the es
+// is read again to compare its attributes, the ones that do not exist anymore, and
+// match the f, are deleted and the ones that exist but are not on the es are unmanaged.
+// The progress is sent as Events to the h if not nil.
+// If the ctx is done the comparison stops and the Report until then is returned
+// with the ctx error
+func Drift(ctx context.Context, p Provider, es *ExistingState, f *filter.Filter, opts DriftOptions, h event.Handler) (*drift.Report, error) {
+	logger := log.Get()
+	logger = kitlog.With(logger, "func", "provider.Drift")
+
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	if f.HasPatterns() {
+		if err := f.Expand(p.ResourceTypes()); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		rep   = drift.New(p.String())
+		rc    = recorder{h: h}
+		retry = RetryPolicy(p, opts.MaxRetries, opts.RetryMaxWait)
+
+		typesWithIDs map[string][]string
+	)
+
+	if len(f.Targets) != 0 {
+		typesWithIDs = f.TargetsTypesWithIDs()
+	}
+
+	for _, t := range es.Types() {
+		logger := kitlog.With(logger, "resource", t)
+		if f.IsExcluded(t) || !f.IsIncluded(t) {
+			logger.Log("msg", "excluded")
+			continue
+		}
+		if _, ok := typesWithIDs[t]; typesWithIDs != nil && !ok {
+			logger.Log("msg", "not targeted")
+			continue
+		}
+		if !p.HasResourceType(t) {
+			logger.Log("msg", "not supported by the provider")
+			continue
+		}
+
+		rc.emit(event.Event{Type: event.TypeResourceTypeStarted, ResourceType: t})
+
+		// The targeted resources are not listed but read directly,
+		// so the ones that do not exist anymore are deleted once read
+		var (
+			resources []Resource
+			err       error
+		)
+		if typesWithIDs != nil {
+			for _, id := range typesWithIDs[t] {
+				resources = append(resources, NewResource(id, t, p))
+			}
+		} else {
+			err = util.RetryContext(ctx, func() (err error) {
+				resources, err = p.Resources(ctx, t, f)
+				return err
+			}, retry)
+		}
+		if err != nil {
+			// The errors of the provider are reported and the
+			// rest of types are compared
+			if errors.Is(err, errcode.ErrProviderAPI) {
+				logger.Log("error", err)
+				rep.Add(drift.Resource{Type: t, Status: drift.StatusError, Error: err.Error()})
+				continue
+			}
+			return nil, errors.WithStack(err)
+		}
+
+		listed := make(map[string]struct{}, len(resources))
+		for i, re := range resources {
+			if ctx.Err() != nil {
+				break
+			}
+
+			id := re.ID()
+			if !f.MatchID(id) {
+				continue
+			}
+			listed[id] = struct{}{}
+
+			er, ok := es.Get(t, id)
+			if !ok {
+				rep.Add(drift.Resource{Type: t, ID: id, Status: drift.StatusUnmanaged})
+				continue
+			}
+
+			rc.emit(event.Event{Type: event.TypeResourceStarted, ResourceType: t, ID: id, Current: i + 1, Total: len(resources)})
+			logger.Log("msg", "comparing", "id", id)
+
+			actual, err := readAttributes(ctx, re, f, retry)
+			if err != nil {
+				if ctx.Err() != nil {
+					break
+				}
+				// The ones filtered once read (ex: by tags) are
+				// not compared, but as they were listed they
+				// are not deleted either
+				if errcode.Classify(err) == errcode.ClassSkipped {
+					logger.Log("msg", "skipped", "id", id, "error", err)
+					continue
+				}
+				if errors.Is(err, errcode.ErrProviderResourceNotRead) {
+					rep.Add(drift.Resource{Type: t, ID: id, Address: er.Address(), Status: drift.StatusDeleted})
+				} else {
+					rep.Add(drift.Resource{Type: t, ID: id, Address: er.Address(), Status: drift.StatusError, Error: err.Error()})
+				}
+				continue
+			}
+
+			// The ones that do not match the Where are not compared,
+			// but as they were listed they are not deleted either
+			if !f.MatchWhere(actual) {
+				logger.Log("msg", "does not match the where expression", "id", id)
+				continue
+			}
+
+			expected, err := existingAttributes(er, p)
+			if err != nil {
+				return nil, err
+			}
+
+			rep.AddCompared(t, id, er.Address(), expected, actual)
+		}
+
+		// If it was stopped not all the resources were
+		// listed so the deleted can not be known
+		if ctx.Err() != nil {
+			break
+		}
+
+		for _, er := range es.Resources(t) {
+			if _, ok := listed[er.ID]; ok || typesWithIDs != nil {
+				continue
+			}
+
+			// The listing may have been narrowed by the filters so
+			// the ones that do not match them are not deleted
+			ok, err := matchExisting(er, p, f)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				rep.Add(drift.Resource{Type: t, ID: er.ID, Address: er.Address(), Status: drift.StatusDeleted})
+			}
+		}
+
+		rc.emit(event.Event{Type: event.TypeResourceTypeDone, ResourceType: t, Total: len(resources)})
+	}
+
+	if ctx.Err() != nil {
+		return rep, errors.Wrap(ctx.Err(), "the drift was stopped, only the resources compared until then are reported")
+	}
+
+	return rep, nil
+}
+
+// matchExisting checks if the er, from the Provider p, matches the filters of the f
+// that the resources are listed or read with: the ID and name patterns, the tags
+// and the where expression
+func matchExisting(er ExistingResource, p Provider, f *filter.Filter) (bool, error) {
+	if !f.MatchID(er.ID) {
+		return false, nil
+	}
+	if !f.HasTags() && f.NamePattern == "" && f.Where == nil {
+		return true, nil
+	}
+
+	attrs, err := existingAttributes(er, p)
+	if err != nil {
+		return false, err
+	}
+	srd := p.TFProvider().ResourcesMap[er.Type].Data(&terraform.InstanceState{ID: er.ID, Attributes: attrs})
+
+	if f.HasTags() && !f.MatchTags(tag.GetTags(p.String(), p.TagKey(), srd)) {
+		return false, nil
+	}
+	if f.NamePattern != "" {
+		if name, _ := ResourceName(p, er.Type, srd); !f.MatchName(name) {
+			return false, nil
+		}
+	}
+
+	return f.MatchWhere(attrs), nil
+}
+
+// readAttributes imports and reads the re, filtered by f,
+// and returns the current attributes it has
+func readAttributes(ctx context.Context, re Resource, f *filter.Filter, retry util.RetryPolicy) (map[string]string, error) {
+	err := runWithContext(ctx, func() error {
+		return util.RetryContext(ctx, func() error {
+			_, err := re.ImportState(ctx)
+			return err
+		}, retry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if re.InstanceState() == nil {
+		return nil, errcode.ErrProviderResourceNotRead
+	}
+
+	err = runWithContext(ctx, func() error {
+		return util.RetryContext(ctx, func() error { return re.Read(ctx, f) }, retry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return re.InstanceState().Attributes, nil
+}
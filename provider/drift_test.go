@@ -0,0 +1,274 @@
+package provider_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform/tfdiags"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/drift"
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+)
+
+func TestDrift(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = mock.NewProvider(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			iamUser3 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		es, err := provider.ReadExistingState(strings.NewReader(existingState))
+		require.NoError(t, err)
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
+		p.EXPECT().TFProvider().Return(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"aws_iam_user": &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		})
+
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser3}, nil)
+
+		iamUser1.EXPECT().ID().Return("1")
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{ID: "1", Attributes: map[string]string{"id": "1", "name": "root"}})
+
+		// The iamUser3 is not on the state
+		iamUser3.EXPECT().ID().Return("3")
+
+		rep, err := provider.Drift(ctx, p, es, f, provider.DriftOptions{}, nil)
+		require.NoError(t, err)
+
+		assert.True(t, rep.HasDrift())
+		assert.Equal(t, []drift.Resource{
+			{
+				Type: "aws_iam_user", ID: "1", Address: "aws_iam_user.admin", Status: drift.StatusChanged,
+				Changes: []drift.Change{{Attribute: "name", Expected: "admin", Actual: "root"}},
+			},
+			{Type: "aws_iam_user", ID: "3", Status: drift.StatusUnmanaged},
+		}, rep.Resources())
+	})
+	t.Run("SuccessWithRetriedImportState", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = retrierProvider{Provider: mock.NewProvider(ctrl)}
+			iamUser1 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+
+			throttling = &provider.DiagnosticsError{
+				Diagnostics: tfdiags.Diagnostics{}.Append(errors.New("error reading IAM User (1): Throttling: Rate exceeded")),
+			}
+		)
+
+		defer ctrl.Finish()
+
+		es, err := provider.ReadExistingState(strings.NewReader(existingState))
+		require.NoError(t, err)
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
+		p.EXPECT().TFProvider().Return(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"aws_iam_user": &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		})
+
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1}, nil)
+
+		iamUser1.EXPECT().ID().Return("1")
+		gomock.InOrder(
+			iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, errors.Wrap(throttling, "could not import resource")),
+			iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil),
+		)
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{ID: "1", Attributes: map[string]string{"id": "1", "name": "admin"}})
+
+		rep, err := provider.Drift(ctx, p, es, f, provider.DriftOptions{MaxRetries: 1, RetryMaxWait: time.Millisecond}, nil)
+		require.NoError(t, err)
+
+		assert.False(t, rep.HasDrift())
+		assert.Equal(t, []drift.Resource{
+			{Type: "aws_iam_user", ID: "1", Address: "aws_iam_user.admin", Status: drift.StatusInSync},
+		}, rep.Resources())
+	})
+	t.Run("SuccessWithErrProviderResourceDoNotMatchTag", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = mock.NewProvider(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		es, err := provider.ReadExistingState(strings.NewReader(existingState))
+		require.NoError(t, err)
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
+
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1}, nil)
+
+		// It's filtered once read so it's
+		// neither compared nor deleted
+		iamUser1.EXPECT().ID().Return("1")
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(errcode.ErrProviderResourceDoNotMatchTag)
+
+		rep, err := provider.Drift(ctx, p, es, f, provider.DriftOptions{}, nil)
+		require.NoError(t, err)
+
+		assert.False(t, rep.HasDrift())
+		assert.Empty(t, rep.Resources())
+	})
+	t.Run("SuccessWithDeleted", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p = mock.NewProvider(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		es, err := provider.ReadExistingState(strings.NewReader(existingState))
+		require.NoError(t, err)
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
+
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return(nil, nil)
+
+		rep, err := provider.Drift(ctx, p, es, f, provider.DriftOptions{}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []drift.Resource{
+			{Type: "aws_iam_user", ID: "1", Address: "aws_iam_user.admin", Status: drift.StatusDeleted},
+		}, rep.Resources())
+	})
+	t.Run("SuccessWithDeletedNotMatchingFilter", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p = mock.NewProvider(ctrl)
+
+			f = &filter.Filter{NamePattern: "^root"}
+		)
+
+		defer ctrl.Finish()
+
+		es, err := provider.ReadExistingState(strings.NewReader(existingState))
+		require.NoError(t, err)
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
+		p.EXPECT().TFProvider().Return(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"aws_iam_user": &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		}).Times(2)
+
+		// The admin is not listed as its name does
+		// not match, so it's not deleted
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return(nil, nil)
+
+		rep, err := provider.Drift(ctx, p, es, f, provider.DriftOptions{}, nil)
+		require.NoError(t, err)
+
+		assert.False(t, rep.HasDrift())
+		assert.Empty(t, rep.Resources())
+	})
+	t.Run("SuccessWithTargets", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p = mock.NewProvider(ctrl)
+
+			f = &filter.Filter{Targets: []string{"aws_instance.i-1"}}
+		)
+
+		defer ctrl.Finish()
+
+		es, err := provider.ReadExistingState(strings.NewReader(existingState))
+		require.NoError(t, err)
+
+		// The aws_iam_user is not targeted so it's
+		// neither listed nor its resources deleted
+		p.EXPECT().String().Return("aws")
+
+		rep, err := provider.Drift(ctx, p, es, f, provider.DriftOptions{}, nil)
+		require.NoError(t, err)
+
+		assert.False(t, rep.HasDrift())
+		assert.Empty(t, rep.Resources())
+	})
+	t.Run("SuccessWithExcluded", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p = mock.NewProvider(ctrl)
+
+			f = &filter.Filter{Exclude: []string{"aws_iam_user"}}
+		)
+
+		defer ctrl.Finish()
+
+		es, err := provider.ReadExistingState(strings.NewReader(existingState))
+		require.NoError(t, err)
+
+		p.EXPECT().String().Return("aws")
+
+		rep, err := provider.Drift(ctx, p, es, f, provider.DriftOptions{}, nil)
+		require.NoError(t, err)
+
+		assert.False(t, rep.HasDrift())
+		assert.Empty(t, rep.Resources())
+	})
+}
@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/configs/hcl2shim"
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/states/statefile"
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/util"
+)
+
+// ExistingState has the resources of a TFState from a previous
+// import so they can keep the same address when imported again
+type ExistingState struct {
+	// resources are the resources by type and ID
+	resources map[string]map[string]ExistingResource
+}
+
+// ExistingResource is a resource of an ExistingState
+type ExistingResource struct {
+	Type string
+	Name string
+	ID   string
+
+	src *states.ResourceInstanceObjectSrc
+}
+
+// Address returns the address of the resource (ex: aws_instance.front)
+func (er ExistingResource) Address() string {
+	return fmt.Sprintf("%s.%s", er.Type, er.Name)
+}
+
+// NewExistingState returns an empty ExistingState
+func NewExistingState() *ExistingState {
+	return &ExistingState{
+		resources: make(map[string]map[string]ExistingResource),
+	}
+}
+
+// ReadExistingState reads the TFState from r
+func ReadExistingState(r io.Reader) (*ExistingState, error) {
+	return readState(r, false)
+}
+
+// ReadManagedState reads the TFState, of a Terraform configuration
+// not generated by Terracognita, from r. Unlike ReadExistingState
+// it also has the instances of the resources with count or for_each
+func ReadManagedState(r io.Reader) (*ExistingState, error) {
+	return readState(r, true)
+}
+
+// readState reads the TFState from r, if allKeys is false only
+// the resources without count or for_each are read
+func readState(r io.Reader, allKeys bool) (*ExistingState, error) {
+	f, err := statefile.Read(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read the existing state")
+	}
+
+	es := NewExistingState()
+
+	for _, m := range f.State.Modules {
+		for _, rs := range m.Resources {
+			if rs.Addr.Resource.Mode != addrs.ManagedResourceMode {
+				continue
+			}
+
+			for k, is := range rs.Instances {
+				// Terracognita only writes resources without
+				// key so the others are ignored
+				if (!allKeys && k != addrs.NoKey) || is.Current == nil {
+					continue
+				}
+
+				id, err := stateID(is.Current)
+				if err != nil {
+					return nil, errors.Wrapf(err, "could not read the ID of %s", rs.Addr)
+				}
+				if id == "" {
+					continue
+				}
+
+				es.add(ExistingResource{
+					Type: rs.Addr.Resource.Type,
+					Name: rs.Addr.Resource.Name,
+					ID:   id,
+					src:  is.Current,
+				})
+			}
+		}
+	}
+
+	return es, nil
+}
+
+// add adds the er to the es replacing
+// the one with the same type and ID
+func (es *ExistingState) add(er ExistingResource) {
+	if _, ok := es.resources[er.Type]; !ok {
+		es.resources[er.Type] = make(map[string]ExistingResource)
+	}
+	es.resources[er.Type][er.ID] = er
+}
+
+// Merge adds all the resources of oes to the es, the ones
+// with the same type and ID are replaced by the ones of oes
+func (es *ExistingState) Merge(oes *ExistingState) {
+	for _, rs := range oes.resources {
+		for _, er := range rs {
+			es.add(er)
+		}
+	}
+}
+
+// stateID returns the ID of the src without
+// having to decode it with the schema
+func stateID(src *states.ResourceInstanceObjectSrc) (string, error) {
+	if src.AttrsFlat != nil {
+		return src.AttrsFlat["id"], nil
+	}
+
+	var attrs struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(src.AttrsJSON, &attrs); err != nil {
+		return "", err
+	}
+
+	return attrs.ID, nil
+}
+
+// Get returns the resource of type t and id if it exists
+func (es *ExistingState) Get(t, id string) (ExistingResource, bool) {
+	er, ok := es.resources[t][id]
+	return er, ok
+}
+
+// Resources returns all the resources of
+// type t sorted by address
+func (es *ExistingState) Resources(t string) []ExistingResource {
+	res := make([]ExistingResource, 0, len(es.resources[t]))
+	for _, er := range es.resources[t] {
+		res = append(res, er)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res
+}
+
+// Types returns all the resource types sorted
+func (es *ExistingState) Types() []string {
+	types := make([]string, 0, len(es.resources))
+	for t := range es.resources {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	return types
+}
+
+// loadExistingResource returns the Resource of the er
+// from the Provider p with the same name it has
+func loadExistingResource(er ExistingResource, p Provider) (Resource, error) {
+	attrs, err := existingAttributes(er, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadResource(er.ID, er.Type, er.Name, attrs, p)
+}
+
+// existingAttributes returns the attributes that the er
+// has on the state decoded with the schema of the Provider p
+func existingAttributes(er ExistingResource, p Provider) (map[string]string, error) {
+	tfr, ok := p.TFProvider().ResourcesMap[er.Type]
+	if !ok {
+		return nil, errors.Wrapf(errcode.ErrProviderResourceNotSupported, "type %s on the existing state", er.Type)
+	}
+
+	ty, err := util.HashicorpToZclonfType(tfr.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := er.src.Decode(ty)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode %s from the existing state", er.Address())
+	}
+
+	return hcl2shim.FlatmapValueFromHCL2(obj.Value), nil
+}
@@ -0,0 +1,140 @@
+package provider_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/provider"
+)
+
+const existingState = `{
+  "version": 4,
+  "terraform_version": "1.1.9",
+  "serial": 1,
+  "lineage": "lineage",
+  "outputs": {},
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "aws_iam_user",
+      "name": "admin",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [
+        {
+          "schema_version": 0,
+          "attributes": {
+            "id": "1",
+            "name": "admin"
+          }
+        }
+      ]
+    },
+    {
+      "mode": "data",
+      "type": "aws_iam_user",
+      "name": "data",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [
+        {
+          "schema_version": 0,
+          "attributes": {
+            "id": "2",
+            "name": "data"
+          }
+        }
+      ]
+    }
+  ]
+}
+`
+
+func TestReadExistingState(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		es, err := provider.ReadExistingState(strings.NewReader(existingState))
+		require.NoError(t, err)
+
+		er, ok := es.Get("aws_iam_user", "1")
+		require.True(t, ok)
+		assert.Equal(t, "aws_iam_user.admin", er.Address())
+
+		// The data sources are ignored
+		_, ok = es.Get("aws_iam_user", "2")
+		assert.False(t, ok)
+
+		assert.Equal(t, []string{"aws_iam_user"}, es.Types())
+		assert.Len(t, es.Resources("aws_iam_user"), 1)
+	})
+	t.Run("ErrorInvalid", func(t *testing.T) {
+		_, err := provider.ReadExistingState(strings.NewReader("{invalid"))
+		assert.Error(t, err)
+	})
+}
+
+const managedState = `{
+  "version": 4,
+  "terraform_version": "1.5.7",
+  "serial": 1,
+  "lineage": "lineage",
+  "outputs": {},
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "aws_iam_user",
+      "name": "devs",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [
+        {
+          "index_key": 0,
+          "schema_version": 0,
+          "attributes": {
+            "id": "3",
+            "name": "dev1"
+          }
+        },
+        {
+          "index_key": 1,
+          "schema_version": 0,
+          "attributes": {
+            "id": "4",
+            "name": "dev2"
+          }
+        }
+      ]
+    }
+  ]
+}
+`
+
+func TestReadManagedState(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		ms, err := provider.ReadManagedState(strings.NewReader(managedState))
+		require.NoError(t, err)
+
+		// The instances with count are also read
+		_, ok := ms.Get("aws_iam_user", "3")
+		assert.True(t, ok)
+		_, ok = ms.Get("aws_iam_user", "4")
+		assert.True(t, ok)
+
+		es, err := provider.ReadExistingState(strings.NewReader(managedState))
+		require.NoError(t, err)
+		assert.Empty(t, es.Types())
+	})
+	t.Run("SuccessWithMerge", func(t *testing.T) {
+		ms, err := provider.ReadManagedState(strings.NewReader(managedState))
+		require.NoError(t, err)
+
+		es, err := provider.ReadManagedState(strings.NewReader(existingState))
+		require.NoError(t, err)
+
+		all := provider.NewExistingState()
+		all.Merge(ms)
+		all.Merge(es)
+
+		assert.Equal(t, []string{"aws_iam_user"}, all.Types())
+		assert.Len(t, all.Resources("aws_iam_user"), 3)
+	})
+}
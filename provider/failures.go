@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"sync"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/event"
+	"github.com/pkg/errors"
+)
+
+// failures keeps track of the resources that failed
+// to be imported and checks them against the limits
+// of the ImportOptions.
+// It's concurrently safe
+type failures struct {
+	strict   bool
+	maxCount int
+	maxRatio float64
+
+	total int
+	list  []failure
+	mu    sync.Mutex
+}
+
+// failure is a resource that failed to be imported
+type failure struct {
+	resourceType string
+	id           string
+	err          error
+}
+
+func newFailures(opts ImportOptions) *failures {
+	return &failures{
+		strict:   opts.Strict,
+		maxCount: opts.MaxErrors,
+		maxRatio: opts.MaxErrorRatio,
+	}
+}
+
+// addTotal adds n to the total of resources
+// that are tried to be imported
+func (fs *failures) addTotal(n int) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	fs.total += n
+}
+
+// add adds the resource of type t and id that failed with err, and returns
+// an error if the import can not continue because of the limits
+func (fs *failures) add(t, id string, err error) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	fs.list = append(fs.list, failure{resourceType: t, id: id, err: err})
+
+	if fs.strict {
+		return errors.Wrapf(err, "strict mode: failed to import resource %s with id %q", t, id)
+	}
+
+	if fs.maxCount > 0 && len(fs.list) > fs.maxCount {
+		return errors.Wrapf(errcode.ErrImportTooManyFailures, "%d resources failed, the maximum is %d", len(fs.list), fs.maxCount)
+	}
+
+	return nil
+}
+
+// events returns the failures as event.Failure
+// to be sent on the event.TypeImportFailures
+func (fs *failures) events() []event.Failure {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	efs := make([]event.Failure, 0, len(fs.list))
+	for _, f := range fs.list {
+		efs = append(efs, event.Failure{
+			ResourceType: f.resourceType,
+			ID:           f.id,
+			Reason:       failureReason(f.err),
+			Error:        f.err.Error(),
+		})
+	}
+	return efs
+}
+
+// check returns an error if the ratio of failed
+// resources is higher than the maximum
+func (fs *failures) check() error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if fs.maxRatio <= 0 || fs.total == 0 {
+		return nil
+	}
+
+	ratio := float64(len(fs.list)) / float64(fs.total)
+	if ratio > fs.maxRatio {
+		return errors.Wrapf(errcode.ErrImportTooManyFailures, "%d of %d resources failed, the ratio %.2f is higher than the maximum %.2f", len(fs.list), fs.total, ratio, fs.maxRatio)
+	}
+
+	return nil
+}
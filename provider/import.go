@@ -3,22 +3,107 @@ package provider
 import (
 	"context"
 	"fmt"
-	"io"
+	"sync"
+	"time"
 
 	kitlog "github.com/go-kit/kit/log"
 
 	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/event"
 	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/graph"
 	"github.com/cycloidio/terracognita/interpolator"
+	"github.com/cycloidio/terracognita/journal"
 	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/report"
 	"github.com/cycloidio/terracognita/util"
 	"github.com/cycloidio/terracognita/writer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/pkg/errors"
 )
 
+// ImportOptions are the options that change the
+// behavior of the Import
+type ImportOptions struct {
+	// Parallelism is the number of resource types that
+	// are listed, and of resources that are read from
+	// the same type, at the same time. If it's lower
+	// than 1 it'll be done sequentially
+	Parallelism int
+
+	// Journal is where each imported resource is recorded,
+	// if it already has entries those are loaded instead of
+	// being imported again
+	Journal *journal.Journal
+
+	// Report is where the outcome of each resource
+	// found is recorded
+	Report *report.Report
+
+	// DryRun only lists the resources and sends an event for
+	// each one with its type and ID, without importing them.
+	// The names are only known once read so they are not sent.
+	// The Journal, Report and writers are not used
+	DryRun bool
+
+	// Strict stops the import on the first
+	// resource that fails to be imported
+	Strict bool
+
+	// MaxErrors is the maximum number of resources that can fail
+	// to be imported before the import is stopped, if it's 0
+	// there is no maximum
+	MaxErrors int
+
+	// MaxErrorRatio is the maximum ratio, from 0 to 1, of resources
+	// that can fail to be imported. The types that fail to be listed
+	// count as one failed resource. It's checked at the end of the
+	// import and if it's 0 there is no maximum
+	MaxErrorRatio float64
+
+	// ResourceTimeout is the maximum time that the import of
+	// one resource can take, after it the resource is considered
+	// failed and the import continues. If it's 0 there is no maximum
+	ResourceTimeout time.Duration
+
+	// MaxRetries is the number of times that the listing and the read
+	// of the resources are retried when they fail with an error that
+	// the Provider classifies as retryable (see Retrier), with an
+	// exponential backoff of at most RetryMaxWait between them.
+	// If it's 0 they are not retried
+	MaxRetries   int
+	RetryMaxWait time.Duration
+
+	// Graph is where the imported resources, and the
+	// references between them, are added if not nil
+	Graph *graph.Graph
+
+	// Existing is the TFState of a previous import, the resources
+	// on it are not imported again but written as they are on it
+	// so they keep the same address
+	Existing *ExistingState
+
+	// Managed are the resources already managed by other
+	// Terraform configurations, they are not imported
+	Managed *ExistingState
+
+	// Interpolator is where the resources imported are added to be interpolated,
+	// if nil a new one is used. It can be shared between the imports of
+	// different providers that write to the same writers
+	Interpolator *interpolator.Interpolator
+
+	// SkipSync does not interpolate nor Sync the writers and the Graph so
+	// more providers can be imported to them. Once all are imported it
+	// has to be done with Sync and the shared Interpolator
+	SkipSync bool
+}
+
 // Import imports from the Provider p all the resources filtered by f and writes
-// the result to the hcl or tfstate if those are not nil
-func Import(ctx context.Context, p Provider, hcl, tfstate writer.Writer, f *filter.Filter, out io.Writer) error {
+// the result to the hcl or tfstate if those are not nil.
+// The progress of the import is sent as Events to the h if not nil.
+// If the ctx is done the import stops, the resources already imported are
+// written and the ctx error is returned
+func Import(ctx context.Context, p Provider, hcl, tfstate writer.Writer, f *filter.Filter, opts ImportOptions, h event.Handler) (rerr error) {
 	logger := log.Get()
 	logger = kitlog.With(logger, "func", "provider.Import")
 
@@ -26,6 +111,18 @@ func Import(ctx context.Context, p Provider, hcl, tfstate writer.Writer, f *filt
 		return err
 	}
 
+	// The patterns are expanded before validating
+	// the types of the Include and Exclude
+	if f.HasPatterns() {
+		if err := f.Expand(p.ResourceTypes()); err != nil {
+			return err
+		}
+	}
+
+	if opts.MaxErrorRatio < 0 || opts.MaxErrorRatio > 1 {
+		return errors.Errorf("invalid MaxErrorRatio %v, it has to be between 0 and 1", opts.MaxErrorRatio)
+	}
+
 	var (
 		err          error
 		types        []string
@@ -63,129 +160,252 @@ func Import(ctx context.Context, p Provider, hcl, tfstate writer.Writer, f *filt
 		}
 	}
 
-	fmt.Fprintf(out, "Importing with filters: %s", f)
+	if opts.Parallelism < 1 {
+		opts.Parallelism = 1
+	}
+
+	var (
+		total int
+		rc    = recorder{rep: opts.Report, h: h}
+		fails = newFailures(opts)
+	)
+
+	rc.emit(event.Event{Type: event.TypeImportStarted, Message: f.String(), DryRun: opts.DryRun})
 	logger.Log("filters", f.String())
 
-	interpolation := interpolator.New(p.String())
+	defer func() {
+		if efs := fails.events(); len(efs) != 0 {
+			rc.emit(event.Event{Type: event.TypeImportFailures, Total: total, Failures: efs})
+		}
 
-	for _, t := range types {
-		logger := kitlog.With(logger, "resource", t)
+		e := event.Event{Type: event.TypeImportDone, Total: total, DryRun: opts.DryRun}
+		if rerr != nil {
+			e.Error = rerr.Error()
+		}
+		rc.emit(e)
+	}()
+
+	interpolation := opts.Interpolator
+	if interpolation == nil {
+		interpolation = interpolator.New(p.String())
+	}
 
-		if f.IsExcluded(t) {
-			logger.Log("msg", "excluded")
+	importTypes := make([]string, 0, len(types))
+	for _, t := range types {
+		if f.IsExcluded(t) || !f.IsIncluded(t) {
+			logger.Log("resource", t, "msg", "excluded")
 			continue
 		}
+		importTypes = append(importTypes, t)
+	}
+
+	// The listing of the types is done ahead on the background
+	// and then consumed on the same order of the types so
+	// the output is always the same
+	done := make(chan struct{})
+	defer close(done)
+	retry := RetryPolicy(p, opts.MaxRetries, opts.RetryMaxWait)
+	lists := listResources(ctx, p, importTypes, typesWithIDs, f, opts.Parallelism, opts.Journal, retry, done)
+
+	// processed is the number of types that were processed, the
+	// rest are recorded as not read if the import is stopped
+	var processed int
+	for ti, t := range importTypes {
+		logger := kitlog.With(logger, "resource", t)
 
 		logger.Log("msg", "fetching the list of resources")
+		rc.emit(event.Event{Type: event.TypeResourceTypeStarted, ResourceType: t})
 
-		var resources []Resource
+		var lr listedResources
+		select {
+		case lr = <-lists[ti]:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
 
-		if typesWithIDs != nil {
-			for _, ID := range typesWithIDs[t] {
-				resources = append(resources, NewResource(ID, t, p))
-			}
-		} else {
-			resources, err = p.Resources(ctx, t, f)
-			if err != nil {
-				// we filter the error: if it's an error provider side, we continue
-				// the import but we print the error.
-				if errors.Is(err, errcode.ErrProviderAPI) {
-					logger.Log("msg", fmt.Sprintf("unable to import resource %s: %s\n", t, err.Error()))
-				} else {
-					return errors.WithStack(err)
+		if lr.err != nil {
+			// we filter the error: if it's an error provider side, we continue
+			// the import but we print the error.
+			if errors.Is(lr.err, errcode.ErrProviderAPI) {
+				logger.Log("msg", fmt.Sprintf("unable to import resource %s: %s\n", t, lr.err.Error()))
+				rc.failed(t, "", lr.err)
+				// The type counts as one resource so the
+				// failure is also on the total of the ratio
+				fails.addTotal(1)
+				if err := fails.add(t, "", lr.err); err != nil {
+					return err
 				}
+			} else {
+				return errors.WithStack(lr.err)
 			}
 		}
 
-		resourceLen := len(resources)
-		for i, re := range resources {
-			logger := kitlog.With(logger, "id", re.ID(), "total", resourceLen, "current", i+1)
-			fmt.Fprintf(out, "\rImporting %s [%d/%d]", t, i+1, resourceLen)
+		ids := make([]string, len(lr.resources))
+		for i, re := range lr.resources {
+			ids[i] = re.ID()
+		}
+
+		// The ones that do not match the patterns are
+		// skipped before importing them, the names that
+		// are not known yet are checked once read
+		if f.IDPattern != "" || f.NamePattern != "" {
+			lr.resources, ids = skipUnmatched(f, t, p, lr.resources, ids, rc)
+		}
+
+		if ms := opts.Managed; ms != nil {
+			lr.resources, ids = skipManaged(ms, t, lr.resources, ids, rc)
+		}
+
+		for _, id := range ids {
+			rc.emit(event.Event{Type: event.TypeResourceDiscovered, ResourceType: t, ID: id})
+		}
+
+		// The names are only known once read, so on a dry
+		// run the resources are only listed by type and ID
+		if opts.DryRun {
+			total += len(lr.resources)
+			processed++
+			continue
+		}
 
-			logger.Log("msg", "reading from TF")
-			res, err := re.ImportState()
+		resources := lr.resources
+		if j := opts.Journal; j != nil {
+			err = loadResources(j, t, p, hcl, tfstate, interpolation, opts.Graph, rc)
 			if err != nil {
 				return err
 			}
 
-			// If the InstanceState is nil after the ImportState it
-			// means that nothing was imported (potentially is not even Importable)
-			// so we have to skip the resource
-			if re.InstanceState() == nil {
-				continue
+			// The resources already on the Journal are not imported again
+			resources = make([]Resource, 0, len(lr.resources))
+			rids := make([]string, 0, len(ids))
+			for i, re := range lr.resources {
+				if j.Has(t, ids[i]) {
+					continue
+				}
+				resources = append(resources, re)
+				rids = append(rids, ids[i])
 			}
+			ids = rids
+		}
 
-			// In case there is more than one State to import
-			// we create a new slice with those elements and iterate
-			// over it
-			for _, r := range append([]Resource{re}, res...) {
-				err = util.RetryDefault(func() error { return r.Read(f) })
-				if err != nil {
-					// Errors are ignored. If a resource is invalid we assume it can be skipped, it can be related to inconsistencies in deployed resources.
-					// So instead of failing and stopping execution we ignore them and continue (we log them if -v is specified)
+		if es := opts.Existing; es != nil {
+			resources, ids, err = loadExisting(es, t, resources, ids, p, hcl, tfstate, interpolation, opts.Graph, rc)
+			if err != nil {
+				return err
+			}
+		}
+		resourceLen := len(resources)
+		total += resourceLen
+		fails.addTotal(resourceLen)
 
-					logger.Log("error", err)
+		reads, err := readResources(ctx, t, resources, ids, f, opts, retry, fails, rc, logger)
+		if err != nil {
+			return err
+		}
 
-					continue
+		// The Resources are written sequentially and on the same order
+		// they were listed so the names given to them are always the same
+		for i, re := range resources {
+			for _, r := range reads[i] {
+				state, err := writeResource(t, re, r, hcl, tfstate, interpolation, logger)
+				if err != nil {
+					return err
 				}
 
-				if hcl != nil {
-					logger.Log("msg", "calculating HCL")
-					err = r.HCL(hcl)
-					if err != nil {
-						return errors.Wrapf(err, "error while calculating the Config of resource %q", t)
-					}
+				if opts.Report != nil || h != nil {
+					rc.imported(r.Type(), r.ID(), fmt.Sprintf("%s.%s", r.Type(), r.Name()))
 				}
 
-				if tfstate != nil {
-					logger.Log("msg", "calculating TFState")
-					err = r.State(tfstate)
-					if err != nil {
-						return errors.Wrapf(err, "error while calculating the satate of resource %q", t)
-					}
+				if opts.Graph != nil && state != nil {
+					addGraphNode(opts.Graph, p, r.Type(), r.Name(), state)
 				}
-				state := r.InstanceState()
+			}
+		}
+		rc.emit(event.Event{Type: event.TypeResourceTypeDone, ResourceType: t, Total: resourceLen})
+		logger.Log("msg", "importing done")
+		processed++
 
-				if state != nil {
-					attributes, err := re.AttributesReference()
-					if err != nil {
-						return errors.Wrapf(err, "unable to fetch attributes of resource")
-					}
-					attrs := make(map[string]string)
-					for _, attribute := range attributes {
-						value, ok := state.Attributes[attribute]
-						if !ok || len(value) == 0 {
-							continue
-						}
-						attrs[attribute] = value
-					}
-					interpolation.AddResourceAttributes(fmt.Sprintf("%s.%s", r.Type(), r.Name()), attrs)
-				}
+		// The import was stopped so the rest of resources of
+		// the type were not read, but the ones that were have
+		// to be written
+		if ctx.Err() != nil {
+			break
+		}
+
+		// If the list failed the type is not done, so
+		// the next time it'll be tried again
+		if opts.Journal != nil && lr.err == nil && !opts.Journal.IsDone(t) {
+			if err = opts.Journal.Done(t); err != nil {
+				return err
 			}
 		}
-		if resourceLen > 0 {
-			fmt.Fprintf(out, "\rImporting %s [%d/%d] Done!\n", t, resourceLen, resourceLen)
+	}
+
+	if ctx.Err() != nil {
+		logger.Log("msg", "import stopped", "error", ctx.Err())
+		for _, t := range importTypes[processed:] {
+			rc.notRead(t, "")
+		}
+	}
+
+	if opts.DryRun {
+		logger.Log("msg", "dry run done", "found", total)
+		return errors.WithStack(ctx.Err())
+	}
+
+	// If it was stopped not all the resources were
+	// tried so the ratio is not checked
+	if ctx.Err() == nil {
+		if err = fails.check(); err != nil {
+			return err
 		}
-		logger.Log("msg", "importing done")
+	}
+
+	if !opts.SkipSync {
+		if err = Sync(hcl, tfstate, opts.Graph, interpolation, h); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Err() != nil {
+		return errors.Wrap(ctx.Err(), "the import was stopped, only the resources imported until then were written")
+	}
+
+	return nil
+}
+
+// Sync interpolates the resources of the g, hcl and tfstate, if not nil, with the
+// interpolation and writes the hcl and tfstate. The progress is sent as Events to the h
+// if not nil. It's done by Import unless the ImportOptions.SkipSync is set
+func Sync(hcl, tfstate writer.Writer, g *graph.Graph, interpolation *interpolator.Interpolator, h event.Handler) error {
+	logger := log.Get()
+	logger = kitlog.With(logger, "func", "provider.Sync")
+
+	rc := recorder{h: h}
+
+	if g != nil {
+		g.Interpolate(interpolation)
 	}
 
 	if hcl != nil {
 		hcl.Interpolate(interpolation)
-		fmt.Fprintf(out, "\rWriting HCL ...")
+		rc.emit(event.Event{Type: event.TypeWriterSyncStarted, Writer: event.WriterHCL})
 		logger.Log("msg", "writing the HCL")
 
-		err = hcl.Sync()
+		err := hcl.Sync()
 		if err != nil {
 			return errors.Wrapf(err, "error while Sync Config")
 		}
 
-		fmt.Fprintf(out, "\rWriting HCL Done!\n")
+		rc.emit(event.Event{Type: event.TypeWriterSyncDone, Writer: event.WriterHCL})
 		logger.Log("msg", "writing the HCL done")
 	}
 
 	if tfstate != nil {
 		tfstate.Interpolate(interpolation)
-		fmt.Fprintf(out, "\rWriting TFState ...")
+		rc.emit(event.Event{Type: event.TypeWriterSyncStarted, Writer: event.WriterTFState})
 		logger.Log("msg", "writing the TFState")
 
 		err := tfstate.Sync()
@@ -193,9 +413,455 @@ func Import(ctx context.Context, p Provider, hcl, tfstate writer.Writer, f *filt
 			return errors.Wrapf(err, "error while Sync State")
 		}
 
-		fmt.Fprintf(out, "\rWriting TFState Done!\n")
+		rc.emit(event.Event{Type: event.TypeWriterSyncDone, Writer: event.WriterTFState})
 		logger.Log("msg", "writing the TFState done")
 	}
 
 	return nil
 }
+
+// listedResources is the result of listing
+// the Resources of one type
+type listedResources struct {
+	resources []Resource
+	err       error
+}
+
+// listResources lists the Resources of all the types on the background
+// with at most parallelism types at the same time. The result of each type
+// is sent to the channel on the same position than the type, and when done
+// is closed no more types will be listed.
+// The types already done on the j are not listed, and
+// the listing is retried with the retry policy
+func listResources(ctx context.Context, p Provider, types []string, typesWithIDs map[string][]string, f *filter.Filter, parallelism int, j *journal.Journal, retry util.RetryPolicy, done <-chan struct{}) []chan listedResources {
+	lists := make([]chan listedResources, len(types))
+	for i := range lists {
+		lists[i] = make(chan listedResources, 1)
+	}
+
+	go func() {
+		sem := make(chan struct{}, parallelism)
+		for i, t := range types {
+			if j != nil && j.IsDone(t) {
+				lists[i] <- listedResources{}
+				continue
+			}
+
+			select {
+			case sem <- struct{}{}:
+			case <-done:
+				return
+			}
+
+			go func(i int, t string) {
+				defer func() { <-sem }()
+
+				var lr listedResources
+				if typesWithIDs != nil {
+					for _, ID := range typesWithIDs[t] {
+						lr.resources = append(lr.resources, NewResource(ID, t, p))
+					}
+				} else {
+					lr.err = util.RetryContext(ctx, func() (err error) {
+						lr.resources, err = p.Resources(ctx, t, f)
+						return err
+					}, retry)
+				}
+				lists[i] <- lr
+			}(i, t)
+		}
+	}()
+
+	return lists
+}
+
+// readResources imports and reads the resources of type t, with the ids, with at most
+// opts.Parallelism of them at the same time. It returns, on the same position of each
+// resource, the list of Resources that were read from it, which can be more than one if
+// the ImportState returned more.
+// Each resource is recorded on the opts.Journal, if not nil, as soon as it's read
+// unless it failed or was not read, so it's tried again if resumed.
+// If any of them fails with a fatal error, or the fails limits are reached, no
+// more resources are read and the error is returned. If the ctx is done no more resources are
+// read, and are recorded as not read, but the ones already read are returned.
+// The resources that are not imported are recorded on the rc
+func readResources(ctx context.Context, t string, resources []Resource, ids []string, f *filter.Filter, opts ImportOptions, retry util.RetryPolicy, fails *failures, rc recorder, logger kitlog.Logger) ([][]Resource, error) {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		current int
+		stopped bool
+
+		sem   = make(chan struct{}, opts.Parallelism)
+		reads = make([][]Resource, len(resources))
+		errs  = make([]error, len(resources))
+	)
+
+	for i, re := range resources {
+		sem <- struct{}{}
+
+		mu.Lock()
+		if stopped || ctx.Err() != nil {
+			mu.Unlock()
+			<-sem
+			if ctx.Err() != nil {
+				for ; i < len(resources); i++ {
+					rc.notRead(t, ids[i])
+				}
+			}
+			break
+		}
+		current++
+		rc.emit(event.Event{Type: event.TypeResourceStarted, ResourceType: t, ID: ids[i], Current: current, Total: len(resources)})
+		mu.Unlock()
+
+		wg.Add(1)
+		go func(i int, re Resource) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			logger := kitlog.With(logger, "id", ids[i], "total", len(resources), "current", i+1)
+			var failed bool
+			reads[i], failed, errs[i] = readResource(ctx, t, ids[i], re, f, opts, retry, fails, rc, logger)
+			if errs[i] == nil && !failed && opts.Journal != nil {
+				errs[i] = opts.Journal.Write(journalEntry(t, ids[i], reads[i]))
+			}
+			if errs[i] != nil {
+				mu.Lock()
+				stopped = true
+				mu.Unlock()
+			}
+		}(i, re)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return reads, nil
+}
+
+// journalEntry returns the journal.Entry of the resource of type t and id with
+// the reads from it. The names of the reads are not known until they are
+// written, so they are given to them once loaded from the journal
+func journalEntry(t, id string, reads []Resource) journal.Entry {
+	e := journal.Entry{Type: t, ID: id}
+	for _, r := range reads {
+		state := r.InstanceState()
+		if state == nil {
+			continue
+		}
+		e.Resources = append(e.Resources, journal.Resource{
+			Type:       r.Type(),
+			ID:         state.ID,
+			Attributes: state.Attributes,
+		})
+	}
+	return e
+}
+
+// readResource imports the state of re, of type t and with the id, and reads it
+// and all the other Resources the import may have returned.
+// The Resources that fail to be imported or read are ignored, recorded on the rc,
+// and if the error is not of the skipped class added to the fails, in which case
+// it returns that it failed. The Resources that take more than the opts.ResourceTimeout
+// fail with a timeout error, and if the ctx is done it's recorded as not read and it
+// returns that it failed without error
+func readResource(ctx context.Context, t, id string, re Resource, f *filter.Filter, opts ImportOptions, retry util.RetryPolicy, fails *failures, rc recorder, logger kitlog.Logger) ([]Resource, bool, error) {
+	rctx := ctx
+	if opts.ResourceTimeout > 0 {
+		var cancel context.CancelFunc
+		rctx, cancel = context.WithTimeout(ctx, opts.ResourceTimeout)
+		defer cancel()
+	}
+
+	logger.Log("msg", "reading from TF")
+	var res []Resource
+	err := runWithContext(rctx, func() error {
+		return util.RetryContext(rctx, func() (err error) {
+			res, err = re.ImportState(rctx)
+			return err
+		}, retry)
+	})
+	if ctx.Err() != nil {
+		rc.notRead(t, id)
+		return nil, true, nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = errors.Wrapf(err, "timeout of %s importing resource %s with id %q", opts.ResourceTimeout, t, id)
+	}
+	if err != nil {
+		logger.Log("error", err)
+		failed, ferr := addFailure(t, id, err, fails, rc)
+		return nil, failed, ferr
+	}
+
+	// If the InstanceState is nil after the ImportState it
+	// means that nothing was imported (potentially is not even Importable)
+	// so we have to skip the resource
+	if re.InstanceState() == nil {
+		rc.notImportable(t, id)
+		return nil, false, nil
+	}
+
+	// In case there is more than one State to import
+	// we create a new slice with those elements and iterate
+	// over it
+	var failed bool
+	reads := make([]Resource, 0, len(res)+1)
+	for i, r := range append([]Resource{re}, res...) {
+		err = runWithContext(rctx, func() error {
+			return util.RetryContext(rctx, func() error { return r.Read(rctx, f) }, retry)
+		})
+		if ctx.Err() != nil {
+			rc.notRead(t, id)
+			return nil, true, nil
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = errors.Wrapf(err, "timeout of %s reading resource %s with id %q", opts.ResourceTimeout, t, id)
+		}
+		// The Where can only be checked once read as
+		// it's an expression of the attributes
+		if err == nil && f.Where != nil && !f.MatchWhere(attributes(r)) {
+			err = errors.Wrapf(errcode.ErrProviderResourceDoNotMatchWhere, "%s", f.Where)
+		}
+		if err != nil {
+			// Errors are ignored. If a resource is invalid we assume it can be skipped, it can be related to inconsistencies in deployed resources.
+			// So instead of failing and stopping execution we ignore them and continue (we log them if -v is specified)
+			// unless they are fatal or the fails limits are reached
+
+			logger.Log("error", err)
+
+			rt, rid := t, id
+			if i != 0 {
+				rt, rid = r.Type(), r.ID()
+			}
+
+			rfailed, ferr := addFailure(rt, rid, err, fails, rc)
+			if ferr != nil {
+				return nil, true, ferr
+			}
+			failed = failed || rfailed
+
+			continue
+		}
+		reads = append(reads, r)
+	}
+
+	return reads, failed, nil
+}
+
+// addFailure records the resource of type t and id that failed with the err on the rc
+// and, if the err is not of the skipped class, adds it to the fails. It returns if it
+// failed and the error if the import can not continue, because the err is fatal or
+// the fails limits are reached
+func addFailure(t, id string, err error, fails *failures, rc recorder) (bool, error) {
+	rc.failed(t, id, err)
+
+	switch errcode.Classify(err) {
+	case errcode.ClassFatal:
+		return true, err
+	case errcode.ClassRecoverable:
+		return true, fails.add(t, id, err)
+	default:
+		return false, nil
+	}
+}
+
+// attributes returns the attributes of the
+// state of the r, nil if it has none
+func attributes(r Resource) map[string]string {
+	if is := r.InstanceState(); is != nil {
+		return is.Attributes
+	}
+	return nil
+}
+
+// runWithContext runs fn and waits for it to finish unless the ctx is
+// done before, in which case it returns the ctx error and fn is left
+// running on the background as it can not be stopped
+func runWithContext(ctx context.Context, fn func() error) error {
+	errc := make(chan error, 1)
+	go func() { errc <- fn() }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// writeResource writes the r, which was read from re of type t, to the hcl and
+// tfstate and adds its attributes to the interpolation. It returns the state
+// of r, which can be nil if it has none
+func writeResource(t string, re, r Resource, hcl, tfstate writer.Writer, interpolation *interpolator.Interpolator, logger kitlog.Logger) (*terraform.InstanceState, error) {
+	if hcl != nil {
+		logger.Log("msg", "calculating HCL")
+		err := r.HCL(hcl)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while calculating the Config of resource %q", t)
+		}
+	}
+
+	if tfstate != nil {
+		logger.Log("msg", "calculating TFState")
+		err := r.State(tfstate)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while calculating the satate of resource %q", t)
+		}
+	}
+	state := r.InstanceState()
+
+	if state != nil {
+		attributes, err := re.AttributesReference()
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to fetch attributes of resource")
+		}
+		attrs := make(map[string]string)
+		for _, attribute := range attributes {
+			value, ok := state.Attributes[attribute]
+			if !ok || len(value) == 0 {
+				continue
+			}
+			attrs[attribute] = value
+		}
+		interpolation.AddResourceAttributes(fmt.Sprintf("%s.%s", r.Type(), r.Name()), attrs)
+	}
+
+	return state, nil
+}
+
+// loadResources writes all the Resources of the type t already on the j
+// as if they were imported on this same run, adds them to the g if not nil
+// and records them on the rc
+func loadResources(j *journal.Journal, t string, p Provider, hcl, tfstate writer.Writer, interpolation *interpolator.Interpolator, g *graph.Graph, rc recorder) error {
+	logger := log.Get()
+	logger = kitlog.With(logger, "func", "provider.loadResources", "resource", t)
+
+	for _, e := range j.Entries(t) {
+		re := NewResource(e.ID, e.Type, p)
+		for _, jr := range e.Resources {
+			r, err := LoadResource(jr.ID, jr.Type, jr.Name, jr.Attributes, p)
+			if err != nil {
+				return err
+			}
+
+			logger.Log("msg", "loading from the journal", "id", jr.ID)
+			state, err := writeResource(t, re, r, hcl, tfstate, interpolation, logger)
+			if err != nil {
+				return err
+			}
+
+			// If the name was not recorded it's
+			// given one once written
+			if g != nil && state != nil {
+				addGraphNode(g, p, jr.Type, r.Name(), state)
+			}
+
+			rc.imported(jr.Type, jr.ID, fmt.Sprintf("%s.%s", jr.Type, r.Name()))
+		}
+	}
+
+	return nil
+}
+
+// loadExisting writes the resources, of type t and with the ids, that are on the es
+// as they are on it, adds them to the g if not nil and records them on the rc.
+// It returns the resources, and its ids, that are not on the es
+func loadExisting(es *ExistingState, t string, resources []Resource, ids []string, p Provider, hcl, tfstate writer.Writer, interpolation *interpolator.Interpolator, g *graph.Graph, rc recorder) ([]Resource, []string, error) {
+	logger := log.Get()
+	logger = kitlog.With(logger, "func", "provider.loadExisting", "resource", t)
+
+	nresources := make([]Resource, 0, len(resources))
+	nids := make([]string, 0, len(ids))
+	for i, re := range resources {
+		er, ok := es.Get(t, ids[i])
+		if !ok {
+			nresources = append(nresources, re)
+			nids = append(nids, ids[i])
+			continue
+		}
+
+		r, err := loadExistingResource(er, p)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		logger.Log("msg", "loading from the existing state", "id", er.ID)
+		state, err := writeResource(t, re, r, hcl, tfstate, interpolation, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if g != nil && state != nil {
+			addGraphNode(g, p, er.Type, er.Name, state)
+		}
+
+		rc.existing(er.Type, er.ID, er.Address())
+	}
+
+	return nresources, nids, nil
+}
+
+// skipManaged records the resources, of type t and with the ids, that are
+// on the ms as managed and returns the ones, and its ids, that are not
+func skipManaged(ms *ExistingState, t string, resources []Resource, ids []string, rc recorder) ([]Resource, []string) {
+	nresources := make([]Resource, 0, len(resources))
+	nids := make([]string, 0, len(ids))
+	for i, re := range resources {
+		if _, ok := ms.Get(t, ids[i]); ok {
+			rc.managed(t, ids[i])
+			continue
+		}
+		nresources = append(nresources, re)
+		nids = append(nids, ids[i])
+	}
+
+	return nresources, nids
+}
+
+// skipUnmatched records the resources, of type t and with the ids, that do not
+// match the IDPattern or the NamePattern, if the listing already set it on the
+// data, of the f as filtered and returns the ones, and its ids, that do
+func skipUnmatched(f *filter.Filter, t string, p Provider, resources []Resource, ids []string, rc recorder) ([]Resource, []string) {
+	nresources := make([]Resource, 0, len(resources))
+	nids := make([]string, 0, len(ids))
+	for i, re := range resources {
+		if !f.MatchID(ids[i]) {
+			rc.failed(t, ids[i], errors.Wrapf(errcode.ErrProviderResourceDoNotMatchPattern, "the ID %q", ids[i]))
+			continue
+		}
+		if f.NamePattern != "" {
+			if name, ok := ResourceName(p, t, re.Data()); ok && !f.MatchName(name) {
+				rc.failed(t, ids[i], errors.Wrapf(errcode.ErrProviderResourceDoNotMatchPattern, "the name %q", name))
+				continue
+			}
+		}
+		nresources = append(nresources, re)
+		nids = append(nids, ids[i])
+	}
+
+	return nresources, nids
+}
+
+// addGraphNode adds to the g the resource of type t
+// and name with the state, from the Provider p
+func addGraphNode(g *graph.Graph, p Provider, t, name string, state *terraform.InstanceState) {
+	// The category is only informative so if
+	// the type has none it's left empty
+	category, _ := ResourceCategory(p.String(), t)
+
+	g.AddNode(graph.Node{
+		Address:  fmt.Sprintf("%s.%s", t, name),
+		Type:     t,
+		ID:       state.ID,
+		Category: category,
+	}, state.Attributes)
+}
@@ -1,23 +1,40 @@
 package provider_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
-	"io/ioutil"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/event"
 	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/graph"
 	"github.com/cycloidio/terracognita/interpolator"
+	"github.com/cycloidio/terracognita/journal"
 	"github.com/cycloidio/terracognita/mock"
 	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/report"
+	"github.com/cycloidio/terracognita/tag"
 	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform/tfdiags"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// retrierProvider is a Provider that implements
+// the provider.Retrier without retrying any error
+type retrierProvider struct {
+	*mock.Provider
+}
+
+func (p retrierProvider) Retryable(err error) (bool, time.Duration) { return false, 0 }
+
 func TestImport(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		var (
@@ -49,20 +66,20 @@ func TestImport(t *testing.T) {
 		iamUser1.EXPECT().ID().Return("3")
 		iamUser2.EXPECT().ID().Return("4")
 
-		instanceResource1.EXPECT().ImportState().Return(nil, nil)
-		instanceResource2.EXPECT().ImportState().Return(nil, nil)
-		iamUser1.EXPECT().ImportState().Return(nil, nil)
-		iamUser2.EXPECT().ImportState().Return(nil, nil)
+		instanceResource1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		instanceResource2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
 
 		instanceResource1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 		instanceResource2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 
-		instanceResource1.EXPECT().Read(f).Return(nil)
-		instanceResource2.EXPECT().Read(f).Return(nil)
-		iamUser1.EXPECT().Read(f).Return(nil)
-		iamUser2.EXPECT().Read(f).Return(nil)
+		instanceResource1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		instanceResource2.EXPECT().Read(gomock.Any(), f).Return(nil)
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		iamUser2.EXPECT().Read(gomock.Any(), f).Return(nil)
 
 		instanceResource1.EXPECT().HCL(hw).Return(nil)
 		instanceResource2.EXPECT().HCL(hw).Return(nil)
@@ -84,10 +101,10 @@ func TestImport(t *testing.T) {
 		sw.EXPECT().Sync().Return(nil)
 		sw.EXPECT().Interpolate(i)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, nil)
 		require.NoError(t, err)
 	})
-	t.Run("SuccessWithFilterInclude", func(t *testing.T) {
+	t.Run("SuccessWithParallelism", func(t *testing.T) {
 		var (
 			ctrl = gomock.NewController(t)
 			ctx  = context.Background()
@@ -95,58 +112,935 @@ func TestImport(t *testing.T) {
 			p                 = mock.NewProvider(ctrl)
 			hw                = mock.NewWriter(ctrl)
 			sw                = mock.NewWriter(ctrl)
+			i                 = interpolator.New("aws")
 			instanceResource1 = mock.NewResource(ctrl)
 			instanceResource2 = mock.NewResource(ctrl)
-			i                 = interpolator.New("aws")
+			iamUser1          = mock.NewResource(ctrl)
+			iamUser2          = mock.NewResource(ctrl)
 
-			f = &filter.Filter{
-				Include: []string{"aws_instance"},
-			}
+			f = &filter.Filter{}
 		)
 
 		defer ctrl.Finish()
 
 		p.EXPECT().String().Return("aws")
-		p.EXPECT().HasResourceType("aws_instance").Return(true)
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"})
+
 		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResource1, instanceResource2}, nil)
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2}, nil)
 
 		instanceResource1.EXPECT().ID().Return("1")
 		instanceResource2.EXPECT().ID().Return("2")
+		iamUser1.EXPECT().ID().Return("3")
+		iamUser2.EXPECT().ID().Return("4")
 
-		instanceResource1.EXPECT().ImportState().Return(nil, nil)
-		instanceResource2.EXPECT().ImportState().Return(nil, nil)
+		instanceResource1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		instanceResource2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
 
 		instanceResource1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 		instanceResource2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+
+		instanceResource1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		instanceResource2.EXPECT().Read(gomock.Any(), f).Return(nil)
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		iamUser2.EXPECT().Read(gomock.Any(), f).Return(nil)
+
+		// Even if read concurrently the resources
+		// have to be written always on the same order
+		gomock.InOrder(
+			instanceResource1.EXPECT().HCL(hw).Return(nil),
+			instanceResource2.EXPECT().HCL(hw).Return(nil),
+			iamUser1.EXPECT().HCL(hw).Return(nil),
+			iamUser2.EXPECT().HCL(hw).Return(nil),
+		)
+
+		instanceResource1.EXPECT().State(sw).Return(nil)
+		instanceResource2.EXPECT().State(sw).Return(nil)
+		iamUser1.EXPECT().State(sw).Return(nil)
+		iamUser2.EXPECT().State(sw).Return(nil)
+
+		instanceResource1.EXPECT().InstanceState().Return(nil)
+		instanceResource2.EXPECT().InstanceState().Return(nil)
+		iamUser1.EXPECT().InstanceState().Return(nil)
+		iamUser2.EXPECT().InstanceState().Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(i)
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(i)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Parallelism: 4}, nil)
+		require.NoError(t, err)
+	})
+	t.Run("SuccessWithRetriedDiagnostics", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p                 = retrierProvider{Provider: mock.NewProvider(ctrl)}
+			hw                = mock.NewWriter(ctrl)
+			sw                = mock.NewWriter(ctrl)
+			i                 = interpolator.New("aws")
+			instanceResource1 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+
+			// The original error is lost on the Diagnostics
+			// so it's only classified by the message
+			throttling = &provider.DiagnosticsError{
+				Diagnostics: tfdiags.Diagnostics{}.Append(errors.New("error reading EC2 Instance (1): ThrottlingException: Rate exceeded")),
+			}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance"})
+
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResource1}, nil)
+
+		instanceResource1.EXPECT().ID().Return("1")
+
+		gomock.InOrder(
+			instanceResource1.EXPECT().ImportState(gomock.Any()).Return(nil, errors.Wrap(throttling, "could not import resource")),
+			instanceResource1.EXPECT().ImportState(gomock.Any()).Return(nil, nil),
+		)
+
+		instanceResource1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+
+		gomock.InOrder(
+			instanceResource1.EXPECT().Read(gomock.Any(), f).Return(errors.Wrap(throttling, "could not read resource")),
+			instanceResource1.EXPECT().Read(gomock.Any(), f).Return(nil),
+		)
+
+		instanceResource1.EXPECT().HCL(hw).Return(nil)
+		instanceResource1.EXPECT().State(sw).Return(nil)
+		instanceResource1.EXPECT().InstanceState().Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(i)
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(i)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{MaxRetries: 1, RetryMaxWait: time.Millisecond}, nil)
+		require.NoError(t, err)
+	})
+	t.Run("SuccessWithJournal", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = mock.NewProvider(ctrl)
+			hw       = mock.NewWriter(ctrl)
+			sw       = mock.NewWriter(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			iamUser2 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+
+			jout bytes.Buffer
+			jin  = strings.NewReader(`{"type":"aws_instance","id":"1"}
+{"type":"aws_instance","done":true}
+{"type":"aws_iam_user","id":"3"}
+`)
+		)
+
+		defer ctrl.Finish()
+
+		j, err := journal.Load(jin, &jout)
+		require.NoError(t, err)
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"})
+
+		// The aws_instance is done so it's not listed again
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2}, nil)
+
+		// The iamUser1 is already on the journal so it's not imported again
+		iamUser1.EXPECT().ID().Return("3")
+		iamUser2.EXPECT().ID().Return("4").AnyTimes()
+
+		iamUser2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser2.EXPECT().Read(gomock.Any(), f).Return(nil)
+		iamUser2.EXPECT().HCL(hw).Return(nil)
+		iamUser2.EXPECT().State(sw).Return(nil)
+		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{ID: "4", Attributes: map[string]string{"id": "4"}}).Times(2)
+		iamUser2.EXPECT().AttributesReference().Return([]string{"id"}, nil)
+		iamUser2.EXPECT().Type().Return("aws_iam_user").AnyTimes()
+		iamUser2.EXPECT().Name().Return("user").AnyTimes()
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(gomock.Any())
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(gomock.Any())
+
+		err = provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Journal: j}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, `{"type":"aws_iam_user","id":"4","resources":[{"type":"aws_iam_user","id":"4","attributes":{"id":"4"}}]}
+{"type":"aws_iam_user","done":true}
+`, jout.String())
+	})
+	t.Run("SuccessWithJournalStoppedMidType", func(t *testing.T) {
+		var (
+			ctrl        = gomock.NewController(t)
+			ctx, cancel = context.WithCancel(context.Background())
+
+			p        = mock.NewProvider(ctrl)
+			hw       = mock.NewWriter(ctrl)
+			sw       = mock.NewWriter(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			iamUser2 = mock.NewResource(ctrl)
+			iamUser3 = mock.NewResource(ctrl)
+			iamUser4 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{
+				Include: []string{"aws_iam_user"},
+			}
+
+			jout bytes.Buffer
+			j    = journal.New(&jout)
+		)
+
+		defer ctrl.Finish()
+		defer cancel()
+
+		p.EXPECT().String().Return("aws").Times(2)
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true).Times(2)
+
+		// The first import is stopped while importing the iamUser2,
+		// the iamUser1 is already on the journal at that point
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2}, nil)
+
+		iamUser1.EXPECT().ID().Return("1")
+		iamUser2.EXPECT().ID().Return("2")
+
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		iamUser1.EXPECT().InstanceState().Return(nil).Times(2)
+		iamUser1.EXPECT().HCL(hw).Return(nil)
+		iamUser1.EXPECT().State(sw).Return(nil)
+
+		iamUser2.EXPECT().ImportState(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]provider.Resource, error) {
+			assert.Equal(t, `{"type":"aws_iam_user","id":"1"}
+`, jout.String())
+			cancel()
+			return nil, nil
+		})
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(gomock.Any())
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(gomock.Any())
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Journal: j}, nil)
+		assert.True(t, errors.Is(err, context.Canceled))
+
+		// It's resumed from the journal of the
+		// first import and only the iamUser2 is read
+		ctx = context.Background()
+		rj, err := journal.Load(strings.NewReader(jout.String()), &jout)
+		require.NoError(t, err)
+
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser3, iamUser4}, nil)
+
+		iamUser3.EXPECT().ID().Return("1")
+		iamUser4.EXPECT().ID().Return("2")
+
+		iamUser4.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser4.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser4.EXPECT().Read(gomock.Any(), f).Return(nil)
+		iamUser4.EXPECT().InstanceState().Return(nil).Times(2)
+		iamUser4.EXPECT().HCL(hw).Return(nil)
+		iamUser4.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(gomock.Any())
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(gomock.Any())
+
+		err = provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Journal: rj}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, `{"type":"aws_iam_user","id":"1"}
+{"type":"aws_iam_user","id":"2"}
+{"type":"aws_iam_user","done":true}
+`, jout.String())
+	})
+	t.Run("SuccessWithReport", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = mock.NewProvider(ctrl)
+			hw       = mock.NewWriter(ctrl)
+			sw       = mock.NewWriter(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			iamUser2 = mock.NewResource(ctrl)
+			iamUser3 = mock.NewResource(ctrl)
+			i        = interpolator.New("aws")
+
+			f   = &filter.Filter{}
+			rep = report.New("aws")
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"})
+
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return(nil, errors.Wrap(errcode.ErrProviderAPI, "access denied"))
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2, iamUser3}, nil)
+
+		iamUser1.EXPECT().ID().Return("1")
+		iamUser2.EXPECT().ID().Return("2").Times(2)
+		iamUser3.EXPECT().ID().Return("3")
+
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser3.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser3.EXPECT().InstanceState().Return(nil)
+
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(errcode.ErrProviderResourceDoNotMatchTag)
+		iamUser2.EXPECT().Read(gomock.Any(), f).Return(nil)
+
+		iamUser2.EXPECT().HCL(hw).Return(nil)
+		iamUser2.EXPECT().State(sw).Return(nil)
+		iamUser2.EXPECT().InstanceState().Return(nil)
+		iamUser2.EXPECT().Type().Return("aws_iam_user").Times(2)
+		iamUser2.EXPECT().Name().Return("user")
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(i)
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(i)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Report: rep}, nil)
+		require.NoError(t, err)
+
+		assert.False(t, rep.Complete())
+		assert.Equal(t, []report.Resource{
+			{Type: "aws_iam_user", ID: "1", Status: report.StatusFilteredByTag, Error: errcode.ErrProviderResourceDoNotMatchTag.Error()},
+			{Type: "aws_iam_user", ID: "2", Address: "aws_iam_user.user", Status: report.StatusImported},
+			{Type: "aws_iam_user", ID: "3", Status: report.StatusNotImportable},
+			{Type: "aws_instance", Status: report.StatusAPIError, Error: "access denied: " + errcode.ErrProviderAPI.Error()},
+		}, rep.Resources())
+	})
+	t.Run("SuccessWithGraph", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p      = mock.NewProvider(ctrl)
+			hw     = mock.NewWriter(ctrl)
+			vpc    = mock.NewResource(ctrl)
+			subnet = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+			g = graph.New()
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().String().Return("aws").Times(3)
+		p.EXPECT().ResourceTypes().Return([]string{"aws_vpc", "aws_subnet"})
+
+		p.EXPECT().Resources(ctx, "aws_vpc", f).Return([]provider.Resource{vpc}, nil)
+		p.EXPECT().Resources(ctx, "aws_subnet", f).Return([]provider.Resource{subnet}, nil)
+
+		vpc.EXPECT().ID().Return("vpc-1")
+		subnet.EXPECT().ID().Return("subnet-1")
+
+		vpc.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		subnet.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+
+		vpc.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		subnet.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+
+		vpc.EXPECT().Read(gomock.Any(), f).Return(nil)
+		subnet.EXPECT().Read(gomock.Any(), f).Return(nil)
+
+		vpc.EXPECT().HCL(hw).Return(nil)
+		subnet.EXPECT().HCL(hw).Return(nil)
+
+		vpc.EXPECT().InstanceState().Return(&terraform.InstanceState{ID: "vpc-1", Attributes: map[string]string{"id": "vpc-1"}})
+		subnet.EXPECT().InstanceState().Return(&terraform.InstanceState{ID: "subnet-1", Attributes: map[string]string{"id": "subnet-1", "vpc_id": "vpc-1"}})
+
+		vpc.EXPECT().AttributesReference().Return([]string{"id"}, nil)
+		subnet.EXPECT().AttributesReference().Return([]string{"id"}, nil)
+
+		vpc.EXPECT().Type().Return("aws_vpc").Times(2)
+		subnet.EXPECT().Type().Return("aws_subnet").Times(2)
+		vpc.EXPECT().Name().Return("main").Times(2)
+		subnet.EXPECT().Name().Return("front").Times(2)
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(gomock.Any())
+
+		err := provider.Import(ctx, p, hw, nil, f, provider.ImportOptions{Graph: g}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []graph.Node{
+			{Address: "aws_subnet.front", Type: "aws_subnet", ID: "subnet-1", Category: "vpc_virtual_private_cloud"},
+			{Address: "aws_vpc.main", Type: "aws_vpc", ID: "vpc-1", Category: "vpc_virtual_private_cloud"},
+		}, g.Nodes())
+		assert.Equal(t, []graph.Edge{
+			{From: "aws_subnet.front", To: "aws_vpc.main", Attribute: "vpc_id"},
+		}, g.Edges())
+	})
+	t.Run("SuccessWithExisting", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = mock.NewProvider(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			iamUser2 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{
+				Include: []string{"aws_iam_user"},
+			}
+			rep = report.New("aws")
+		)
+
+		defer ctrl.Finish()
+
+		es, err := provider.ReadExistingState(strings.NewReader(existingState))
+		require.NoError(t, err)
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
+		p.EXPECT().TFProvider().Return(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"aws_iam_user": &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		}).AnyTimes()
+
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2}, nil)
+
+		// The iamUser1 is on the existing state so it's not imported again
+		iamUser1.EXPECT().ID().Return("1")
+		iamUser1.EXPECT().AttributesReference().Return([]string{"id"}, nil)
+
+		iamUser2.EXPECT().ID().Return("2").Times(2)
+		iamUser2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser2.EXPECT().Read(gomock.Any(), f).Return(nil)
+		iamUser2.EXPECT().InstanceState().Return(nil)
+		iamUser2.EXPECT().Type().Return("aws_iam_user").Times(2)
+		iamUser2.EXPECT().Name().Return("user")
+
+		err = provider.Import(ctx, p, nil, nil, f, provider.ImportOptions{Existing: es, Report: rep}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []report.Resource{
+			{Type: "aws_iam_user", ID: "1", Address: "aws_iam_user.admin", Status: report.StatusExisting},
+			{Type: "aws_iam_user", ID: "2", Address: "aws_iam_user.user", Status: report.StatusImported},
+		}, rep.Resources())
+	})
+	t.Run("SuccessWithManaged", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = mock.NewProvider(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			iamUser3 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{
+				Include: []string{"aws_iam_user"},
+			}
+			rep = report.New("aws")
+		)
+
+		defer ctrl.Finish()
+
+		ms, err := provider.ReadManagedState(strings.NewReader(managedState))
+		require.NoError(t, err)
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
+
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser3}, nil)
+
+		iamUser1.EXPECT().ID().Return("1").Times(2)
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		iamUser1.EXPECT().InstanceState().Return(nil)
+		iamUser1.EXPECT().Type().Return("aws_iam_user").Times(2)
+		iamUser1.EXPECT().Name().Return("admin")
+
+		// The iamUser3 is managed so nothing else is called
+		iamUser3.EXPECT().ID().Return("3")
+
+		err = provider.Import(ctx, p, nil, nil, f, provider.ImportOptions{Managed: ms, Report: rep}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []report.Resource{
+			{Type: "aws_iam_user", ID: "1", Address: "aws_iam_user.admin", Status: report.StatusImported},
+			{Type: "aws_iam_user", ID: "3", Status: report.StatusManaged},
+		}, rep.Resources())
+	})
+	t.Run("SuccessWithDryRun", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p                 = mock.NewProvider(ctrl)
+			instanceResource1 = mock.NewResource(ctrl)
+			instanceResource2 = mock.NewResource(ctrl)
+			iamUser1          = mock.NewResource(ctrl)
+
+			f   = &filter.Filter{}
+			out bytes.Buffer
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"})
+
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResource1, instanceResource2}, nil)
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1}, nil)
+
+		// Nothing else is called on the resources
+		instanceResource1.EXPECT().ID().Return("i-1")
+		instanceResource2.EXPECT().ID().Return("i-2")
+		iamUser1.EXPECT().ID().Return("user")
+
+		err := provider.Import(ctx, p, nil, nil, f, provider.ImportOptions{DryRun: true}, event.NewTTY(&out))
+		require.NoError(t, err)
+
+		assert.Contains(t, out.String(), `aws_instance	i-1
+aws_instance	i-2
+aws_iam_user	user
+Found 3 resources
+`)
+	})
+	t.Run("SuccessWithFilterInclude", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p                 = mock.NewProvider(ctrl)
+			hw                = mock.NewWriter(ctrl)
+			sw                = mock.NewWriter(ctrl)
+			instanceResource1 = mock.NewResource(ctrl)
+			instanceResource2 = mock.NewResource(ctrl)
+			i                 = interpolator.New("aws")
+
+			f = &filter.Filter{
+				Include: []string{"aws_instance"},
+			}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().HasResourceType("aws_instance").Return(true)
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResource1, instanceResource2}, nil)
+
+		instanceResource1.EXPECT().ID().Return("1")
+		instanceResource2.EXPECT().ID().Return("2")
+
+		instanceResource1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		instanceResource2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+
+		instanceResource1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		instanceResource2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+
+		instanceResource1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		instanceResource2.EXPECT().Read(gomock.Any(), f).Return(nil)
+
+		instanceResource1.EXPECT().HCL(hw).Return(nil)
+		instanceResource2.EXPECT().HCL(hw).Return(nil)
+
+		instanceResource1.EXPECT().State(sw).Return(nil)
+		instanceResource2.EXPECT().State(sw).Return(nil)
+
+		instanceResource1.EXPECT().InstanceState().Return(nil)
+		instanceResource2.EXPECT().InstanceState().Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(i)
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(i)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, nil)
+		require.NoError(t, err)
+	})
+	t.Run("SuccessWithFilterIncludePattern", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = mock.NewProvider(ctrl)
+			hw       = mock.NewWriter(ctrl)
+			sw       = mock.NewWriter(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			i        = interpolator.New("aws")
+
+			f = &filter.Filter{
+				Include: []string{"aws_iam_*", "!aws_iam_role"},
+			}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user", "aws_iam_role"})
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1}, nil)
+
+		iamUser1.EXPECT().ID().Return("1")
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		iamUser1.EXPECT().HCL(hw).Return(nil)
+		iamUser1.EXPECT().State(sw).Return(nil)
+		iamUser1.EXPECT().InstanceState().Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(i)
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(i)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"aws_iam_user"}, f.Include)
+	})
+	t.Run("SuccessWithFilterIncludePatternWithoutTypes", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p  = mock.NewProvider(ctrl)
+			hw = mock.NewWriter(ctrl)
+			sw = mock.NewWriter(ctrl)
+			i  = interpolator.New("aws")
+
+			f = &filter.Filter{
+				Include: []string{"/^aws_s3_/"},
+			}
+		)
+
+		defer ctrl.Finish()
+
+		// Nothing is imported as none of the types matches
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"}).Times(2)
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(i)
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(i)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, nil)
+		require.NoError(t, err)
+	})
+	t.Run("SuccessWithIDAndNamePatterns", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = mock.NewProvider(ctrl)
+			hw       = mock.NewWriter(ctrl)
+			sw       = mock.NewWriter(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			iamUser2 = mock.NewResource(ctrl)
+			iamUser3 = mock.NewResource(ctrl)
+			i        = interpolator.New("aws")
+			rep      = report.New("aws")
+
+			tfr = &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {Type: schema.TypeString, Optional: true},
+				},
+			}
+
+			f = &filter.Filter{
+				Include:     []string{"aws_iam_user"},
+				IDPattern:   "^AID",
+				NamePattern: "^payments-",
+			}
+		)
+
+		defer ctrl.Finish()
+
+		// The name of the iamUser2 is set by the listing so it's not
+		// imported and the iamUser3 does not match the IDPattern
+		data1 := tfr.Data(nil)
+		data2 := tfr.Data(nil)
+		require.NoError(t, data2.Set("name", "billing-1"))
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2, iamUser3}, nil)
+
+		iamUser1.EXPECT().ID().Return("AID1").Times(2)
+		iamUser2.EXPECT().ID().Return("AID2")
+		iamUser3.EXPECT().ID().Return("3")
+
+		iamUser1.EXPECT().Data().Return(data1)
+		iamUser2.EXPECT().Data().Return(data2)
+
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		iamUser1.EXPECT().HCL(hw).Return(nil)
+		iamUser1.EXPECT().State(sw).Return(nil)
+		iamUser1.EXPECT().InstanceState().Return(nil)
+		iamUser1.EXPECT().Type().Return("aws_iam_user").Times(2)
+		iamUser1.EXPECT().Name().Return("payments_1")
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(i)
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(i)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Report: rep}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []report.Resource{
+			{Type: "aws_iam_user", ID: "3", Status: report.StatusFilteredByPattern, Error: `the ID "3": ` + errcode.ErrProviderResourceDoNotMatchPattern.Error()},
+			{Type: "aws_iam_user", ID: "AID1", Address: "aws_iam_user.payments_1", Status: report.StatusImported},
+			{Type: "aws_iam_user", ID: "AID2", Status: report.StatusFilteredByPattern, Error: `the name "billing-1": ` + errcode.ErrProviderResourceDoNotMatchPattern.Error()},
+		}, rep.Resources())
+	})
+	t.Run("SuccessWithWhere", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = mock.NewProvider(ctrl)
+			hw       = mock.NewWriter(ctrl)
+			sw       = mock.NewWriter(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			iamUser2 = mock.NewResource(ctrl)
+			i        = interpolator.New("aws")
+			rep      = report.New("aws")
+		)
+
+		where, err := tag.Parse("path = /admins/*")
+		require.NoError(t, err)
+
+		f := &filter.Filter{
+			Include: []string{"aws_iam_user"},
+			Where:   where,
+		}
+
+		defer ctrl.Finish()
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2}, nil)
+
+		iamUser1.EXPECT().ID().Return("1").Times(2)
+		iamUser2.EXPECT().ID().Return("2")
+
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		iamUser2.EXPECT().Read(gomock.Any(), f).Return(nil)
+
+		// Only the iamUser1 matches the Where
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{Attributes: map[string]string{"path": "/admins/ops/"}})
+		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{Attributes: map[string]string{"path": "/"}})
+
+		iamUser1.EXPECT().HCL(hw).Return(nil)
+		iamUser1.EXPECT().State(sw).Return(nil)
+		iamUser1.EXPECT().InstanceState().Return(nil)
+		iamUser1.EXPECT().Type().Return("aws_iam_user").Times(2)
+		iamUser1.EXPECT().Name().Return("admin")
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(i)
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(i)
+
+		err = provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Report: rep}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []report.Resource{
+			{Type: "aws_iam_user", ID: "1", Address: "aws_iam_user.admin", Status: report.StatusImported},
+			{Type: "aws_iam_user", ID: "2", Status: report.StatusFilteredByWhere, Error: "path:/admins/*: " + errcode.ErrProviderResourceDoNotMatchWhere.Error()},
+		}, rep.Resources())
+	})
+	t.Run("SuccessWithExclude", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = mock.NewProvider(ctrl)
+			hw       = mock.NewWriter(ctrl)
+			sw       = mock.NewWriter(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			iamUser2 = mock.NewResource(ctrl)
+			i        = interpolator.New("aws")
+
+			f = &filter.Filter{
+				Exclude: []string{"aws_instance"},
+			}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().HasResourceType("aws_instance").Return(true)
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"})
+
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2}, nil)
+
+		iamUser1.EXPECT().ID().Return("1")
+		iamUser2.EXPECT().ID().Return("2")
+
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		iamUser2.EXPECT().Read(gomock.Any(), f).Return(nil)
+
+		iamUser1.EXPECT().HCL(hw).Return(nil)
+		iamUser2.EXPECT().HCL(hw).Return(nil)
+
+		iamUser1.EXPECT().State(sw).Return(nil)
+		iamUser2.EXPECT().State(sw).Return(nil)
+
+		iamUser1.EXPECT().InstanceState().Return(nil)
+		iamUser2.EXPECT().InstanceState().Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(i)
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(i)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, nil)
+		require.NoError(t, err)
+	})
+	t.Run("SuccessWithErrProviderResourceDoNotMatchTag", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = mock.NewProvider(ctrl)
+			hw       = mock.NewWriter(ctrl)
+			sw       = mock.NewWriter(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			iamUser2 = mock.NewResource(ctrl)
+			i        = interpolator.New("aws")
+
+			f = &filter.Filter{
+				Exclude: []string{"aws_instance"},
+			}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().HasResourceType("aws_instance").Return(true)
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"})
+
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2}, nil)
+
+		iamUser1.EXPECT().ID().Return("1")
+		iamUser2.EXPECT().ID().Return("2")
+
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+
+		iamUser2.EXPECT().InstanceState().Return(nil)
+
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(errcode.ErrProviderResourceDoNotMatchTag)
+		iamUser2.EXPECT().Read(gomock.Any(), f).Return(nil)
+
+		iamUser2.EXPECT().HCL(hw).Return(nil)
+
+		iamUser2.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(i)
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(i)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, nil)
+		require.NoError(t, err)
+	})
+	t.Run("SuccessWithNoHCLWriter", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = mock.NewProvider(ctrl)
+			sw       = mock.NewWriter(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			iamUser2 = mock.NewResource(ctrl)
+			i        = interpolator.New("aws")
+
+			f = &filter.Filter{
+				Exclude: []string{"aws_instance"},
+			}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().HasResourceType("aws_instance").Return(true)
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"})
+
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2}, nil)
+
+		iamUser1.EXPECT().ID().Return("1")
+		iamUser2.EXPECT().ID().Return("2")
 
-		instanceResource1.EXPECT().Read(f).Return(nil)
-		instanceResource2.EXPECT().Read(f).Return(nil)
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
 
-		instanceResource1.EXPECT().HCL(hw).Return(nil)
-		instanceResource2.EXPECT().HCL(hw).Return(nil)
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 
-		instanceResource1.EXPECT().State(sw).Return(nil)
-		instanceResource2.EXPECT().State(sw).Return(nil)
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(errcode.ErrProviderResourceDoNotMatchTag)
+		iamUser2.EXPECT().Read(gomock.Any(), f).Return(nil)
 
-		instanceResource1.EXPECT().InstanceState().Return(nil)
-		instanceResource2.EXPECT().InstanceState().Return(nil)
+		iamUser2.EXPECT().State(sw).Return(nil)
+		iamUser2.EXPECT().InstanceState().Return(nil)
 
-		hw.EXPECT().Sync().Return(nil)
-		hw.EXPECT().Interpolate(i)
 		sw.EXPECT().Sync().Return(nil)
 		sw.EXPECT().Interpolate(i)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, nil, sw, f, provider.ImportOptions{}, nil)
 		require.NoError(t, err)
 	})
-	t.Run("SuccessWithExclude", func(t *testing.T) {
+	t.Run("SuccessWithNoTFStateWriter", func(t *testing.T) {
 		var (
 			ctrl = gomock.NewController(t)
 			ctx  = context.Background()
 
 			p        = mock.NewProvider(ctrl)
 			hw       = mock.NewWriter(ctrl)
-			sw       = mock.NewWriter(ctrl)
 			iamUser1 = mock.NewResource(ctrl)
 			iamUser2 = mock.NewResource(ctrl)
 			i        = interpolator.New("aws")
@@ -167,33 +1061,25 @@ func TestImport(t *testing.T) {
 		iamUser1.EXPECT().ID().Return("1")
 		iamUser2.EXPECT().ID().Return("2")
 
-		iamUser1.EXPECT().ImportState().Return(nil, nil)
-		iamUser2.EXPECT().ImportState().Return(nil, nil)
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
 
 		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 
-		iamUser1.EXPECT().Read(f).Return(nil)
-		iamUser2.EXPECT().Read(f).Return(nil)
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(errcode.ErrProviderResourceDoNotMatchTag)
+		iamUser2.EXPECT().Read(gomock.Any(), f).Return(nil)
 
-		iamUser1.EXPECT().HCL(hw).Return(nil)
 		iamUser2.EXPECT().HCL(hw).Return(nil)
-
-		iamUser1.EXPECT().State(sw).Return(nil)
-		iamUser2.EXPECT().State(sw).Return(nil)
-
-		iamUser1.EXPECT().InstanceState().Return(nil)
 		iamUser2.EXPECT().InstanceState().Return(nil)
 
 		hw.EXPECT().Sync().Return(nil)
 		hw.EXPECT().Interpolate(i)
-		sw.EXPECT().Sync().Return(nil)
-		sw.EXPECT().Interpolate(i)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, nil, f, provider.ImportOptions{}, nil)
 		require.NoError(t, err)
 	})
-	t.Run("SuccessWithErrProviderResourceDoNotMatchTag", func(t *testing.T) {
+	t.Run("ErrorWithErrProviderResourceNotRead", func(t *testing.T) {
 		var (
 			ctrl = gomock.NewController(t)
 			ctx  = context.Background()
@@ -221,35 +1107,35 @@ func TestImport(t *testing.T) {
 		iamUser1.EXPECT().ID().Return("1")
 		iamUser2.EXPECT().ID().Return("2")
 
-		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
-		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
-
-		iamUser1.EXPECT().ImportState().Return(nil, nil)
-		iamUser2.EXPECT().ImportState().Return(nil, nil)
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
 
-		iamUser2.EXPECT().InstanceState().Return(nil)
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(errcode.ErrProviderResourceNotRead)
+		iamUser2.EXPECT().Read(gomock.Any(), f).Return(nil)
 
-		iamUser1.EXPECT().Read(f).Return(errcode.ErrProviderResourceDoNotMatchTag)
-		iamUser2.EXPECT().Read(f).Return(nil)
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 
 		iamUser2.EXPECT().HCL(hw).Return(nil)
 
 		iamUser2.EXPECT().State(sw).Return(nil)
+		iamUser2.EXPECT().InstanceState().Return(nil)
 
 		hw.EXPECT().Sync().Return(nil)
 		hw.EXPECT().Interpolate(i)
 		sw.EXPECT().Sync().Return(nil)
 		sw.EXPECT().Interpolate(i)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, nil)
 		require.NoError(t, err)
 	})
-	t.Run("SuccessWithNoHCLWriter", func(t *testing.T) {
+	t.Run("ErrorWithErrProviderResourceAutogenerated", func(t *testing.T) {
 		var (
 			ctrl = gomock.NewController(t)
 			ctx  = context.Background()
 
 			p        = mock.NewProvider(ctrl)
+			hw       = mock.NewWriter(ctrl)
 			sw       = mock.NewWriter(ctrl)
 			iamUser1 = mock.NewResource(ctrl)
 			iamUser2 = mock.NewResource(ctrl)
@@ -271,70 +1157,112 @@ func TestImport(t *testing.T) {
 		iamUser1.EXPECT().ID().Return("1")
 		iamUser2.EXPECT().ID().Return("2")
 
-		iamUser1.EXPECT().ImportState().Return(nil, nil)
-		iamUser2.EXPECT().ImportState().Return(nil, nil)
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(errcode.ErrProviderResourceAutogenerated)
+		iamUser2.EXPECT().Read(gomock.Any(), f).Return(nil)
 
 		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 
-		iamUser1.EXPECT().Read(f).Return(errcode.ErrProviderResourceDoNotMatchTag)
-		iamUser2.EXPECT().Read(f).Return(nil)
+		iamUser2.EXPECT().HCL(hw).Return(nil)
 
 		iamUser2.EXPECT().State(sw).Return(nil)
 		iamUser2.EXPECT().InstanceState().Return(nil)
 
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(i)
 		sw.EXPECT().Sync().Return(nil)
 		sw.EXPECT().Interpolate(i)
 
-		err := provider.Import(ctx, p, nil, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, nil)
 		require.NoError(t, err)
 	})
-	t.Run("SuccessWithNoTFStateWriter", func(t *testing.T) {
+	t.Run("ErrorWithStrict", func(t *testing.T) {
 		var (
 			ctrl = gomock.NewController(t)
 			ctx  = context.Background()
 
 			p        = mock.NewProvider(ctrl)
 			hw       = mock.NewWriter(ctrl)
+			sw       = mock.NewWriter(ctrl)
 			iamUser1 = mock.NewResource(ctrl)
 			iamUser2 = mock.NewResource(ctrl)
-			i        = interpolator.New("aws")
 
 			f = &filter.Filter{
-				Exclude: []string{"aws_instance"},
+				Include: []string{"aws_iam_user"},
 			}
 		)
 
 		defer ctrl.Finish()
 
 		p.EXPECT().String().Return("aws")
-		p.EXPECT().HasResourceType("aws_instance").Return(true)
-		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"})
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
 
 		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2}, nil)
 
+		// The iamUser2 is not read as the import
+		// stops on the first failure
 		iamUser1.EXPECT().ID().Return("1")
 		iamUser2.EXPECT().ID().Return("2")
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(errcode.ErrProviderResourceNotRead)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Strict: true}, nil)
+		assert.True(t, errors.Is(err, errcode.ErrProviderResourceNotRead))
+	})
+	t.Run("ErrorWithMaxErrorRatio", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = mock.NewProvider(ctrl)
+			hw       = mock.NewWriter(ctrl)
+			sw       = mock.NewWriter(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			iamUser2 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{
+				Include: []string{"aws_iam_user"},
+			}
+			out bytes.Buffer
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
+
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2}, nil)
+
+		iamUser1.EXPECT().ID().Return("1")
+		iamUser2.EXPECT().ID().Return("2").Times(2)
 
-		iamUser1.EXPECT().ImportState().Return(nil, nil)
-		iamUser2.EXPECT().ImportState().Return(nil, nil)
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
 
 		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 
-		iamUser1.EXPECT().Read(f).Return(errcode.ErrProviderResourceDoNotMatchTag)
-		iamUser2.EXPECT().Read(f).Return(nil)
+		iamUser1.EXPECT().Read(gomock.Any(), f).Return(errcode.ErrProviderResourceNotRead)
+		iamUser2.EXPECT().Read(gomock.Any(), f).Return(nil)
 
 		iamUser2.EXPECT().HCL(hw).Return(nil)
+		iamUser2.EXPECT().State(sw).Return(nil)
 		iamUser2.EXPECT().InstanceState().Return(nil)
-
-		hw.EXPECT().Sync().Return(nil)
-		hw.EXPECT().Interpolate(i)
-
-		err := provider.Import(ctx, p, hw, nil, f, ioutil.Discard)
-		require.NoError(t, err)
+		iamUser2.EXPECT().Type().Return("aws_iam_user").Times(2)
+		iamUser2.EXPECT().Name().Return("user")
+
+		// Half of the resources failed so no output is written
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{MaxErrorRatio: 0.3}, event.NewTTY(&out))
+		assert.True(t, errors.Is(err, errcode.ErrImportTooManyFailures))
+		assert.Contains(t, out.String(), `Failed to import 1 of 2 resources:
+  aws_iam_user: 1 (the resource did not return an ID)
+`)
 	})
-	t.Run("ErrorWithErrProviderResourceNotRead", func(t *testing.T) {
+	t.Run("SuccessWithImportStateErrorWithinMaxErrors", func(t *testing.T) {
 		var (
 			ctrl = gomock.NewController(t)
 			ctx  = context.Background()
@@ -347,44 +1275,46 @@ func TestImport(t *testing.T) {
 			i        = interpolator.New("aws")
 
 			f = &filter.Filter{
-				Exclude: []string{"aws_instance"},
+				Include: []string{"aws_iam_user"},
 			}
+			out bytes.Buffer
 		)
 
 		defer ctrl.Finish()
 
 		p.EXPECT().String().Return("aws")
-		p.EXPECT().HasResourceType("aws_instance").Return(true)
-		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"})
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
 
 		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2}, nil)
 
 		iamUser1.EXPECT().ID().Return("1")
-		iamUser2.EXPECT().ID().Return("2")
+		iamUser2.EXPECT().ID().Return("2").Times(2)
 
-		iamUser1.EXPECT().ImportState().Return(nil, nil)
-		iamUser2.EXPECT().ImportState().Return(nil, nil)
+		// The iamUser1 fails to be imported but the
+		// import continues as it's within the MaxErrors
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, errors.New("access denied"))
+		iamUser2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
 
-		iamUser1.EXPECT().Read(f).Return(errcode.ErrProviderResourceNotRead)
-		iamUser2.EXPECT().Read(f).Return(nil)
-
-		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
-
+		iamUser2.EXPECT().Read(gomock.Any(), f).Return(nil)
 		iamUser2.EXPECT().HCL(hw).Return(nil)
-
 		iamUser2.EXPECT().State(sw).Return(nil)
 		iamUser2.EXPECT().InstanceState().Return(nil)
+		iamUser2.EXPECT().Type().Return("aws_iam_user").Times(2)
+		iamUser2.EXPECT().Name().Return("user")
 
 		hw.EXPECT().Sync().Return(nil)
 		hw.EXPECT().Interpolate(i)
 		sw.EXPECT().Sync().Return(nil)
 		sw.EXPECT().Interpolate(i)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{MaxErrors: 1}, event.NewTTY(&out))
 		require.NoError(t, err)
+		assert.Contains(t, out.String(), `Failed to import 1 of 2 resources:
+  aws_iam_user: 1 (unknown)
+`)
 	})
-	t.Run("ErrorWithErrProviderResourceAutogenerated", func(t *testing.T) {
+	t.Run("ErrorWithImportStateErrorAndStrict", func(t *testing.T) {
 		var (
 			ctrl = gomock.NewController(t)
 			ctx  = context.Background()
@@ -394,35 +1324,68 @@ func TestImport(t *testing.T) {
 			sw       = mock.NewWriter(ctrl)
 			iamUser1 = mock.NewResource(ctrl)
 			iamUser2 = mock.NewResource(ctrl)
-			i        = interpolator.New("aws")
 
 			f = &filter.Filter{
-				Exclude: []string{"aws_instance"},
+				Include: []string{"aws_iam_user"},
 			}
 		)
 
 		defer ctrl.Finish()
 
 		p.EXPECT().String().Return("aws")
-		p.EXPECT().HasResourceType("aws_instance").Return(true)
-		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"})
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
 
 		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2}, nil)
 
 		iamUser1.EXPECT().ID().Return("1")
 		iamUser2.EXPECT().ID().Return("2")
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, errcode.ErrProviderResourceNotRead)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Strict: true}, nil)
+		assert.True(t, errors.Is(err, errcode.ErrProviderResourceNotRead))
+		assert.Contains(t, err.Error(), "strict mode")
+	})
+	t.Run("SuccessWithResourceTimeout", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
 
-		iamUser1.EXPECT().ImportState().Return(nil, nil)
-		iamUser2.EXPECT().ImportState().Return(nil, nil)
+			p        = mock.NewProvider(ctrl)
+			hw       = mock.NewWriter(ctrl)
+			sw       = mock.NewWriter(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			iamUser2 = mock.NewResource(ctrl)
+			i        = interpolator.New("aws")
+
+			f = &filter.Filter{
+				Include: []string{"aws_iam_user"},
+			}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
+
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2}, nil)
 
-		iamUser1.EXPECT().Read(f).Return(errcode.ErrProviderResourceAutogenerated)
-		iamUser2.EXPECT().Read(f).Return(nil)
+		iamUser1.EXPECT().ID().Return("1")
+		iamUser2.EXPECT().ID().Return("2")
+
+		iamUser1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		iamUser2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
 
 		iamUser1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 		iamUser2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 
-		iamUser2.EXPECT().HCL(hw).Return(nil)
+		// The iamUser1 hangs until the timeout
+		iamUser1.EXPECT().Read(gomock.Any(), f).DoAndReturn(func(ctx context.Context, f *filter.Filter) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		iamUser2.EXPECT().Read(gomock.Any(), f).Return(nil)
 
+		iamUser2.EXPECT().HCL(hw).Return(nil)
 		iamUser2.EXPECT().State(sw).Return(nil)
 		iamUser2.EXPECT().InstanceState().Return(nil)
 
@@ -431,9 +1394,130 @@ func TestImport(t *testing.T) {
 		sw.EXPECT().Sync().Return(nil)
 		sw.EXPECT().Interpolate(i)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{ResourceTimeout: 10 * time.Millisecond}, nil)
 		require.NoError(t, err)
 	})
+	t.Run("ErrorWithCanceledContext", func(t *testing.T) {
+		var (
+			ctrl        = gomock.NewController(t)
+			ctx, cancel = context.WithCancel(context.Background())
+
+			p                 = mock.NewProvider(ctrl)
+			hw                = mock.NewWriter(ctrl)
+			sw                = mock.NewWriter(ctrl)
+			instanceResource1 = mock.NewResource(ctrl)
+			instanceResource2 = mock.NewResource(ctrl)
+			iamUser1          = mock.NewResource(ctrl)
+			i                 = interpolator.New("aws")
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+		defer cancel()
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"})
+
+		// The aws_iam_user may be listed on the background
+		// but it's never read
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResource1, instanceResource2}, nil)
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1}, nil).AnyTimes()
+
+		instanceResource1.EXPECT().ID().Return("1")
+		instanceResource2.EXPECT().ID().Return("2")
+
+		instanceResource1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		instanceResource2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+
+		instanceResource1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		instanceResource2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+
+		instanceResource1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		instanceResource2.EXPECT().Read(gomock.Any(), f).Return(nil)
+
+		// It's canceled while writing the aws_instance so
+		// those are written but not the next types
+		instanceResource1.EXPECT().HCL(hw).DoAndReturn(func(w interface{}) error {
+			cancel()
+			return nil
+		})
+		instanceResource2.EXPECT().HCL(hw).Return(nil)
+
+		instanceResource1.EXPECT().State(sw).Return(nil)
+		instanceResource2.EXPECT().State(sw).Return(nil)
+
+		instanceResource1.EXPECT().InstanceState().Return(nil)
+		instanceResource2.EXPECT().InstanceState().Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(i)
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(i)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, nil)
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+	t.Run("ErrorWithCanceledContextAndReport", func(t *testing.T) {
+		var (
+			ctrl        = gomock.NewController(t)
+			ctx, cancel = context.WithCancel(context.Background())
+
+			p                 = mock.NewProvider(ctrl)
+			hw                = mock.NewWriter(ctrl)
+			sw                = mock.NewWriter(ctrl)
+			instanceResource1 = mock.NewResource(ctrl)
+			instanceResource2 = mock.NewResource(ctrl)
+			iamUser1          = mock.NewResource(ctrl)
+			i                 = interpolator.New("aws")
+
+			f   = &filter.Filter{}
+			rep = report.New("aws")
+		)
+
+		defer ctrl.Finish()
+		defer cancel()
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"})
+
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResource1, instanceResource2}, nil)
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1}, nil).AnyTimes()
+
+		instanceResource1.EXPECT().ID().Return("1").Times(2)
+		instanceResource2.EXPECT().ID().Return("2")
+
+		// It's canceled while importing the second
+		// aws_instance so it's not read
+		instanceResource1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		instanceResource2.EXPECT().ImportState(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]provider.Resource, error) {
+			cancel()
+			return nil, nil
+		})
+
+		instanceResource1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		instanceResource1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		instanceResource1.EXPECT().HCL(hw).Return(nil)
+		instanceResource1.EXPECT().State(sw).Return(nil)
+		instanceResource1.EXPECT().InstanceState().Return(nil)
+		instanceResource1.EXPECT().Type().Return("aws_instance").Times(2)
+		instanceResource1.EXPECT().Name().Return("front")
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(i)
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(i)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Report: rep}, nil)
+		assert.True(t, errors.Is(err, context.Canceled))
+
+		assert.False(t, rep.Complete())
+		assert.Equal(t, []report.Resource{
+			{Type: "aws_iam_user", Status: report.StatusNotRead},
+			{Type: "aws_instance", ID: "1", Address: "aws_instance.front", Status: report.StatusImported},
+			{Type: "aws_instance", ID: "2", Status: report.StatusNotRead},
+		}, rep.Resources())
+	})
 	t.Run("ErrorWithIncorrectFilterInclude", func(t *testing.T) {
 		var (
 			ctrl = gomock.NewController(t)
@@ -453,10 +1537,31 @@ func TestImport(t *testing.T) {
 		p.EXPECT().HasResourceType("aws_instance").Return(true)
 		p.EXPECT().HasResourceType("aws_potato").Return(false)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, nil)
 		assert.Equal(t, errcode.ErrProviderResourceNotSupported.Error(), errors.Cause(err).Error())
 	})
 
+	t.Run("ErrorWithInvalidFilterPattern", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p  = mock.NewProvider(ctrl)
+			hw = mock.NewWriter(ctrl)
+			sw = mock.NewWriter(ctrl)
+
+			f = &filter.Filter{
+				Exclude: []string{"/aws_(iam/"},
+			}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"})
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, nil)
+		assert.True(t, errors.Is(err, errcode.ErrFilterPatternInvalid))
+	})
 	t.Run("ErrorWithIncorrectFilterExclude", func(t *testing.T) {
 		var (
 			ctrl = gomock.NewController(t)
@@ -477,7 +1582,7 @@ func TestImport(t *testing.T) {
 		p.EXPECT().HasResourceType("aws_instance").Return(true)
 		p.EXPECT().HasResourceType("aws_potato").Return(false)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, nil)
 		assert.Equal(t, errcode.ErrProviderResourceNotSupported.Error(), errors.Cause(err).Error())
 	})
 	t.Run("ErrorWithNotErrProviderAPI", func(t *testing.T) {
@@ -502,7 +1607,7 @@ func TestImport(t *testing.T) {
 		p.EXPECT().String().Return("aws")
 		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return(nil, errors.New("should stop the import"))
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, nil)
 		assert.Contains(t, err.Error(), "stop the import")
 	})
 	t.Run("ErrorWithErrProviderAPI", func(t *testing.T) {
@@ -531,11 +1636,63 @@ func TestImport(t *testing.T) {
 		instanceResource1.EXPECT().ID().Return("1")
 		instanceResource2.EXPECT().ID().Return("2")
 
-		instanceResource1.EXPECT().ImportState().Return(nil, nil)
-		instanceResource2.EXPECT().ImportState().Return(nil, nil)
+		instanceResource1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		instanceResource2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+
+		instanceResource1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		instanceResource2.EXPECT().Read(gomock.Any(), f).Return(nil)
+
+		instanceResource1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+		instanceResource2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
+
+		instanceResource1.EXPECT().HCL(hw).Return(nil)
+		instanceResource2.EXPECT().HCL(hw).Return(nil)
+
+		instanceResource1.EXPECT().State(sw).Return(nil)
+		instanceResource2.EXPECT().State(sw).Return(nil)
+
+		instanceResource1.EXPECT().InstanceState().Return(nil)
+		instanceResource2.EXPECT().InstanceState().Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		hw.EXPECT().Interpolate(i)
+		sw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Interpolate(i)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, nil)
+		require.NoError(t, err)
+	})
+	t.Run("SuccessWithErrProviderAPIAndMaxErrorRatio", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p                 = mock.NewProvider(ctrl)
+			hw                = mock.NewWriter(ctrl)
+			sw                = mock.NewWriter(ctrl)
+			i                 = interpolator.New("aws")
+			instanceResource1 = mock.NewResource(ctrl)
+			instanceResource2 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().String().Return("aws")
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"})
+
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResource1, instanceResource2}, nil)
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return(nil, fmt.Errorf("%w: should not stop the import", errcode.ErrProviderAPI))
+
+		instanceResource1.EXPECT().ID().Return("1")
+		instanceResource2.EXPECT().ID().Return("2")
+
+		instanceResource1.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
+		instanceResource2.EXPECT().ImportState(gomock.Any()).Return(nil, nil)
 
-		instanceResource1.EXPECT().Read(f).Return(nil)
-		instanceResource2.EXPECT().Read(f).Return(nil)
+		instanceResource1.EXPECT().Read(gomock.Any(), f).Return(nil)
+		instanceResource2.EXPECT().Read(gomock.Any(), f).Return(nil)
 
 		instanceResource1.EXPECT().InstanceState().Return(&terraform.InstanceState{})
 		instanceResource2.EXPECT().InstanceState().Return(&terraform.InstanceState{})
@@ -554,7 +1711,9 @@ func TestImport(t *testing.T) {
 		sw.EXPECT().Sync().Return(nil)
 		sw.EXPECT().Interpolate(i)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		// The aws_iam_user counts as one of the
+		// resources so the ratio is 1 of 3
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{MaxErrorRatio: 0.4}, nil)
 		require.NoError(t, err)
 	})
 }
@@ -2,10 +2,15 @@ package provider
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/util"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform/tfdiags"
+	"github.com/pkg/errors"
 )
 
 //go:generate mockgen -destination=../mock/provider.go -mock_names=Provider=Provider -package mock github.com/cycloidio/terracognita/provider Provider
@@ -68,3 +73,105 @@ type Provider interface {
 	// like instances in autoscaling
 	FilterByTags(tags interface{}) error
 }
+
+// Aliaser is implemented by the Providers that can be configured
+// more than once on the same HCL, for example one for each region,
+// so each one of them is identified by its alias
+type Aliaser interface {
+	// Alias returns the alias of the Provider,
+	// empty if it's the default one
+	Alias() string
+}
+
+// Alias returns the alias of the p if it
+// implements Aliaser, if not it's empty
+func Alias(p Provider) string {
+	if a, ok := p.(Aliaser); ok {
+		return a.Alias()
+	}
+	return ""
+}
+
+// GRPCClienter is implemented by the Providers that keep the
+// GRPCClient of their TFProvider, so all their Resources
+// share it and it's released along with the Provider
+type GRPCClienter interface {
+	// GRPCClient returns the GRPCClient of the TFProvider
+	GRPCClient() *GRPCClient
+}
+
+// Namer is implemented by the Providers which resources are
+// not all named by their 'name' attribute (ex: by a tag)
+type Namer interface {
+	// NameKey returns the key of the attribute with
+	// the name of the resources of type t (ex: 'tags.Name')
+	NameKey(t string) string
+}
+
+// Retrier is implemented by the Providers that know
+// which errors of their APIs can be retried, the
+// errors of the ones that do not are not retried
+type Retrier interface {
+	// Retryable returns if the err can be retried and, if
+	// the err has it (ex: the Retry-After of an HTTP
+	// response), after how long
+	Retryable(err error) (bool, time.Duration)
+}
+
+// RetryPolicy returns the util.RetryPolicy with the maxRetries
+// and maxWait and, if the p implements Retrier, its classifier
+// which also retries the DiagnosticsError of throttling
+func RetryPolicy(p Provider, maxRetries int, maxWait time.Duration) util.RetryPolicy {
+	var classifier util.RetryClassifier
+	if r, ok := p.(Retrier); ok {
+		classifier = func(err error) (bool, time.Duration) {
+			if ok, after := r.Retryable(err); ok {
+				return true, after
+			}
+			return retryableDiagnostics(err), 0
+		}
+	}
+	return util.NewRetryPolicy(maxRetries, maxWait, classifier)
+}
+
+// DiagnosticsError is the error of the Diagnostics returned
+// by the TF provider when importing or reading a Resource
+type DiagnosticsError struct {
+	Diagnostics tfdiags.Diagnostics
+}
+
+func (e *DiagnosticsError) Error() string {
+	return e.Diagnostics.Err().Error()
+}
+
+// retryableDiagnosticsMessages are the messages of the errors of the
+// providers APIs, as the errors themselves are lost on the Diagnostics
+// and the Retrier can not classify them, that can be retried
+var retryableDiagnosticsMessages = []string{
+	// AWS
+	"Throttling", "RequestLimitExceeded", "TooManyRequestsException", "Rate exceeded", "SlowDown",
+	// Google
+	"rateLimitExceeded", "userRateLimitExceeded", "Error 429", "Error 503",
+	// AzureRM
+	"StatusCode=429", "StatusCode=503",
+}
+
+// retryableDiagnostics checks if the err is a DiagnosticsError
+// with any of the retryableDiagnosticsMessages
+func retryableDiagnostics(err error) bool {
+	var derr *DiagnosticsError
+	if !errors.As(err, &derr) {
+		return false
+	}
+
+	for _, d := range derr.Diagnostics {
+		desc := d.Description()
+		for _, m := range retryableDiagnosticsMessages {
+			if strings.Contains(desc.Summary, m) || strings.Contains(desc.Detail, m) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/event"
+	"github.com/cycloidio/terracognita/report"
+)
+
+// recorder records the outcome of the resources on the Report
+// and sends the Events to the Handler, both can be nil
+type recorder struct {
+	rep *report.Report
+	h   event.Handler
+}
+
+// emit sends the e to the Handler with the current time
+func (rc recorder) emit(e event.Event) {
+	if rc.h == nil {
+		return
+	}
+	e.Time = time.Now()
+	rc.h.Handle(e)
+}
+
+// imported records the resource of type t and id as imported
+func (rc recorder) imported(t, id, address string) {
+	if rc.rep != nil {
+		rc.rep.AddImported(t, id, address)
+	}
+	rc.emit(event.Event{
+		Type:         event.TypeResourceImported,
+		ResourceType: t,
+		ID:           id,
+		Address:      address,
+	})
+}
+
+// notImportable records the resource of type t and
+// id as skipped because it could not be imported
+func (rc recorder) notImportable(t, id string) {
+	if rc.rep != nil {
+		rc.rep.Add(report.Resource{Type: t, ID: id, Status: report.StatusNotImportable})
+	}
+	rc.emit(event.Event{
+		Type:         event.TypeResourceSkipped,
+		ResourceType: t,
+		ID:           id,
+		Reason:       string(report.StatusNotImportable),
+	})
+}
+
+// existing records the resource of type t and id, with the address,
+// as skipped because it was already on the existing state
+func (rc recorder) existing(t, id, address string) {
+	if rc.rep != nil {
+		rc.rep.Add(report.Resource{Type: t, ID: id, Address: address, Status: report.StatusExisting})
+	}
+	rc.emit(event.Event{
+		Type:         event.TypeResourceSkipped,
+		ResourceType: t,
+		ID:           id,
+		Address:      address,
+		Reason:       string(report.StatusExisting),
+	})
+}
+
+// managed records the resource of type t and id as skipped
+// because it's already managed by another Terraform configuration
+func (rc recorder) managed(t, id string) {
+	if rc.rep != nil {
+		rc.rep.Add(report.Resource{Type: t, ID: id, Status: report.StatusManaged})
+	}
+	rc.emit(event.Event{
+		Type:         event.TypeResourceSkipped,
+		ResourceType: t,
+		ID:           id,
+		Reason:       string(report.StatusManaged),
+	})
+}
+
+// notRead records the resource of type t and id as not read because the
+// import was stopped, if the id is empty it's the type that was not listed
+func (rc recorder) notRead(t, id string) {
+	if rc.rep != nil {
+		rc.rep.Add(report.Resource{Type: t, ID: id, Status: report.StatusNotRead})
+	}
+	rc.emit(event.Event{
+		Type:         event.TypeResourceSkipped,
+		ResourceType: t,
+		ID:           id,
+		Reason:       string(report.StatusNotRead),
+	})
+}
+
+// failed records the resource of type t and id that was not imported
+// because of the err, which can mean that it was skipped or failed
+func (rc recorder) failed(t, id string, err error) {
+	if rc.rep != nil {
+		rc.rep.AddError(t, id, err)
+	}
+
+	if errcode.Classify(err) == errcode.ClassSkipped {
+		rc.emit(event.Event{
+			Type:         event.TypeResourceSkipped,
+			ResourceType: t,
+			ID:           id,
+			Reason:       string(report.ErrorStatus(err)),
+		})
+		return
+	}
+
+	rc.emit(event.Event{
+		Type:         event.TypeResourceFailed,
+		ResourceType: t,
+		ID:           id,
+		Reason:       failureReason(err),
+		Error:        err.Error(),
+	})
+}
+
+// failureReason returns the Reason of the err
+// of a failure, which is its error code
+func failureReason(err error) string {
+	if c := errcode.Code(err); c != nil {
+		return c.Error()
+	}
+	return "unknown"
+}
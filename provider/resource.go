@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -64,11 +65,11 @@ type Resource interface {
 	// it imported more than one state, this list does not
 	// include the actual Resource on parameters, so if
 	// len([]Resource) == 0 means only the Resource is imported
-	ImportState() ([]Resource, error)
+	ImportState(ctx context.Context) ([]Resource, error)
 
 	// Read read the remote information of the Resource to the
 	// state and calculates the ResourceInstanceObject
-	Read(f *filter.Filter) error
+	Read(ctx context.Context, f *filter.Filter) error
 
 	// State calculates the state of the Resource and
 	// writes it to w
@@ -146,10 +147,51 @@ func NewResource(id, rt string, p Provider) Resource {
 		id:           id,
 		resourceType: rt,
 		provider:     p,
-		client:       NewGRPCClient(p.TFProvider()),
 	}
 }
 
+// LoadResource returns an already read Resource from its state attributes
+// and the name it was given, so it can be written without having to Import
+// and Read it again
+func LoadResource(id, rt, name string, attrs map[string]string, p Provider) (Resource, error) {
+	r := &resource{
+		id:           id,
+		resourceType: rt,
+		provider:     p,
+		configName:   name,
+	}
+
+	r.state = &terraform.InstanceState{
+		ID:         id,
+		Attributes: attrs,
+		Meta: map[string]interface{}{
+			"schema_version": r.TFResource().SchemaVersion,
+		},
+	}
+
+	v, err := r.state.AttrsAsObjectValue(r.TFResource().CoreConfigSchema().ImpliedType())
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load resource %s with id %s", rt, id)
+	}
+	r.stateValue = v
+	r.data = r.TFResource().Data(r.state)
+
+	if err = r.setResourceInstanceObject(); err != nil {
+		return nil, errors.Wrapf(err, "could not load resource %s with id %s", rt, id)
+	}
+
+	return r, nil
+}
+
+// grpcClient returns the GRPCClient of the Provider
+// which is shared with all the other Resources of it
+func (r *resource) grpcClient() *GRPCClient {
+	if r.client == nil {
+		r.client = getGRPCClient(r.provider)
+	}
+	return r.client
+}
+
 func (r *resource) AttributesReference() ([]string, error) {
 	resourceFunc, ok := providerResources[r.provider.String()]
 	if !ok {
@@ -206,7 +248,7 @@ func (r *resource) Data() *schema.ResourceData {
 
 func (r *resource) Provider() Provider { return r.provider }
 
-func (r *resource) ImportState() ([]Resource, error) {
+func (r *resource) ImportState(ctx context.Context) ([]Resource, error) {
 	logger := log.Get()
 	// If it does not support import do not try
 	if r.TFResource().Importer == nil {
@@ -214,12 +256,12 @@ func (r *resource) ImportState() ([]Resource, error) {
 		return nil, nil
 	}
 
-	irsresp := r.client.ImportResourceState(ImportResourceStateRequest{
+	irsresp := r.grpcClient().ImportResourceState(ctx, ImportResourceStateRequest{
 		TypeName: r.resourceType,
 		ID:       r.id,
 	})
-	if err := irsresp.Diagnostics.Err(); err != nil {
-		return nil, errors.Wrapf(err, "could not import resource %s with id %s", r.resourceType, r.id)
+	if irsresp.Diagnostics.HasErrors() {
+		return nil, errors.Wrapf(&DiagnosticsError{Diagnostics: irsresp.Diagnostics}, "could not import resource %s with id %s", r.resourceType, r.id)
 	}
 	// This converts value to state so we can follow the 2 API
 	newInstanceStates := make([]*terraform.InstanceState, 0, len(irsresp.ImportedResources))
@@ -261,15 +303,15 @@ func (r *resource) ImportState() ([]Resource, error) {
 	return resources, nil
 }
 
-func (r *resource) Read(f *filter.Filter) error {
+func (r *resource) Read(ctx context.Context, f *filter.Filter) error {
 	var err error
 	rrreq := ReadResourceRequest{
 		TypeName:   r.Type(),
 		PriorState: r.stateValue,
 	}
-	rrres := r.client.ReadResource(rrreq)
-	if err = rrres.Diagnostics.Err(); err != nil {
-		return errors.Wrapf(err, "could not read resource %s with id %s", r.resourceType, r.id)
+	rrres := r.grpcClient().ReadResource(ctx, rrreq)
+	if rrres.Diagnostics.HasErrors() {
+		return errors.Wrapf(&DiagnosticsError{Diagnostics: rrres.Diagnostics}, "could not read resource %s with id %s", r.resourceType, r.id)
 	}
 
 	// After getting the resource data we call the provider to fix any potential
@@ -298,23 +340,21 @@ func (r *resource) Read(f *filter.Filter) error {
 	// We don't do it if explicitly set on
 	// the resource to not do it as it means
 	// it has been done already
-	if !r.ignoreTagFilter {
-		for _, t := range f.Tags {
-			// Default match key
-			if v, ok := r.data.GetOk(fmt.Sprintf("%s.%s", r.Provider().TagKey(), t.Name)); ok && v.(string) == t.Value {
-				continue
-			}
-
-			// Check if the filter tag match any other tags found
-			// https://github.com/cycloidio/terracognita/issues/223
-			if v, ok := tag.GetOtherTags(r.Provider().String(), r.data, t); ok && v == t.Value {
-				continue
-			}
-
+	if !r.ignoreTagFilter && f.HasTags() {
+		// The tags can also be on other attributes
+		// https://github.com/cycloidio/terracognita/issues/223
+		tags := tag.GetTags(r.Provider().String(), r.Provider().TagKey(), r.data)
+		if !f.MatchTags(tags) {
 			return errors.WithStack(errcode.ErrProviderResourceDoNotMatchTag)
 		}
 	}
 
+	if f.NamePattern != "" {
+		if name, _ := ResourceName(r.Provider(), r.Type(), r.data); !f.MatchName(name) {
+			return errors.Wrapf(errcode.ErrProviderResourceDoNotMatchPattern, "the name %q", name)
+		}
+	}
+
 	// Filter out autogenerated resources from AWS
 	if v, ok := r.data.GetOk(r.Provider().TagKey()); ok {
 		if err = r.provider.FilterByTags(v); err != nil {
@@ -322,12 +362,36 @@ func (r *resource) Read(f *filter.Filter) error {
 		}
 	}
 
+	return r.setResourceInstanceObject()
+}
+
+// ResourceName returns the name of the resource of type t, from the Provider p,
+// with the srd, which is the one matched by the filter.Filter NamePattern: the 'name'
+// attribute or the one of the p if it implements Namer.
+// It returns false if it's not set on the srd
+func ResourceName(p Provider, t string, srd *schema.ResourceData) (string, bool) {
+	key := "name"
+	if n, ok := p.(Namer); ok {
+		key = n.NameKey(t)
+	}
+
+	v, ok := srd.GetOk(key)
+	if !ok {
+		return "", false
+	}
+	name, ok := v.(string)
+	return name, ok
+}
+
+// setResourceInstanceObject calculates the ResourceInstanceObject
+// from the current state of the Resource
+func (r *resource) setResourceInstanceObject() error {
 	meta, err := json.Marshal(r.state.Meta)
 	if err != nil {
 		return err
 	}
 
-	zstate, err := util.HashicorpToZclonfValue(newStateValue, r.tfResource.CoreConfigSchema().ImpliedType())
+	zstate, err := util.HashicorpToZclonfValue(r.stateValue, r.TFResource().CoreConfigSchema().ImpliedType())
 	if err != nil {
 		return err
 	}
@@ -350,14 +414,12 @@ func (r *resource) State(w writer.Writer) error {
 		// If it does not have any configName we will generate one
 		// and store it, so net time it'll use that one on any config
 		if r.configName == "" {
-			configName := tag.GetNameFromTag(r.provider.TagKey(), r.data, r.id)
-			if ok, err := w.Has(fmt.Sprintf("%s.%s", r.resourceType, configName)); err != nil {
+			configName, err := r.newConfigName(w)
+			if err != nil {
 				return err
-			} else if ok {
-				configName = pwgen.Alpha(5)
 			}
 
-			err := w.Write(fmt.Sprintf("%s.%s", r.resourceType, configName), r)
+			err = w.Write(fmt.Sprintf("%s.%s", r.resourceType, configName), r)
 			if err != nil {
 				return err
 			}
@@ -375,34 +437,49 @@ func (r *resource) State(w writer.Writer) error {
 	return nil
 }
 
+// ResourceCategory returns the category, in snake_case, that the
+// resource type rt of the provider has on the documentation
+func ResourceCategory(provider, rt string) (string, error) {
+	resourceFunc, ok := providerResources[provider]
+	if !ok {
+		return "", errors.New(fmt.Sprintf("provider %s is not supported", provider))
+	}
+
+	tfdoc, err := resourceFunc(rt)
+	if err != nil {
+		return "", errors.New(fmt.Sprintf("provider %s with resource %s is not supported on the docs", provider, rt))
+	}
+
+	// This will convert all Category into snake_case
+	return strings.ToLower(name.Delimit(tfdoc.Category, '_')), nil
+}
+
 // HCL returns the HCL configuration of the Resource and
 // writes it to HCL
 func (r *resource) HCL(w writer.Writer) error {
 	cfg := mergeFullConfig(r.data, r.tfResource.Schema, "")
 
-	resourceFunc, ok := providerResources[r.provider.String()]
-	if !ok {
-		return errors.New(fmt.Sprintf("provider %s is not supported", r.provider.String()))
+	category, err := ResourceCategory(r.provider.String(), r.Type())
+	if err != nil {
+		return err
 	}
+	cfg[writer.ResourceCategoryKey] = category
 
-	tfdoc, err := resourceFunc(r.Type())
-	if err != nil {
-		return errors.New(fmt.Sprintf("provider %s with resource %s is not supported on the docs", r.provider.String(), r.Type()))
+	// The resources of an aliased provider
+	// have to reference it explicitly
+	if alias := Alias(r.provider); alias != "" {
+		cfg["provider"] = fmt.Sprintf("${%s.%s}", r.provider.String(), alias)
 	}
-	// This will convert all Category into snake_case
-	cfg[writer.ResourceCategoryKey] = strings.ToLower(name.Delimit(tfdoc.Category, '_'))
 
 	// If it does not have any configName we will generate one
 	// and store it, so net time it'll use that one on any config
 	if r.configName == "" {
-		configName := tag.GetNameFromTag(r.provider.TagKey(), r.data, r.id)
-		if ok, err := w.Has(fmt.Sprintf("%s.%s", r.resourceType, configName)); err != nil {
+		configName, err := r.newConfigName(w)
+		if err != nil {
 			return err
-		} else if ok {
-			configName = pwgen.Alpha(5)
 		}
 
-		err := w.Write(fmt.Sprintf("%s.%s", r.resourceType, configName), cfg)
+		err = w.Write(fmt.Sprintf("%s.%s", r.resourceType, configName), cfg)
 		if err != nil {
 			return err
 		}
@@ -418,6 +495,30 @@ func (r *resource) HCL(w writer.Writer) error {
 	return nil
 }
 
+// newConfigName returns a name for the resource from its tags that is not
+// already on the w. If it is, the alias of the provider is added to it,
+// so the same resource on different regions can be identified, and if
+// it has none or it's also on the w a random one is used
+func (r *resource) newConfigName(w writer.Writer) (string, error) {
+	configName := tag.GetNameFromTag(r.provider.TagKey(), r.data, r.id)
+	if ok, err := w.Has(fmt.Sprintf("%s.%s", r.resourceType, configName)); err != nil {
+		return "", err
+	} else if !ok {
+		return configName, nil
+	}
+
+	if alias := Alias(r.provider); alias != "" {
+		aliasName := fmt.Sprintf("%s_%s", configName, alias)
+		if ok, err := w.Has(fmt.Sprintf("%s.%s", r.resourceType, aliasName)); err != nil {
+			return "", err
+		} else if !ok {
+			return aliasName, nil
+		}
+	}
+
+	return pwgen.Alpha(5), nil
+}
+
 func (r *resource) InstanceInfo() *terraform.InstanceInfo {
 	return &terraform.InstanceInfo{
 		Id:   r.id,
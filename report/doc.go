@@ -0,0 +1,4 @@
+// Package report has the Report of an import
+// with the outcome of each one of the resources
+// found so it can be checked if it was complete
+package report
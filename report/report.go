@@ -0,0 +1,204 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/pkg/errors"
+)
+
+// Status is the outcome of the import of a resource
+type Status string
+
+// List of all the Status a resource can have
+const (
+	StatusImported      Status = "imported"
+	StatusFilteredByTag Status = "filtered_by_tag"
+	StatusAutogenerated Status = "autogenerated"
+	StatusNotImportable Status = "not_importable"
+	StatusReadError     Status = "read_error"
+	StatusAPIError      Status = "api_error"
+
+	// StatusExisting is for the resources that were already
+	// on the existing TFState so they were not imported again
+	StatusExisting Status = "existing"
+
+	// StatusManaged is for the resources that are already
+	// managed by other Terraform configurations so they
+	// were not imported
+	StatusManaged Status = "managed"
+
+	// StatusFilteredByPattern is for the resources that do
+	// not match the ID or name patterns of the filter
+	StatusFilteredByPattern Status = "filtered_by_pattern"
+
+	// StatusFilteredByWhere is for the resources that once
+	// read do not match the where expression of the filter
+	StatusFilteredByWhere Status = "filtered_by_where"
+
+	// StatusNotRead is for the resources that were not read
+	// because the import was stopped, and for the types that
+	// were not listed, in which case the ID is empty
+	StatusNotRead Status = "not_read"
+)
+
+// Resource is the outcome of one resource
+type Resource struct {
+	// Type is the type of the resource (ex: aws_instance)
+	Type string `json:"type"`
+
+	// ID is the ID of the resource, it's empty
+	// if the resources of the Type could not
+	// be listed
+	ID string `json:"id,omitempty"`
+
+	// Address is the address of the resource on
+	// the HCL (ex: aws_instance.front), it's only
+	// set if it was imported
+	Address string `json:"address,omitempty"`
+
+	Status Status `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report holds the outcome of all the resources
+// found on an import.
+// It's concurrently safe
+type Report struct {
+	provider  string
+	resources []Resource
+	mu        sync.Mutex
+}
+
+// New returns a new Report for the provider
+func New(provider string) *Report {
+	return &Report{
+		provider: provider,
+	}
+}
+
+// Add adds the res to the Report
+func (r *Report) Add(res Resource) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.resources = append(r.resources, res)
+}
+
+// AddImported adds the resource of type t and id
+// as imported with the address it has on the HCL
+func (r *Report) AddImported(t, id, address string) {
+	r.Add(Resource{
+		Type:    t,
+		ID:      id,
+		Address: address,
+		Status:  StatusImported,
+	})
+}
+
+// AddError adds the resource of type t and id with
+// the Status that corresponds to the err
+func (r *Report) AddError(t, id string, err error) {
+	r.Add(Resource{
+		Type:   t,
+		ID:     id,
+		Status: ErrorStatus(err),
+		Error:  err.Error(),
+	})
+}
+
+// ErrorStatus returns the Status that corresponds to the err
+func ErrorStatus(err error) Status {
+	switch {
+	case errors.Is(err, errcode.ErrProviderResourceDoNotMatchTag):
+		return StatusFilteredByTag
+	case errors.Is(err, errcode.ErrProviderResourceDoNotMatchPattern):
+		return StatusFilteredByPattern
+	case errors.Is(err, errcode.ErrProviderResourceDoNotMatchWhere):
+		return StatusFilteredByWhere
+	case errors.Is(err, errcode.ErrProviderResourceAutogenerated):
+		return StatusAutogenerated
+	case errors.Is(err, errcode.ErrProviderAPI):
+		return StatusAPIError
+	default:
+		return StatusReadError
+	}
+}
+
+// Resources returns all the resources of the Report
+// sorted by Type and ID
+func (r *Report) Resources() []Resource {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := append([]Resource(nil), r.resources...)
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Type != res[j].Type {
+			return res[i].Type < res[j].Type
+		}
+		return res[i].ID < res[j].ID
+	})
+
+	return res
+}
+
+// Complete checks if none of the resources failed to be
+// imported nor were left unread because the import was
+// stopped. The ones that were filtered are not considered failures
+func (r *Report) Complete() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, res := range r.resources {
+		if res.Status == StatusReadError || res.Status == StatusAPIError || res.Status == StatusNotRead {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Summary returns the number of resources for each Status
+func (r *Report) Summary() map[Status]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := make(map[Status]int)
+	for _, res := range r.resources {
+		s[res.Status]++
+	}
+
+	return s
+}
+
+// report is the JSON representation of the Report
+type report struct {
+	Provider  string         `json:"provider"`
+	Complete  bool           `json:"complete"`
+	Summary   map[Status]int `json:"summary"`
+	Resources []Resource     `json:"resources"`
+}
+
+// Write writes the Report as JSON to w
+func (r *Report) Write(w io.Writer) error {
+	rep := report{
+		Provider:  r.provider,
+		Complete:  r.Complete(),
+		Summary:   r.Summary(),
+		Resources: r.Resources(),
+	}
+	if rep.Resources == nil {
+		rep.Resources = make([]Resource, 0)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(rep); err != nil {
+		return errors.Wrap(err, "unable to write the report")
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package report_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/report"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status report.Status
+	}{
+		{name: "FilteredByTag", err: errors.WithStack(errcode.ErrProviderResourceDoNotMatchTag), status: report.StatusFilteredByTag},
+		{name: "FilteredByPattern", err: errors.WithStack(errcode.ErrProviderResourceDoNotMatchPattern), status: report.StatusFilteredByPattern},
+		{name: "FilteredByWhere", err: errors.WithStack(errcode.ErrProviderResourceDoNotMatchWhere), status: report.StatusFilteredByWhere},
+		{name: "Autogenerated", err: errcode.ErrProviderResourceAutogenerated, status: report.StatusAutogenerated},
+		{name: "APIError", err: errors.Wrap(errcode.ErrProviderAPI, "access denied"), status: report.StatusAPIError},
+		{name: "ReadError", err: errors.New("failed"), status: report.StatusReadError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.status, report.ErrorStatus(tt.err))
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("Complete", func(t *testing.T) {
+		var b bytes.Buffer
+		rep := report.New("aws")
+
+		rep.AddImported("aws_instance", "2", "aws_instance.back")
+		rep.AddImported("aws_instance", "1", "aws_instance.front")
+		rep.AddError("aws_iam_user", "3", errcode.ErrProviderResourceAutogenerated)
+
+		err := rep.Write(&b)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"provider": "aws",
+			"complete": true,
+			"summary": { "imported": 2, "autogenerated": 1 },
+			"resources": [
+				{ "type": "aws_iam_user", "id": "3", "status": "autogenerated", "error": "the resource is autogenerated and should not be imported" },
+				{ "type": "aws_instance", "id": "1", "address": "aws_instance.front", "status": "imported" },
+				{ "type": "aws_instance", "id": "2", "address": "aws_instance.back", "status": "imported" }
+			]
+		}`, b.String())
+	})
+	t.Run("NotComplete", func(t *testing.T) {
+		var b bytes.Buffer
+		rep := report.New("aws")
+
+		rep.AddError("aws_instance", "", errors.Wrap(errcode.ErrProviderAPI, "access denied"))
+
+		err := rep.Write(&b)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"provider": "aws",
+			"complete": false,
+			"summary": { "api_error": 1 },
+			"resources": [
+				{ "type": "aws_instance", "status": "api_error", "error": "access denied: error while requesting the provider APIs" }
+			]
+		}`, b.String())
+	})
+	t.Run("NotCompleteWithNotRead", func(t *testing.T) {
+		var b bytes.Buffer
+		rep := report.New("aws")
+
+		rep.AddImported("aws_instance", "1", "aws_instance.front")
+		rep.Add(report.Resource{Type: "aws_instance", ID: "2", Status: report.StatusNotRead})
+		rep.Add(report.Resource{Type: "aws_iam_user", Status: report.StatusNotRead})
+
+		err := rep.Write(&b)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"provider": "aws",
+			"complete": false,
+			"summary": { "imported": 1, "not_read": 2 },
+			"resources": [
+				{ "type": "aws_iam_user", "status": "not_read" },
+				{ "type": "aws_instance", "id": "1", "address": "aws_instance.front", "status": "imported" },
+				{ "type": "aws_instance", "id": "2", "status": "not_read" }
+			]
+		}`, b.String())
+	})
+}
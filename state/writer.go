@@ -86,9 +86,11 @@ func (w *Writer) Write(key string, value interface{}) error {
 		},
 	}
 
+	pv := r.Provider()
 	absProviderConf := addrs.AbsProviderConfig{
 		Module:   nil,
-		Provider: addrs.NewDefaultProvider(r.Provider().String()),
+		Provider: addrs.NewDefaultProvider(pv.String()),
+		Alias:    provider.Alias(pv),
 	}
 
 	zt, err := util.HashicorpToZclonfType(r.ImpliedType())
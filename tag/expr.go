@@ -0,0 +1,498 @@
+package tag
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+// Expr is a boolean expression over the tags of a resource
+// (ex: env in (prod,staging) AND NOT owner:legacy AND has(cost-center))
+type Expr interface {
+	// Match checks if the tags match the expression
+	Match(tags map[string]string) bool
+
+	// String returns the expression with
+	// the format of Parse
+	String() string
+}
+
+// Condition is a condition that all the tags that match an Expr meet,
+// so it can be used as a filter of the provider before reading
+// the resources. The tag Name has to exist and, if there are
+// Values, its value has to match any of them
+type Condition struct {
+	Name string
+
+	// Values can be globs (ex: platform-*)
+	Values []string
+}
+
+// Conditions returns the Conditions that the tags that match the e
+// meet, only the ones of the top level AND can be known. If the
+// e does not have any or it's nil, it's empty
+func Conditions(e Expr) []Condition {
+	switch ex := e.(type) {
+	case andExpr:
+		var cs []Condition
+		for _, se := range ex {
+			cs = append(cs, Conditions(se)...)
+		}
+		return cs
+	case valueExpr:
+		return []Condition{{Name: ex.name, Values: ex.values}}
+	case hasExpr:
+		return []Condition{{Name: string(ex)}}
+	default:
+		return nil
+	}
+}
+
+// IsGlob checks if the v has any wildcard
+// of the globs: '*' for any characters
+// and '?' for one character
+func IsGlob(v string) bool {
+	return strings.ContainsAny(v, "*?")
+}
+
+// andExpr matches if all of them match
+type andExpr []Expr
+
+func (e andExpr) Match(tags map[string]string) bool {
+	for _, se := range e {
+		if !se.Match(tags) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e andExpr) String() string { return joinExprs(e, " AND ") }
+
+// orExpr matches if any of them matches
+type orExpr []Expr
+
+func (e orExpr) Match(tags map[string]string) bool {
+	for _, se := range e {
+		if se.Match(tags) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e orExpr) String() string { return joinExprs(e, " OR ") }
+
+// notExpr matches if the Expr does not match
+type notExpr struct {
+	Expr
+}
+
+func (e notExpr) Match(tags map[string]string) bool { return !e.Expr.Match(tags) }
+func (e notExpr) String() string                    { return "NOT " + e.Expr.String() }
+
+// valueExpr matches if the tag name has any of the
+// values, which can be globs (NAME:VALUE or NAME in (VALUE,...))
+type valueExpr struct {
+	name   string
+	values []string
+
+	// globs has the compiled glob of each one of
+	// the values, nil for the ones that are not
+	globs []*regexp.Regexp
+}
+
+// newValueExpr returns the valueExpr of the
+// name and values with their globs compiled
+func newValueExpr(name string, values []string) valueExpr {
+	globs := make([]*regexp.Regexp, len(values))
+	for i, v := range values {
+		if IsGlob(v) {
+			globs[i] = compileGlob(v)
+		}
+	}
+	return valueExpr{name: name, values: values, globs: globs}
+}
+
+func (e valueExpr) Match(tags map[string]string) bool {
+	v, ok := tags[e.name]
+	if !ok {
+		return false
+	}
+	for i, ev := range e.values {
+		if g := e.globs[i]; g != nil {
+			if g.MatchString(v) {
+				return true
+			}
+		} else if ev == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e valueExpr) String() string {
+	if len(e.values) == 1 {
+		return fmt.Sprintf("%s:%s", e.name, e.values[0])
+	}
+	return fmt.Sprintf("%s in (%s)", e.name, strings.Join(e.values, ","))
+}
+
+// hasExpr matches if the tag
+// exists with any value
+type hasExpr string
+
+func (e hasExpr) Match(tags map[string]string) bool {
+	_, ok := tags[string(e)]
+	return ok
+}
+
+func (e hasExpr) String() string { return fmt.Sprintf("has(%s)", string(e)) }
+
+func joinExprs(es []Expr, sep string) string {
+	ss := make([]string, 0, len(es))
+	for _, e := range es {
+		ss = append(ss, e.String())
+	}
+	return "(" + strings.Join(ss, sep) + ")"
+}
+
+// compileGlob returns the regexp that matches the glob g
+func compileGlob(g string) *regexp.Regexp {
+	re := regexp.QuoteMeta(g)
+	re = strings.ReplaceAll(re, `\*`, ".*")
+	re = strings.ReplaceAll(re, `\?`, ".")
+	return regexp.MustCompile("^" + re + "$")
+}
+
+// Parse parses the expression s of tags, which can have:
+// * NAME:VALUE or NAME = VALUE the tag NAME has the VALUE, which can be a glob (ex: team:platform-*)
+// * NAME != VALUE the tag NAME does not have the VALUE
+// * NAME in (VALUE,...) the tag NAME has any of the VALUEs
+// * has(NAME) the tag NAME exists
+// * AND (or ','), OR and NOT, on this order of precedence, and parentheses
+// The names and values can be quoted with '"' if they have any of
+// the characters used by the expressions (ex: "a,b":"c d")
+func Parse(s string) (Expr, error) {
+	p := parser{s: s, tokens: scan(s)}
+	if len(p.tokens) == 0 {
+		return nil, errors.Wrapf(errcode.ErrTagInvalidExpression, "the expression is empty")
+	}
+
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, errors.Wrapf(err, "on %q", s)
+	}
+	if t, ok := p.peek(); ok {
+		return nil, errors.Wrapf(errcode.ErrTagInvalidExpression, "unexpected %q on %q", t.raw, s)
+	}
+
+	return e, nil
+}
+
+// tokenKind is the kind of a token of an expression
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenOpen
+	tokenClose
+	tokenComma
+)
+
+// token of an expression, the raw is as it is on the
+// expression and the text is without the quotes
+type token struct {
+	kind       tokenKind
+	raw, text  string
+	start, end int
+	quoted     bool
+}
+
+// keyword checks if the t is the keyword k
+func (t token) keyword(k string) bool {
+	return t.kind == tokenWord && !t.quoted && strings.EqualFold(t.text, k)
+}
+
+// scan splits the s on tokens, the words are separated
+// by spaces, parentheses and commas out of the quotes
+func scan(s string) []token {
+	var tokens []token
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenOpen, raw: "(", start: i, end: i + 1})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenClose, raw: ")", start: i, end: i + 1})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, raw: ",", start: i, end: i + 1})
+			i++
+		default:
+			t := token{kind: tokenWord, start: i}
+			var text strings.Builder
+			var inQuotes bool
+		word:
+			for ; i < len(s); i++ {
+				c := s[i]
+				switch {
+				case c == '"':
+					inQuotes = !inQuotes
+					t.quoted = true
+					continue
+				case inQuotes:
+				case c == ' ' || c == '\t' || c == '\n' || c == '(' || c == ')' || c == ',':
+					break word
+				}
+				text.WriteByte(c)
+			}
+			t.end = i
+			t.raw = s[t.start:t.end]
+			t.text = text.String()
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
+
+// parser of the expression s
+type parser struct {
+	s      string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *parser) next() (token, bool) {
+	t, ok := p.peek()
+	if ok {
+		p.pos++
+	}
+	return t, ok
+}
+
+func (p *parser) expect(k tokenKind, what string) (token, error) {
+	t, ok := p.next()
+	if !ok {
+		return t, errors.Wrapf(errcode.ErrTagInvalidExpression, "expected %s at the end", what)
+	}
+	if t.kind != k {
+		return t, errors.Wrapf(errcode.ErrTagInvalidExpression, "expected %s and found %q", what, t.raw)
+	}
+	return t, nil
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	e, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	es := orExpr{e}
+	for t, ok := p.peek(); ok && t.keyword("or"); t, ok = p.peek() {
+		p.next()
+		e, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		es = append(es, e)
+	}
+
+	if len(es) == 1 {
+		return es[0], nil
+	}
+	return es, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	e, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	es := andExpr{e}
+	for t, ok := p.peek(); ok && (t.keyword("and") || t.kind == tokenComma); t, ok = p.peek() {
+		p.next()
+		e, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		es = append(es, e)
+	}
+
+	if len(es) == 1 {
+		return es[0], nil
+	}
+	return es, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if t, ok := p.peek(); ok && t.keyword("not") {
+		p.next()
+		e, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{e}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	t, ok := p.next()
+	if !ok {
+		return nil, errors.Wrapf(errcode.ErrTagInvalidExpression, "expected a tag at the end")
+	}
+
+	switch t.kind {
+	case tokenOpen:
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenClose, "')'"); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case tokenWord:
+	default:
+		return nil, errors.Wrapf(errcode.ErrTagInvalidExpression, "expected a tag and found %q", t.raw)
+	}
+
+	if nt, ok := p.peek(); ok && t.keyword("has") && nt.kind == tokenOpen {
+		p.next()
+		n, err := p.expect(tokenWord, "the tag name")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenClose, "')'"); err != nil {
+			return nil, err
+		}
+		return hasExpr(n.text), nil
+	}
+
+	if nt, ok := p.peek(); ok && nt.keyword("in") {
+		p.next()
+		return p.parseIn(t.text)
+	}
+
+	// The operator can be on the same word than the
+	// name (ex: env:prod) or on the next one (ex: env = prod)
+	raw, last := t.raw, t
+	if _, op, _ := splitOperator(raw); op == "" {
+		if nt, ok := p.peek(); ok && nt.kind == tokenWord && !nt.quoted && hasOperatorPrefix(nt.raw) {
+			p.next()
+			raw, last = raw+nt.raw, nt
+		}
+	}
+
+	name, op, value := splitOperator(raw)
+	if op == "" || name == "" {
+		return nil, errors.Wrapf(errcode.ErrTagInvalidExpression, "expected the format 'NAME:VALUE' or 'NAME = VALUE' and found %q", raw)
+	}
+
+	// The value of the '=' and '!=' can
+	// be on the next word (ex: env = prod)
+	if value == "" && op != ":" && !strings.HasSuffix(raw, `""`) {
+		v, err := p.expect(tokenWord, "a value")
+		if err != nil {
+			return nil, err
+		}
+		value, last = v.text, v
+	}
+	value += p.parseValue(last)
+
+	e := newValueExpr(name, []string{value})
+	if op == "!=" {
+		return notExpr{e}, nil
+	}
+	return e, nil
+}
+
+// operators are the ones that can be used between
+// the name and the value, on the order they are checked
+var operators = []string{"!=", "=", ":"}
+
+// splitOperator splits the raw by the first operator out of the quotes,
+// it returns the name and the value without quotes. If it has
+// no operator the op is empty
+func splitOperator(raw string) (name, op, value string) {
+	var inQuotes bool
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '"' {
+			inQuotes = !inQuotes
+			continue
+		}
+		if inQuotes {
+			continue
+		}
+		for _, o := range operators {
+			if strings.HasPrefix(raw[i:], o) {
+				return strings.ReplaceAll(raw[:i], `"`, ""), o, strings.ReplaceAll(raw[i+len(o):], `"`, "")
+			}
+		}
+	}
+	return "", "", ""
+}
+
+// hasOperatorPrefix checks if the raw starts with '=' or '!='
+func hasOperatorPrefix(raw string) bool {
+	return strings.HasPrefix(raw, "=") || strings.HasPrefix(raw, "!=")
+}
+
+// parseIn parses the values of NAME in (VALUE,...)
+func (p *parser) parseIn(name string) (Expr, error) {
+	if _, err := p.expect(tokenOpen, "'('"); err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for {
+		t, err := p.expect(tokenWord, "a value")
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, t.text+p.parseValue(t))
+
+		t, ok := p.next()
+		if !ok {
+			return nil, errors.Wrapf(errcode.ErrTagInvalidExpression, "expected ')' at the end")
+		}
+		if t.kind == tokenClose {
+			break
+		}
+		if t.kind != tokenComma {
+			return nil, errors.Wrapf(errcode.ErrTagInvalidExpression, "expected ',' or ')' and found %q", t.raw)
+		}
+	}
+
+	return newValueExpr(name, values), nil
+}
+
+// parseValue returns the rest of the value that started on the t, as the
+// values can have spaces (ex: Name:My Server), which are the next
+// words that are not keywords with the spaces between them
+func (p *parser) parseValue(t token) string {
+	var b strings.Builder
+	prev := t
+	for nt, ok := p.peek(); ok && nt.kind == tokenWord && !nt.keyword("and") && !nt.keyword("or"); nt, ok = p.peek() {
+		p.next()
+		b.WriteString(p.s[prev.end:nt.start])
+		b.WriteString(nt.text)
+		prev = nt
+	}
+	return b.String()
+}
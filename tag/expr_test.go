@@ -0,0 +1,146 @@
+package tag_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/tag"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Expr   string
+		String string
+		Match  []map[string]string
+		NMatch []map[string]string
+	}{
+		{
+			Name:   "Value",
+			Expr:   "env:prod",
+			String: "env:prod",
+			Match:  []map[string]string{{"env": "prod"}, {"env": "prod", "team": "a"}},
+			NMatch: []map[string]string{{"env": "staging"}, {"team": "a"}, nil},
+		},
+		{
+			Name:   "ValueWithSpaces",
+			Expr:   "Name:My Server AND env:prod",
+			String: "(Name:My Server AND env:prod)",
+			Match:  []map[string]string{{"Name": "My Server", "env": "prod"}},
+			NMatch: []map[string]string{{"Name": "My", "env": "prod"}},
+		},
+		{
+			Name:   "Quoted",
+			Expr:   `"a,b":"c and d"`,
+			String: "a,b:c and d",
+			Match:  []map[string]string{{"a,b": "c and d"}},
+		},
+		{
+			Name:   "Glob",
+			Expr:   "team:platform-*",
+			String: "team:platform-*",
+			Match:  []map[string]string{{"team": "platform-"}, {"team": "platform-core"}},
+			NMatch: []map[string]string{{"team": "data-platform-core"}},
+		},
+		{
+			Name:   "Equal",
+			Expr:   `instance_type = "t2.*" AND vpc_id=vpc-123`,
+			String: "(instance_type:t2.* AND vpc_id:vpc-123)",
+			Match:  []map[string]string{{"instance_type": "t2.micro", "vpc_id": "vpc-123"}},
+			NMatch: []map[string]string{{"instance_type": "m5.large", "vpc_id": "vpc-123"}},
+		},
+		{
+			Name:   "NotEqual",
+			Expr:   "engine != postgres AND engine !=mysql",
+			String: "(NOT engine:postgres AND NOT engine:mysql)",
+			Match:  []map[string]string{{"engine": "aurora"}, nil},
+			NMatch: []map[string]string{{"engine": "postgres"}, {"engine": "mysql"}},
+		},
+		{
+			Name:   "EqualEmpty",
+			Expr:   `description = ""`,
+			String: "description:",
+			Match:  []map[string]string{{"description": ""}},
+			NMatch: []map[string]string{{"description": "a"}, nil},
+		},
+		{
+			Name:   "In",
+			Expr:   "env in (prod, staging)",
+			String: "env in (prod,staging)",
+			Match:  []map[string]string{{"env": "prod"}, {"env": "staging"}},
+			NMatch: []map[string]string{{"env": "dev"}},
+		},
+		{
+			Name:   "Has",
+			Expr:   "has(cost-center)",
+			String: "has(cost-center)",
+			Match:  []map[string]string{{"cost-center": ""}, {"cost-center": "1"}},
+			NMatch: []map[string]string{{"env": "prod"}},
+		},
+		{
+			Name:   "CommaIsAnd",
+			Expr:   "env:prod,team:a",
+			String: "(env:prod AND team:a)",
+			Match:  []map[string]string{{"env": "prod", "team": "a"}},
+			NMatch: []map[string]string{{"env": "prod"}, {"team": "a"}},
+		},
+		{
+			Name:   "Precedence",
+			Expr:   "env:prod or env:staging and not owner:legacy",
+			String: "(env:prod OR (env:staging AND NOT owner:legacy))",
+			Match:  []map[string]string{{"env": "prod", "owner": "legacy"}, {"env": "staging"}},
+			NMatch: []map[string]string{{"env": "staging", "owner": "legacy"}},
+		},
+		{
+			Name:   "Parentheses",
+			Expr:   "(env:prod OR env:staging) AND NOT owner:legacy",
+			String: "((env:prod OR env:staging) AND NOT owner:legacy)",
+			Match:  []map[string]string{{"env": "prod"}, {"env": "staging", "owner": "me"}},
+			NMatch: []map[string]string{{"env": "prod", "owner": "legacy"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			e, err := tag.Parse(tt.Expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.String, e.String())
+			for _, m := range tt.Match {
+				assert.True(t, e.Match(m), "%v", m)
+			}
+			for _, m := range tt.NMatch {
+				assert.False(t, e.Match(m), "%v", m)
+			}
+		})
+	}
+
+	for _, s := range []string{"", "env", ":prod", "env:prod AND", "(env:prod", "env:prod)", "env in prod", "env in (prod", "has(env", "NOT", "env:prod OR OR env:dev", "env =", "= prod"} {
+		t.Run("Error_"+s, func(t *testing.T) {
+			_, err := tag.Parse(s)
+			assert.Equal(t, errcode.ErrTagInvalidExpression, errors.Cause(err))
+		})
+	}
+}
+
+func TestConditions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		e, err := tag.Parse("env in (prod,staging) AND has(cost-center) AND NOT owner:legacy AND (team:a OR team:b)")
+		require.NoError(t, err)
+		assert.Equal(t, []tag.Condition{
+			{Name: "env", Values: []string{"prod", "staging"}},
+			{Name: "cost-center"},
+		}, tag.Conditions(e))
+	})
+	t.Run("SuccessOr", func(t *testing.T) {
+		e, err := tag.Parse("env:prod OR env:staging")
+		require.NoError(t, err)
+		assert.Empty(t, tag.Conditions(e))
+	})
+	t.Run("SuccessNil", func(t *testing.T) {
+		assert.Empty(t, tag.Conditions(nil))
+	})
+}
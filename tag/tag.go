@@ -45,6 +45,22 @@ func (t Tag) ToEC2Filter() *ec2.Filter {
 	}
 }
 
+// ToEC2Filter transforms the Condition to a ec2.Filter
+// to use on AWS filters, if it has no Values it
+// filters by the existence of the tag
+func (c Condition) ToEC2Filter() *ec2.Filter {
+	if len(c.Values) == 0 {
+		return &ec2.Filter{
+			Name:   aws.String("tag-key"),
+			Values: []*string{aws.String(c.Name)},
+		}
+	}
+	return &ec2.Filter{
+		Name:   aws.String(fmt.Sprintf("tag:%s", c.Name)),
+		Values: aws.StringSlice(c.Values),
+	}
+}
+
 // ToRDSFilter transforms the Tag to a rds.Filter
 // to use on AWS filters
 func (t Tag) ToRDSFilter() *rds.Filter {
@@ -97,8 +113,34 @@ func isValidResourceName(name string) bool {
 	return nameRegexp.MatchString(name)
 }
 
+// GetTags returns all the tags of the srd, the ones of the
+// tagKey (ex: tags) and the other tags (see GetOtherTags)
+func GetTags(provider, tagKey string, srd *schema.ResourceData) map[string]string {
+	tags := getOtherTags(provider, srd)
+	if v, ok := srd.GetOk(tagKey); ok {
+		if ts, ok := v.(map[string]interface{}); ok {
+			for k, tv := range ts {
+				if s, ok := tv.(string); ok {
+					tags[k] = s
+				}
+			}
+		}
+	}
+	return tags
+}
+
 // GetOtherTags used to check other possible tag attributes on resources
 func GetOtherTags(provider string, srd *schema.ResourceData, filterTag Tag) (string, bool) {
+	// return the tag value if key (tag name) found
+	if val, ok := getOtherTags(provider, srd)[filterTag.Name]; ok {
+		return val, true
+	}
+
+	return "", false
+}
+
+// getOtherTags returns the tags of the special tag attributes of the srd
+func getOtherTags(provider string, srd *schema.ResourceData) map[string]string {
 	// keep the same logic as r.data.GetOk
 	otherTagsMap := make(map[string]string)
 
@@ -123,10 +165,5 @@ func GetOtherTags(provider string, srd *schema.ResourceData, filterTag Tag) (str
 		}
 	}
 
-	// return the tag value if key (tag name) found
-	if val, ok := otherTagsMap[filterTag.Name]; ok {
-		return val, true
-	}
-
-	return "", false
+	return otherTagsMap
 }
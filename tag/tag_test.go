@@ -24,6 +24,23 @@ func TestToEC2Filer(t *testing.T) {
 	})
 }
 
+func TestConditionToEC2Filer(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		c := tag.Condition{Name: "tag-name", Values: []string{"tag-value", "tag-*"}}
+		assert.Equal(t, &ec2.Filter{
+			Name:   aws.String("tag:tag-name"),
+			Values: []*string{aws.String("tag-value"), aws.String("tag-*")},
+		}, c.ToEC2Filter())
+	})
+	t.Run("SuccessWithoutValues", func(t *testing.T) {
+		c := tag.Condition{Name: "tag-name"}
+		assert.Equal(t, &ec2.Filter{
+			Name:   aws.String("tag-key"),
+			Values: []*string{aws.String("tag-name")},
+		}, c.ToEC2Filter())
+	})
+}
+
 func TestToNeptuneFiler(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		tt := tag.Tag{Name: "tag-name", Value: "tag-value"}
@@ -177,6 +194,17 @@ func TestGetNameFromTag(t *testing.T) {
 	}
 }
 
+func TestGetTags(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		srd := createSRD(t, "tags", "env", "prod")
+		assert.Equal(t, map[string]string{"env": "prod"}, tag.GetTags("aws", "tags", srd))
+	})
+	t.Run("SuccessWithoutTags", func(t *testing.T) {
+		srd := createSRD(t, "noTags", "env", "prod")
+		assert.Equal(t, map[string]string{}, tag.GetTags("aws", "tags", srd))
+	})
+}
+
 // createSRD creates a schema.ResourceData with a
 // 'schemaKey' of TypeMap with a 'tagKey' with 'tagValue'
 func createSRD(t *testing.T, schemaKey, tagKey, tagValue string) *schema.ResourceData {
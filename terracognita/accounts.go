@@ -0,0 +1,44 @@
+package terracognita
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/aws"
+)
+
+// runAWSAccounts runs the c on each one of the AWS Accounts assuming the
+// AWS RoleName on them. The outputs of each account are written on
+// their own directory, see accountConfig. If one of the accounts
+// fails the rest are still imported
+func runAWSAccounts(ctx context.Context, c Config) (*Result, error) {
+	accounts := c.AWS.Accounts
+	if len(accounts) == 1 && accounts[0] == AllAWSAccounts {
+		var err error
+		accounts, err = aws.Accounts(ctx, c.AWS.options())
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list the AWS accounts of the Organization")
+		}
+	}
+
+	results, err := runEach(ctx, "account", accounts, func(a string) (Config, error) {
+		return accountConfig(c, a)
+	})
+
+	return &Result{Provider: "aws", Accounts: results}, err
+}
+
+// accountConfig returns the Config to import from the AWS account a, which
+// assumes the AWS RoleName on it and has the outputs of the c on a directory
+// named as the account next to them (ex: out/main.tf is out/<a>/main.tf)
+func accountConfig(c Config, a string) (Config, error) {
+	ac := *c.AWS
+	ac.Accounts = nil
+	ac.RoleName = ""
+	ac.RoleARN = fmt.Sprintf("arn:aws:iam::%s:role/%s", a, c.AWS.RoleName)
+	c.AWS = &ac
+
+	return outputsIn(c, a)
+}
@@ -0,0 +1,44 @@
+package terracognita
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terracognita-accounts")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c := Config{
+		AWS: &AWSConfig{
+			Region:   "eu-west-1",
+			Accounts: []string{"123456789012"},
+			RoleName: "terracognita",
+		},
+		HCL:     filepath.Join(dir, "hcl"),
+		TFState: filepath.Join(dir, "terraform.tfstate"),
+		Report:  filepath.Join(dir, "report.json"),
+	}
+
+	ac, err := accountConfig(c, "123456789012")
+	require.NoError(t, err)
+
+	assert.Equal(t, &AWSConfig{
+		Region:  "eu-west-1",
+		RoleARN: "arn:aws:iam::123456789012:role/terracognita",
+	}, ac.AWS)
+	assert.Equal(t, filepath.Join(dir, "123456789012", "hcl"), ac.HCL)
+	assert.Equal(t, filepath.Join(dir, "123456789012", "terraform.tfstate"), ac.TFState)
+	assert.Equal(t, filepath.Join(dir, "123456789012", "report.json"), ac.Report)
+	assert.DirExists(t, filepath.Join(dir, "123456789012"))
+
+	// The c is not changed
+	assert.Equal(t, []string{"123456789012"}, c.AWS.Accounts)
+	assert.Equal(t, filepath.Join(dir, "hcl"), c.HCL)
+}
@@ -0,0 +1,412 @@
+package terracognita
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/cycloidio/terracognita/event"
+	"github.com/cycloidio/terracognita/graph"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/tag"
+)
+
+// Config is the configuration of a Run, at least one of the Provider, AWS,
+// Google, AzureRM or VSphere has to be set. If more than one is set all of
+// them are imported, on that order, to the same outputs
+type Config struct {
+	// Provider is an already initialized Provider to import from
+	Provider provider.Provider
+
+	AWS     *AWSConfig
+	Google  *GoogleConfig
+	AzureRM *AzureRMConfig
+	VSphere *VSphereConfig
+
+	// Include, Exclude and Targets are the filters
+	// of the resources to import, the format is the
+	// same as the one on the filter.Filter
+	Include []string
+	Exclude []string
+	Targets []string
+
+	// Tags are the tags (or labels) the resources
+	// need to have to be imported
+	Tags []tag.Tag
+
+	// TagsExpr is an expression of the tags (or labels) that
+	// the resources need to match, along with the Tags, to be
+	// imported. It can be parsed with tag.Parse
+	TagsExpr tag.Expr
+
+	// IDPattern and NamePattern are regular expressions that the
+	// ID and the name of the resources need to match to be imported,
+	// the name is the 'name' attribute or the 'Name' tag if the
+	// type has no 'name' (see provider.ResourceName)
+	IDPattern   string
+	NamePattern string
+
+	// Where is an expression of the attributes that the resources
+	// need to match once read to be imported, with the same
+	// format as the TagsExpr (ex: instance_type = "t2.*")
+	Where tag.Expr
+
+	// HCL is the output file or directory of the HCL.
+	// If it's a directory it'll be emptied before importing
+	HCL string
+
+	// TFState is the output file of the TFState
+	TFState string
+
+	// ImportBlocks generates, instead of the TFState, an imports.tf
+	// with the Terraform 'import {}' block of each resource. It's
+	// written on the same directory as the HCL or on the Module
+	ImportBlocks bool
+
+	// ExistingState is the TFState file of a previous import, the
+	// resources on it are not imported again and keep the same address,
+	// so only the new ones are imported. It can be the same file as the TFState
+	ExistingState string
+
+	// ManagedStates are TFState files, or directories with them, of
+	// resources already managed by other Terraform configurations,
+	// the resources on them are not imported
+	ManagedStates []string
+
+	// Module is the output directory of the HCL in module format,
+	// if set the HCL is ignored. It'll be emptied before importing
+	Module string
+
+	// ModuleVariables is the list of attributes, by resource
+	// type, to use as variables when building the Module
+	ModuleVariables map[string][]string
+
+	// Interpolate activates the interpolation for the HCL and
+	// the dependencies building for the TFState
+	Interpolate bool
+
+	// HCLProviderBlock generates the 'provider {}' block
+	// for the imported provider
+	HCLProviderBlock bool
+
+	// Journal is the file on which each imported resource is recorded
+	// so the import can be resumed from it. If Resume is set, the import
+	// continues from the already existing Journal
+	Journal string
+	Resume  bool
+
+	// Report is the file on which the report with the
+	// outcome of each resource is written as JSON
+	Report string
+
+	// Graph is the file on which the graph of the imported resources
+	// is written, the format depends on the extension: .dot, .mmd or .json
+	Graph string
+
+	// Events is where the progress of the import is sent
+	Events event.Handler
+
+	// RateLimits are the requests per second to each service of the
+	// providers (ex: iam), set over the defaults of each provider.
+	// They can be parsed with util.ParseRateLimits
+	RateLimits map[string]float64
+
+	// The rest of the options are the same as the
+	// ones on the provider.ImportOptions
+
+	Parallelism     int
+	DryRun          bool
+	Strict          bool
+	MaxErrors       int
+	MaxErrorRatio   float64
+	ResourceTimeout time.Duration
+	MaxRetries      int
+	RetryMaxWait    time.Duration
+}
+
+const (
+	// AllAWSRegions is the value of the AWSConfig.Regions
+	// to import from all the enabled regions
+	AllAWSRegions = "all"
+
+	// AllAWSAccounts is the value of the AWSConfig.Accounts to
+	// import from all the accounts of the Organization
+	AllAWSAccounts = "all"
+
+	// AllAzureRMSubscriptions is the value of the AzureRMConfig.Subscriptions
+	// to import from all the subscriptions accessible with the credentials
+	AllAzureRMSubscriptions = "all"
+)
+
+// AWSConfig has the credentials of AWS, if the AccessKey and SecretKey
+// are not set they are read from the credentials chain of the AWS SDK
+// (ENV, SharedCredentialsFile with the Profile, SSO, web identity, ECS/EC2 roles)
+type AWSConfig struct {
+	AccessKey    string `yaml:"access_key"`
+	SecretKey    string `yaml:"secret_key"`
+	SessionToken string `yaml:"session_token"`
+	Region       string `yaml:"region"`
+
+	// Regions to import from instead of the Region, or
+	// AllAWSRegions for all the enabled ones. Each one has
+	// its 'provider' block with the region as alias
+	Regions []string `yaml:"regions"`
+
+	// RoleARN is the role to assume with the credentials, with
+	// the ExternalID and MFASerial if the role requires them
+	RoleARN    string `yaml:"role_arn"`
+	ExternalID string `yaml:"external_id"`
+	MFASerial  string `yaml:"mfa_serial"`
+
+	// Accounts to import from, or AllAWSAccounts for all the
+	// ones of the Organization, assuming the RoleName on each
+	// one of them. Each account is imported on its own outputs
+	Accounts []string `yaml:"accounts"`
+	RoleName string   `yaml:"role_name"`
+
+	SharedCredentialsFile string `yaml:"shared_credentials_file"`
+	Profile               string `yaml:"profile"`
+}
+
+// GoogleConfig has the credentials of Google
+type GoogleConfig struct {
+	// Credentials is the path to the JSON credentials
+	Credentials string `yaml:"credentials"`
+	Project     string `yaml:"project"`
+	Region      string `yaml:"region"`
+
+	// Regions to import from instead of the Region. Each
+	// one has its 'provider' block with the region as alias
+	Regions []string `yaml:"regions"`
+
+	// Projects to import from instead of the Project, or the Parent
+	// (folders/<id> or organizations/<id>) to import from all its
+	// active projects. Each project is imported on its own outputs
+	// and has its 'provider' block with the project as alias
+	Projects []string `yaml:"projects"`
+	Parent   string   `yaml:"parent"`
+
+	// alias of the providers when importing
+	// one of the Projects, see projectConfig
+	alias string
+
+	// MaxResults to fetch when pagination is used
+	MaxResults uint64 `yaml:"max_results"`
+}
+
+// AzureRMConfig has the credentials of AzureRM
+type AzureRMConfig struct {
+	ClientID           string   `yaml:"client_id"`
+	ClientSecret       string   `yaml:"client_secret"`
+	Environment        string   `yaml:"environment"`
+	ResourceGroupNames []string `yaml:"resource_group_names"`
+	SubscriptionID     string   `yaml:"subscription_id"`
+	TenantID           string   `yaml:"tenant_id"`
+
+	// AllResourceGroups imports from all the resource
+	// groups of the subscription instead of the ResourceGroupNames
+	AllResourceGroups bool `yaml:"all_resource_groups"`
+
+	// Subscriptions to import from instead of the SubscriptionID, or
+	// AllAzureRMSubscriptions for all the accessible ones. Each
+	// subscription is imported on its own outputs and has its
+	// 'provider' block with the subscription as alias
+	Subscriptions []string `yaml:"subscriptions"`
+
+	// alias of the provider when importing one
+	// of the Subscriptions, see subscriptionConfig
+	alias string
+}
+
+// VSphereConfig has the credentials of vSphere
+type VSphereConfig struct {
+	SoapURL       string `yaml:"soap_url"`
+	Username      string `yaml:"username"`
+	Password      string `yaml:"password"`
+	VSphereServer string `yaml:"vsphere_server"`
+	Insecure      bool   `yaml:"insecure"`
+}
+
+// ProvidersConfig has the configuration of the providers
+// of a Config, it can be read from a file with ReadProvidersConfig
+type ProvidersConfig struct {
+	AWS     *AWSConfig     `yaml:"aws"`
+	Google  *GoogleConfig  `yaml:"google"`
+	AzureRM *AzureRMConfig `yaml:"azurerm"`
+	VSphere *VSphereConfig `yaml:"vsphere"`
+}
+
+// Validate checks that the Config has at least
+// one provider and the outputs needed
+func (c Config) Validate() error {
+	if c.providers() == 0 {
+		return errors.New("at least one provider has to be configured")
+	}
+
+	if !c.DryRun && c.HCL == "" && c.TFState == "" && c.Module == "" {
+		return errors.New("one of Module, HCL or TFState is required")
+	}
+
+	if c.ImportBlocks {
+		if c.TFState != "" {
+			return errors.New("the ImportBlocks are generated instead of the TFState so both can not be used")
+		}
+		if c.HCL == "" && c.Module == "" {
+			return errors.New("one of Module or HCL is required to generate the ImportBlocks")
+		}
+	}
+
+	if c.AWS != nil && (len(c.AWS.Regions) > 1 || (len(c.AWS.Regions) == 1 && c.AWS.Regions[0] == AllAWSRegions)) {
+		// The resources reference the 'provider' block of their
+		// region, which is passed to the module if it's used
+		if (c.HCL != "" || c.Module != "") && !c.HCLProviderBlock {
+			return errors.New("the HCLProviderBlock is required to use the AWS Regions")
+		}
+	}
+
+	if c.Google != nil && (len(c.Google.Regions) > 1 || len(c.Google.Projects) != 0 || c.Google.Parent != "") {
+		// The resources reference the 'provider' block of their project
+		// or region, which is passed to the module if it's used, and
+		// each project is written on its own module
+		if (c.HCL != "" || c.Module != "") && !c.HCLProviderBlock {
+			return errors.New("the HCLProviderBlock is required to use the Google Projects, Parent and Regions")
+		}
+	}
+
+	if c.Google != nil && (len(c.Google.Projects) != 0 || c.Google.Parent != "") {
+		if len(c.Google.Projects) != 0 && c.Google.Parent != "" {
+			return errors.New("only one of the Google Projects or Parent can be used")
+		}
+		if c.Google.Parent != "" && !strings.HasPrefix(c.Google.Parent, "folders/") && !strings.HasPrefix(c.Google.Parent, "organizations/") {
+			return errors.Errorf("invalid Google Parent %q, it has to be 'folders/<id>' or 'organizations/<id>'", c.Google.Parent)
+		}
+		if c.providers() != 1 {
+			return errors.New("the Google Projects can only be imported without other providers")
+		}
+	}
+
+	if c.AzureRM != nil && len(c.AzureRM.Subscriptions) != 0 {
+		if c.AzureRM.SubscriptionID != "" {
+			return errors.New("only one of the AzureRM SubscriptionID or Subscriptions can be used")
+		}
+		// The resources reference the 'provider' block of their
+		// subscription, which is passed to the module if it's used,
+		// and each subscription is written on its own module
+		if (c.HCL != "" || c.Module != "") && !c.HCLProviderBlock {
+			return errors.New("the HCLProviderBlock is required to use the AzureRM Subscriptions")
+		}
+		if c.providers() != 1 {
+			return errors.New("the AzureRM Subscriptions can only be imported without other providers")
+		}
+	}
+
+	if c.AWS != nil && len(c.AWS.Accounts) != 0 {
+		if c.AWS.RoleName == "" {
+			return errors.New("the AWS RoleName is required to import from the AWS Accounts")
+		}
+		if c.AWS.RoleARN != "" {
+			return errors.New("the AWS RoleARN can not be used with the AWS Accounts as the RoleName is assumed on each one")
+		}
+		if c.providers() != 1 {
+			return errors.New("the AWS Accounts can only be imported without other providers")
+		}
+	}
+
+	if c.Resume && c.Journal == "" {
+		return errors.New("the Journal is required to Resume")
+	}
+
+	if c.Graph != "" {
+		if _, err := graph.FormatFromPath(c.Graph); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateProvider checks that the Config has only one provider
+func (c Config) validateProvider() error {
+	if providers := c.providers(); providers != 1 {
+		return errors.Errorf("one provider has to be configured and %d were", providers)
+	}
+
+	return nil
+}
+
+// providers returns the number of providers configured
+func (c Config) providers() int {
+	var providers int
+	for _, set := range []bool{c.Provider != nil, c.AWS != nil, c.Google != nil, c.AzureRM != nil, c.VSphere != nil} {
+		if set {
+			providers++
+		}
+	}
+
+	return providers
+}
+
+// ReadProvidersConfig reads the ProvidersConfig from the
+// file on path p which can be a YAML or JSON
+func ReadProvidersConfig(p string) (ProvidersConfig, error) {
+	var pc ProvidersConfig
+
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return pc, fmt.Errorf("could not ReadFile on path %q: %w", p, err)
+	}
+
+	switch filepath.Ext(p) {
+	case ".yml", ".yaml", ".json":
+		// The JSON is also valid YAML
+		err = yaml.UnmarshalStrict(b, &pc)
+		if err != nil {
+			return pc, fmt.Errorf("invalid providers config file %s: %w", p, err)
+		}
+	default:
+		return pc, fmt.Errorf("invalid providers config %s, only supported extensions are yaml/yml/json", p)
+	}
+
+	// The defaults are the same as the ones of the flags
+	if pc.Google != nil && pc.Google.MaxResults == 0 {
+		pc.Google.MaxResults = 500
+	}
+	if pc.AzureRM != nil && pc.AzureRM.Environment == "" {
+		pc.AzureRM.Environment = "public"
+	}
+
+	return pc, nil
+}
+
+// ReadModuleVariables reads the module variables from the
+// file on path p which can be a YAML or JSON
+func ReadModuleVariables(p string) (map[string][]string, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("could not ReadFile on path %q: %w", p, err)
+	}
+
+	var values map[string][]string
+	switch filepath.Ext(p) {
+	case ".yml", ".yaml":
+		err := yaml.Unmarshal(b, &values)
+		if err != nil {
+			return nil, fmt.Errorf("invalid YAML on module-variables file %s: %w", p, err)
+		}
+	case ".json":
+		err = json.Unmarshal(b, &values)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON on module-variables file %s: %w", p, err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid module-variables %s, only supported extensions are yaml/yml/json", p)
+	}
+
+	return values, nil
+}
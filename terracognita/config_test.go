@@ -0,0 +1,255 @@
+package terracognita_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/terracognita"
+)
+
+func TestConfigValidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := mock.NewProvider(ctrl)
+
+	tests := []struct {
+		Name   string
+		Config terracognita.Config
+		Error  bool
+	}{
+		{
+			Name:   "Success",
+			Config: terracognita.Config{Provider: p, HCL: "out.tf"},
+		},
+		{
+			Name:   "SuccessWithDryRun",
+			Config: terracognita.Config{AWS: &terracognita.AWSConfig{}, DryRun: true},
+		},
+		{
+			Name:   "ErrorWithoutProvider",
+			Config: terracognita.Config{HCL: "out.tf"},
+			Error:  true,
+		},
+		{
+			Name:   "SuccessWithMultipleProviders",
+			Config: terracognita.Config{AWS: &terracognita.AWSConfig{}, Google: &terracognita.GoogleConfig{}, HCL: "out.tf"},
+		},
+		{
+			Name:   "SuccessWithAWSRegions",
+			Config: terracognita.Config{AWS: &terracognita.AWSConfig{Regions: []string{"eu-west-1", "us-east-1"}}, HCL: "out.tf", HCLProviderBlock: true},
+		},
+		{
+			Name:   "SuccessWithAWSRegionsAndModule",
+			Config: terracognita.Config{AWS: &terracognita.AWSConfig{Regions: []string{terracognita.AllAWSRegions}}, Module: "out", HCLProviderBlock: true},
+		},
+		{
+			Name:   "ErrorAWSRegionsWithModuleWithoutHCLProviderBlock",
+			Config: terracognita.Config{AWS: &terracognita.AWSConfig{Regions: []string{terracognita.AllAWSRegions}}, Module: "out"},
+			Error:  true,
+		},
+		{
+			Name:   "ErrorAWSRegionsWithoutHCLProviderBlock",
+			Config: terracognita.Config{AWS: &terracognita.AWSConfig{Regions: []string{"eu-west-1", "us-east-1"}}, HCL: "out.tf"},
+			Error:  true,
+		},
+		{
+			Name:   "SuccessWithAWSAccounts",
+			Config: terracognita.Config{AWS: &terracognita.AWSConfig{Accounts: []string{terracognita.AllAWSAccounts}, RoleName: "terracognita"}, HCL: "out.tf"},
+		},
+		{
+			Name:   "ErrorAWSAccountsWithoutRoleName",
+			Config: terracognita.Config{AWS: &terracognita.AWSConfig{Accounts: []string{"123456789012"}}, HCL: "out.tf"},
+			Error:  true,
+		},
+		{
+			Name:   "ErrorAWSAccountsWithRoleARN",
+			Config: terracognita.Config{AWS: &terracognita.AWSConfig{Accounts: []string{"123456789012"}, RoleName: "terracognita", RoleARN: "arn:aws:iam::123456789012:role/admin"}, HCL: "out.tf"},
+			Error:  true,
+		},
+		{
+			Name:   "ErrorAWSAccountsWithMultipleProviders",
+			Config: terracognita.Config{AWS: &terracognita.AWSConfig{Accounts: []string{"123456789012"}, RoleName: "terracognita"}, Google: &terracognita.GoogleConfig{}, HCL: "out.tf"},
+			Error:  true,
+		},
+		{
+			Name:   "SuccessWithGoogleProjects",
+			Config: terracognita.Config{Google: &terracognita.GoogleConfig{Projects: []string{"project-a", "project-b"}}, HCL: "out.tf", HCLProviderBlock: true},
+		},
+		{
+			Name:   "SuccessWithGoogleParent",
+			Config: terracognita.Config{Google: &terracognita.GoogleConfig{Parent: "folders/123"}, TFState: "out.tfstate"},
+		},
+		{
+			Name:   "ErrorGoogleProjectsWithParent",
+			Config: terracognita.Config{Google: &terracognita.GoogleConfig{Projects: []string{"project-a"}, Parent: "folders/123"}, TFState: "out.tfstate"},
+			Error:  true,
+		},
+		{
+			Name:   "ErrorGoogleInvalidParent",
+			Config: terracognita.Config{Google: &terracognita.GoogleConfig{Parent: "123"}, TFState: "out.tfstate"},
+			Error:  true,
+		},
+		{
+			Name:   "SuccessWithGoogleProjectsAndModule",
+			Config: terracognita.Config{Google: &terracognita.GoogleConfig{Projects: []string{"project-a"}}, Module: "out", HCLProviderBlock: true},
+		},
+		{
+			Name:   "ErrorGoogleProjectsWithModuleWithoutHCLProviderBlock",
+			Config: terracognita.Config{Google: &terracognita.GoogleConfig{Projects: []string{"project-a"}}, Module: "out"},
+			Error:  true,
+		},
+		{
+			Name:   "ErrorGoogleRegionsWithoutHCLProviderBlock",
+			Config: terracognita.Config{Google: &terracognita.GoogleConfig{Regions: []string{"europe-west1", "us-east1"}}, HCL: "out.tf"},
+			Error:  true,
+		},
+		{
+			Name:   "ErrorGoogleProjectsWithMultipleProviders",
+			Config: terracognita.Config{Google: &terracognita.GoogleConfig{Projects: []string{"project-a"}}, AWS: &terracognita.AWSConfig{}, TFState: "out.tfstate"},
+			Error:  true,
+		},
+		{
+			Name:   "SuccessWithAzureRMSubscriptions",
+			Config: terracognita.Config{AzureRM: &terracognita.AzureRMConfig{Subscriptions: []string{terracognita.AllAzureRMSubscriptions}, AllResourceGroups: true}, HCL: "out.tf", HCLProviderBlock: true},
+		},
+		{
+			Name:   "ErrorAzureRMSubscriptionsWithSubscriptionID",
+			Config: terracognita.Config{AzureRM: &terracognita.AzureRMConfig{Subscriptions: []string{"sub-a", "sub-b"}, SubscriptionID: "sub-a"}, TFState: "out.tfstate"},
+			Error:  true,
+		},
+		{
+			Name:   "SuccessWithAzureRMSubscriptionsAndModule",
+			Config: terracognita.Config{AzureRM: &terracognita.AzureRMConfig{Subscriptions: []string{"sub-a", "sub-b"}}, Module: "out", HCLProviderBlock: true},
+		},
+		{
+			Name:   "ErrorAzureRMSubscriptionsWithModuleWithoutHCLProviderBlock",
+			Config: terracognita.Config{AzureRM: &terracognita.AzureRMConfig{Subscriptions: []string{"sub-a", "sub-b"}}, Module: "out"},
+			Error:  true,
+		},
+		{
+			Name:   "ErrorAzureRMSubscriptionsWithoutHCLProviderBlock",
+			Config: terracognita.Config{AzureRM: &terracognita.AzureRMConfig{Subscriptions: []string{"sub-a", "sub-b"}}, HCL: "out.tf"},
+			Error:  true,
+		},
+		{
+			Name:   "ErrorAzureRMSubscriptionsWithMultipleProviders",
+			Config: terracognita.Config{AzureRM: &terracognita.AzureRMConfig{Subscriptions: []string{"sub-a", "sub-b"}}, AWS: &terracognita.AWSConfig{}, TFState: "out.tfstate"},
+			Error:  true,
+		},
+		{
+			Name:   "ErrorWithoutOutput",
+			Config: terracognita.Config{Provider: p},
+			Error:  true,
+		},
+		{
+			Name:   "SuccessWithImportBlocks",
+			Config: terracognita.Config{Provider: p, HCL: "out.tf", ImportBlocks: true},
+		},
+		{
+			Name:   "ErrorImportBlocksWithTFState",
+			Config: terracognita.Config{Provider: p, HCL: "out.tf", TFState: "out.tfstate", ImportBlocks: true},
+			Error:  true,
+		},
+		{
+			Name:   "ErrorImportBlocksWithoutHCL",
+			Config: terracognita.Config{Provider: p, ImportBlocks: true},
+			Error:  true,
+		},
+		{
+			Name:   "ErrorResumeWithoutJournal",
+			Config: terracognita.Config{Provider: p, HCL: "out.tf", Resume: true},
+			Error:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			err := tt.Config.Validate()
+			if tt.Error {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReadProvidersConfig(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "terracognita")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		p := filepath.Join(dir, "providers.yml")
+		err = ioutil.WriteFile(p, []byte(`
+aws:
+  region: eu-west-1
+  profile: prod
+google:
+  credentials: credentials.json
+  project: project
+  region: europe-west1
+`), 0644)
+		require.NoError(t, err)
+
+		pc, err := terracognita.ReadProvidersConfig(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, terracognita.ProvidersConfig{
+			AWS: &terracognita.AWSConfig{
+				Region:  "eu-west-1",
+				Profile: "prod",
+			},
+			Google: &terracognita.GoogleConfig{
+				Credentials: "credentials.json",
+				Project:     "project",
+				Region:      "europe-west1",
+				MaxResults:  500,
+			},
+		}, pc)
+	})
+	t.Run("SuccessWithJSON", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "terracognita")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		p := filepath.Join(dir, "providers.json")
+		err = ioutil.WriteFile(p, []byte(`{"vsphere": {"soap_url": "https://vcenter/sdk", "username": "user", "password": "pass"}}`), 0644)
+		require.NoError(t, err)
+
+		pc, err := terracognita.ReadProvidersConfig(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, terracognita.ProvidersConfig{
+			VSphere: &terracognita.VSphereConfig{
+				SoapURL:  "https://vcenter/sdk",
+				Username: "user",
+				Password: "pass",
+			},
+		}, pc)
+	})
+	t.Run("ErrorWithUnknownField", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "terracognita")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		p := filepath.Join(dir, "providers.yml")
+		err = ioutil.WriteFile(p, []byte("aws:\n  regions: eu-west-1\n"), 0644)
+		require.NoError(t, err)
+
+		_, err = terracognita.ReadProvidersConfig(p)
+		assert.Error(t, err)
+	})
+	t.Run("ErrorWithInvalidExtension", func(t *testing.T) {
+		_, err := terracognita.ReadProvidersConfig("providers.toml")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,4 @@
+// Package terracognita is the entry point to use Terracognita
+// as a library, the Run imports from the Provider defined
+// on the Config and writes the outputs. The CLI is built on top of it
+package terracognita
@@ -0,0 +1,67 @@
+package terracognita
+
+import (
+	"context"
+
+	kitlog "github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/drift"
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// Drift compares the resources of the TFState on path state with the current
+// ones of the provider of the c. Only the provider, the filters, the retries
+// and the Events of the c are used, nothing is written.
+// If the ctx is done the comparison stops and the drift.Report
+// of the resources compared until then is returned with the error
+func Drift(ctx context.Context, c Config, state string) (*drift.Report, error) {
+	logger := log.Get()
+	logger = kitlog.With(logger, "func", "terracognita.Drift")
+
+	if err := c.validateProvider(); err != nil {
+		return nil, err
+	}
+
+	if state == "" {
+		return nil, errors.New("the state to compare is required")
+	}
+
+	es, err := readExistingState(state)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := newProvider(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &filter.Filter{
+		Include: c.Include,
+		Exclude: c.Exclude,
+		Targets: c.Targets,
+		Tags:    c.Tags,
+
+		TagsExpr:    c.TagsExpr,
+		IDPattern:   c.IDPattern,
+		NamePattern: c.NamePattern,
+		Where:       c.Where,
+	}
+
+	logger.Log("msg", "comparing", "state", state)
+
+	opts := provider.DriftOptions{
+		MaxRetries:   c.MaxRetries,
+		RetryMaxWait: c.RetryMaxWait,
+	}
+
+	rep, err := provider.Drift(ctx, p, es, f, opts, c.Events)
+	if err != nil {
+		return rep, errors.Wrap(err, "could not compare with "+p.String())
+	}
+
+	return rep, nil
+}
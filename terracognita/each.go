@@ -0,0 +1,80 @@
+package terracognita
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	kitlog "github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/log"
+)
+
+// runEach runs the Config returned by configFor for each one of
+// the ids, which are the accounts or projects (kind) to import from.
+// If one of them fails the rest are still imported and the Result
+// of each one is returned
+func runEach(ctx context.Context, kind string, ids []string, configFor func(string) (Config, error)) (map[string]*Result, error) {
+	logger := log.Get()
+	logger = kitlog.With(logger, "func", "terracognita.runEach")
+
+	results := make(map[string]*Result, len(ids))
+
+	var errs []string
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			break
+		}
+
+		logger.Log("msg", "importing", kind, id)
+
+		c, err := configFor(id)
+		if err != nil {
+			return results, err
+		}
+
+		res, err := Run(ctx, c)
+		if res != nil {
+			results[id] = res
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", id, err))
+		}
+	}
+
+	if len(errs) != 0 {
+		return results, errors.Errorf("could not import from the %ss: %s", kind, strings.Join(errs, ", "))
+	}
+
+	return results, nil
+}
+
+// outputsIn returns the c with all its outputs on a directory
+// named dir next to them (ex: out/main.tf is out/<dir>/main.tf)
+func outputsIn(c Config, dir string) (Config, error) {
+	for _, p := range []*string{&c.HCL, &c.TFState, &c.Module, &c.ExistingState, &c.Journal, &c.Report, &c.Graph} {
+		if *p == "" {
+			continue
+		}
+
+		*p = pathIn(*p, dir)
+
+		// The HCL and the Module directories
+		// are created when writing them
+		if err := os.MkdirAll(filepath.Dir(*p), 0700); err != nil {
+			return c, err
+		}
+	}
+
+	return c, nil
+}
+
+// pathIn returns the path p inside
+// of a directory named dir next to it
+func pathIn(p, dir string) string {
+	p = filepath.Clean(p)
+	return filepath.Join(filepath.Dir(p), dir, filepath.Base(p))
+}
@@ -0,0 +1,14 @@
+package terracognita
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathIn(t *testing.T) {
+	assert.Equal(t, filepath.Join("out", "123", "main.tf"), pathIn("out/main.tf", "123"))
+	assert.Equal(t, filepath.Join("out", "123", "hcl"), pathIn("out/hcl/", "123"))
+	assert.Equal(t, filepath.Join("123", "terraform.tfstate"), pathIn("terraform.tfstate", "123"))
+}
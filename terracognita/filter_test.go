@@ -0,0 +1,100 @@
+package terracognita
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/tag"
+)
+
+func TestProvidersFilters(t *testing.T) {
+	var (
+		ctrl = gomock.NewController(t)
+
+		awsp    = mock.NewProvider(ctrl)
+		googlep = mock.NewProvider(ctrl)
+		ps      = []provider.Provider{awsp, googlep}
+		tags    = []tag.Tag{{Name: "env", Value: "prod"}}
+	)
+
+	defer ctrl.Finish()
+
+	awsp.EXPECT().String().Return("aws").AnyTimes()
+	googlep.EXPECT().String().Return("google").AnyTimes()
+
+	t.Run("Success", func(t *testing.T) {
+		filters, err := providersFilters(&filter.Filter{
+			Include: []string{"aws_instance", "google_compute_instance"},
+			Exclude: []string{"aws_iam_user"},
+			Tags:    tags,
+		}, ps)
+		require.NoError(t, err)
+
+		assert.Equal(t, []*filter.Filter{
+			{Include: []string{"aws_instance"}, Exclude: []string{"aws_iam_user"}, Tags: tags},
+			{Include: []string{"google_compute_instance"}, Tags: tags},
+		}, filters)
+	})
+	t.Run("SuccessWithoutProviderTypes", func(t *testing.T) {
+		filters, err := providersFilters(&filter.Filter{
+			Targets: []string{"aws_instance.i-1"},
+		}, ps)
+		require.NoError(t, err)
+
+		// Nothing is imported from Google
+		assert.Equal(t, []*filter.Filter{
+			{Targets: []string{"aws_instance.i-1"}},
+			nil,
+		}, filters)
+	})
+	t.Run("SuccessWithSameProvider", func(t *testing.T) {
+		awsp2 := mock.NewProvider(ctrl)
+		awsp2.EXPECT().String().Return("aws").AnyTimes()
+
+		filters, err := providersFilters(&filter.Filter{
+			Include: []string{"aws_instance"},
+		}, []provider.Provider{awsp, awsp2, googlep})
+		require.NoError(t, err)
+
+		// Each region of AWS has the same types
+		assert.Equal(t, []*filter.Filter{
+			{Include: []string{"aws_instance"}},
+			{Include: []string{"aws_instance"}},
+			nil,
+		}, filters)
+	})
+	t.Run("SuccessWithPatterns", func(t *testing.T) {
+		awsp.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"}).Times(2)
+		googlep.EXPECT().ResourceTypes().Return([]string{"google_compute_instance"}).Times(2)
+
+		filters, err := providersFilters(&filter.Filter{
+			Include: []string{"/_instance$/", "!aws_iam_*"},
+		}, ps)
+		require.NoError(t, err)
+
+		assert.Equal(t, []*filter.Filter{
+			{Include: []string{"/_instance$/", "!aws_iam_*"}},
+			{Include: []string{"/_instance$/"}},
+		}, filters)
+	})
+	t.Run("SuccessWithOneProvider", func(t *testing.T) {
+		f := &filter.Filter{Include: []string{"google_compute_instance"}}
+
+		filters, err := providersFilters(f, ps[:1])
+		require.NoError(t, err)
+
+		assert.Equal(t, []*filter.Filter{f}, filters)
+	})
+	t.Run("ErrorWithUnknownType", func(t *testing.T) {
+		_, err := providersFilters(&filter.Filter{
+			Include: []string{"azurerm_virtual_machine"},
+		}, ps)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,198 @@
+package terracognita
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/cycloidio/mxwriter"
+
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// importBlocksFile is the name of the file
+// on which the import blocks are written
+const importBlocksFile = "imports.tf"
+
+// outputs has the destinations of the HCL, TFState and import blocks
+type outputs struct {
+	module   string
+	hcl      string
+	isHCLDir bool
+
+	hclOut     io.ReadWriter
+	stateOut   *os.File
+	importsOut *os.File
+}
+
+// newOutputs initializes the outputs of the c, the Module and the HCL, if it's
+// a directory, are emptied before
+func newOutputs(c Config) (*outputs, error) {
+	o := &outputs{
+		module: c.Module,
+		hcl:    c.HCL,
+	}
+
+	if o.module != "" {
+		// We check if there is any error checking it
+		// if its NotExist we do not care as we'll create it
+		// but if it exists and it's not a Dir then we fail
+		fi, err := os.Stat(o.module)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err == nil && !fi.IsDir() {
+			return nil, fmt.Errorf("the Module must be a directory: %s", o.module)
+		}
+
+		if err = cleanDir(o.module); err != nil {
+			return nil, err
+		}
+
+		o.hclOut = mxwriter.NewMux()
+	} else if o.hcl != "" {
+		o.isHCLDir = IsHCLDir(o.hcl)
+		if o.isHCLDir {
+			if err := cleanDir(o.hcl); err != nil {
+				return nil, err
+			}
+		}
+
+		o.hclOut = mxwriter.NewMux()
+	}
+
+	if c.TFState != "" {
+		f, err := os.OpenFile(c.TFState, os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("could not OpenFile %s because: %s", c.TFState, err)
+		}
+		o.stateOut = f
+	}
+
+	if c.ImportBlocks {
+		// The import blocks are on the root module
+		// so they are next to the HCL or the Module
+		dir := o.module
+		if dir == "" {
+			dir = o.hcl
+			if !o.isHCLDir {
+				dir = filepath.Dir(o.hcl)
+			}
+		}
+
+		p := filepath.Join(dir, importBlocksFile)
+		f, err := os.OpenFile(p, os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("could not OpenFile %s because: %s", p, err)
+		}
+		o.importsOut = f
+	}
+
+	return o, nil
+}
+
+// IsHCLDir returns if the HCL output on path p is a directory,
+// which is when it has no extension and it's not an existing file
+func IsHCLDir(p string) bool {
+	if filepath.Ext(p) != "" {
+		return false
+	}
+
+	fi, err := os.Stat(p)
+	return err != nil || fi.IsDir()
+}
+
+// cleanDir removes all the content of the
+// directory d or creates it if it does not exist
+func cleanDir(d string) error {
+	// Clean the module dir
+	err := os.RemoveAll(d)
+	if err != nil {
+		return err
+	}
+
+	// Recreate it just if it was not created
+	// RemoveAll will not return error if
+	// it does not exists
+	return os.MkdirAll(d, 0700)
+}
+
+// write writes the HCL to the files, as
+// the TFState is written directly on Sync
+func (o *outputs) write() error {
+	if o.module != "" {
+		dm, err := mxwriter.NewDemux(o.hclOut)
+		if err != nil {
+			return err
+		}
+
+		moduleName := filepath.Base(o.module)
+		mdir := fmt.Sprintf("module-%s", moduleName)
+
+		err = os.Mkdir(filepath.Join(o.module, mdir), 0700)
+		if err != nil {
+			return err
+		}
+
+		for _, k := range dm.Keys() {
+			var (
+				filep string
+			)
+			if k == writer.ModuleCategoryKey {
+				filep = filepath.Join(o.module, "module.tf")
+			} else {
+				filep = filepath.Join(o.module, mdir, fmt.Sprintf("%s.tf", k))
+			}
+
+			if err = writeFile(filep, dm.Read(k)); err != nil {
+				return err
+			}
+		}
+	} else if o.hcl != "" {
+		if o.isHCLDir {
+			dm, err := mxwriter.NewDemux(o.hclOut)
+			if err != nil {
+				return err
+			}
+
+			for _, k := range dm.Keys() {
+				filep := filepath.Join(o.hcl, fmt.Sprintf("%s.tf", k))
+				if err = writeFile(filep, dm.Read(k)); err != nil {
+					return err
+				}
+			}
+		} else {
+			if err := writeFile(o.hcl, o.hclOut); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// close closes the opened files
+func (o *outputs) close() error {
+	for _, f := range []*os.File{o.stateOut, o.importsOut} {
+		if f == nil {
+			continue
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFile writes all the r to the file on path p
+func writeFile(p string, r io.Reader) error {
+	f, err := os.OpenFile(p, os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("could not OpenFile %s because: %s", p, err)
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
@@ -0,0 +1,44 @@
+package terracognita
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/google"
+)
+
+// runGoogleProjects runs the c on each one of the Google Projects, or the
+// active projects of the Google Parent. The outputs of each project are
+// written on their own directory, see projectConfig. If one of the
+// projects fails the rest are still imported
+func runGoogleProjects(ctx context.Context, c Config) (*Result, error) {
+	projects := c.Google.Projects
+	if c.Google.Parent != "" {
+		var err error
+		projects, err = google.Projects(ctx, c.Google.Credentials, c.Google.Parent)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not list the Google projects of %s", c.Google.Parent)
+		}
+	}
+
+	results, err := runEach(ctx, "project", projects, func(p string) (Config, error) {
+		return projectConfig(c, p)
+	})
+
+	return &Result{Provider: "google", Projects: results}, err
+}
+
+// projectConfig returns the Config to import from the Google project p, which
+// has the project as alias of its providers and the outputs of the c on a
+// directory named as the project next to them (ex: out/main.tf is out/<p>/main.tf)
+func projectConfig(c Config, p string) (Config, error) {
+	gc := *c.Google
+	gc.Projects = nil
+	gc.Parent = ""
+	gc.Project = p
+	gc.alias = google.Alias(p)
+	c.Google = &gc
+
+	return outputsIn(c, p)
+}
@@ -0,0 +1,68 @@
+package terracognita
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terracognita-projects")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c := Config{
+		Google: &GoogleConfig{
+			Credentials: "credentials.json",
+			Region:      "europe-west1",
+			Projects:    []string{"my-project"},
+		},
+		HCL:     filepath.Join(dir, "hcl"),
+		TFState: filepath.Join(dir, "terraform.tfstate"),
+	}
+
+	pc, err := projectConfig(c, "my-project")
+	require.NoError(t, err)
+
+	assert.Equal(t, &GoogleConfig{
+		Credentials: "credentials.json",
+		Region:      "europe-west1",
+		Project:     "my-project",
+		alias:       "my_project",
+	}, pc.Google)
+	assert.Equal(t, filepath.Join(dir, "my-project", "hcl"), pc.HCL)
+	assert.Equal(t, filepath.Join(dir, "my-project", "terraform.tfstate"), pc.TFState)
+	assert.DirExists(t, filepath.Join(dir, "my-project"))
+
+	// The c is not changed
+	assert.Equal(t, []string{"my-project"}, c.Google.Projects)
+	assert.Equal(t, filepath.Join(dir, "hcl"), c.HCL)
+}
+
+func TestProjectConfigWithModule(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terracognita-projects")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c := Config{
+		Google: &GoogleConfig{
+			Projects: []string{"project-a", "project-b"},
+		},
+		Module:  filepath.Join(dir, "test"),
+		TFState: filepath.Join(dir, "terraform.tfstate"),
+	}
+
+	for _, p := range c.Google.Projects {
+		pc, err := projectConfig(c, p)
+		require.NoError(t, err)
+
+		// Each project has its own module
+		assert.Equal(t, filepath.Join(dir, p, "test"), pc.Module)
+		assert.Equal(t, filepath.Join(dir, p, "terraform.tfstate"), pc.TFState)
+		assert.Equal(t, "test", writerOptions(pc).Module)
+	}
+}
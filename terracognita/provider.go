@@ -0,0 +1,224 @@
+package terracognita
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/aws"
+	"github.com/cycloidio/terracognita/azurerm"
+	"github.com/cycloidio/terracognita/google"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/util"
+	"github.com/cycloidio/terracognita/vsphere"
+)
+
+// newProvider initializes the only Provider configured on the c
+func newProvider(ctx context.Context, c Config) (provider.Provider, error) {
+	ps, err := newProviders(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if len(ps) != 1 {
+		return nil, errors.Errorf("one provider has to be configured and %d were", len(ps))
+	}
+
+	return ps[0], nil
+}
+
+// newProviders initializes all the Providers configured on
+// the c on the order: Provider, AWS, Google, AzureRM and VSphere.
+// The providers of the same cloud share the RateLimiter
+func newProviders(ctx context.Context, c Config) ([]provider.Provider, error) {
+	var ps []provider.Provider
+
+	if c.Provider != nil {
+		ps = append(ps, c.Provider)
+	}
+
+	if c.AWS != nil {
+		ac := *c.AWS
+		if ac.Region == "" && len(ac.Regions) == 0 {
+			return nil, errors.New("the AWS Region or Regions are required")
+		}
+		aps, err := newAWSProviders(ctx, ac, util.NewRateLimiter(aws.RateLimitsDefault, c.RateLimits))
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, aps...)
+	}
+
+	if c.Google != nil {
+		gc := *c.Google
+		if gc.Credentials == "" || gc.Project == "" || (gc.Region == "" && len(gc.Regions) == 0) {
+			return nil, errors.New("the Google Credentials, Project and Region or Regions are required")
+		}
+		gps, err := newGoogleProviders(ctx, gc, util.NewRateLimiter(google.RateLimitsDefault, c.RateLimits))
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, gps...)
+	}
+
+	if c.AzureRM != nil {
+		ac := c.AzureRM
+		if ac.ClientID == "" || ac.ClientSecret == "" || ac.SubscriptionID == "" || ac.TenantID == "" || (len(ac.ResourceGroupNames) == 0 && !ac.AllResourceGroups) {
+			return nil, errors.New("the AzureRM ClientID, ClientSecret, SubscriptionID, TenantID and ResourceGroupNames or AllResourceGroups are required")
+		}
+		if len(ac.Subscriptions) != 0 {
+			return nil, errors.New("the AzureRM Subscriptions are imported one by one with Run")
+		}
+		p, err := azurerm.NewProviderWithOptions(ctx, azurerm.Options{
+			ClientID:           ac.ClientID,
+			ClientSecret:       ac.ClientSecret,
+			Environment:        ac.Environment,
+			SubscriptionID:     ac.SubscriptionID,
+			TenantID:           ac.TenantID,
+			ResourceGroupNames: ac.ResourceGroupNames,
+			AllResourceGroups:  ac.AllResourceGroups,
+			Alias:              ac.alias,
+			RateLimiter:        util.NewRateLimiter(azurerm.RateLimitsDefault, c.RateLimits),
+		})
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+
+	if c.VSphere != nil {
+		vc := c.VSphere
+		if vc.SoapURL == "" || vc.Username == "" || vc.Password == "" {
+			return nil, errors.New("the vSphere SoapURL, Username and Password are required")
+		}
+		p, err := vsphere.NewProvider(ctx, vc.SoapURL, vc.Username, vc.Password, vc.VSphereServer, vc.Insecure)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+
+	if len(ps) == 0 {
+		return nil, errors.New("no provider configured")
+	}
+
+	return ps, nil
+}
+
+// options returns the aws.Options of the credentials of the ac
+func (ac AWSConfig) options() aws.Options {
+	return aws.Options{
+		AccessKey:             ac.AccessKey,
+		SecretKey:             ac.SecretKey,
+		SessionToken:          ac.SessionToken,
+		Region:                ac.Region,
+		Profile:               ac.Profile,
+		SharedCredentialsFile: ac.SharedCredentialsFile,
+		RoleARN:               ac.RoleARN,
+		ExternalID:            ac.ExternalID,
+		MFASerial:             ac.MFASerial,
+	}
+}
+
+// newAWSProviders initializes the AWS Provider of the Region or, if the
+// Regions are set, one for each of them with the region as alias. The global
+// services are only read from the first one of the Regions
+func newAWSProviders(ctx context.Context, ac AWSConfig, rl *util.RateLimiter) ([]provider.Provider, error) {
+	if len(ac.Accounts) != 0 {
+		return nil, errors.New("the AWS Accounts are imported one by one with Run")
+	}
+
+	opts := ac.options()
+	opts.RateLimiter = rl
+
+	// The credentials are resolved once and shared by all the
+	// regions, so the MFA token is only asked once
+	creds, err := aws.NewCredentials(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize the AWS credentials")
+	}
+	opts.Credentials = creds
+
+	regions := ac.Regions
+	if len(regions) == 1 && regions[0] == AllAWSRegions {
+		regions, err = aws.Regions(ctx, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list the AWS regions")
+		}
+	}
+
+	switch len(regions) {
+	case 0:
+		regions = []string{ac.Region}
+		fallthrough
+	case 1:
+		opts.Region = regions[0]
+		p, err := aws.NewProviderWithOptions(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return []provider.Provider{p}, nil
+	}
+
+	ps := make([]provider.Provider, 0, len(regions))
+	for i, r := range regions {
+		ropts := opts
+		ropts.Region = r
+		ropts.Alias = aws.RegionAlias(r)
+		ropts.SkipGlobal = i != 0
+		p, err := aws.NewProviderWithOptions(ctx, ropts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not initialize the AWS region %s", r)
+		}
+		ps = append(ps, p)
+	}
+
+	return ps, nil
+}
+
+// newGoogleProviders initializes the Google Provider of the Region or, if
+// the Regions are set, one for each of them with the region as alias. The
+// resources that are not regional are only read from the first one of the Regions
+func newGoogleProviders(ctx context.Context, gc GoogleConfig, rl *util.RateLimiter) ([]provider.Provider, error) {
+	if len(gc.Projects) != 0 || gc.Parent != "" {
+		return nil, errors.New("the Google Projects are imported one by one with Run")
+	}
+
+	opts := google.Options{
+		MaxResults:  gc.MaxResults,
+		Project:     gc.Project,
+		Region:      gc.Region,
+		Credentials: gc.Credentials,
+		Alias:       gc.alias,
+		RateLimiter: rl,
+	}
+
+	if len(gc.Regions) == 1 {
+		opts.Region = gc.Regions[0]
+	}
+
+	if len(gc.Regions) <= 1 {
+		p, err := google.NewProviderWithOptions(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return []provider.Provider{p}, nil
+	}
+
+	ps := make([]provider.Provider, 0, len(gc.Regions))
+	for i, r := range gc.Regions {
+		ropts := opts
+		ropts.Region = r
+		ropts.Alias = google.Alias(r)
+		if gc.alias != "" {
+			ropts.Alias = gc.alias + "_" + ropts.Alias
+		}
+		ropts.SkipGlobal = i != 0
+		p, err := google.NewProviderWithOptions(ctx, ropts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not initialize the Google region %s", r)
+		}
+		ps = append(ps, p)
+	}
+
+	return ps, nil
+}
@@ -0,0 +1,464 @@
+package terracognita
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	kitlog "github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/graph"
+	"github.com/cycloidio/terracognita/hcl"
+	"github.com/cycloidio/terracognita/importblock"
+	"github.com/cycloidio/terracognita/interpolator"
+	"github.com/cycloidio/terracognita/journal"
+	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/report"
+	"github.com/cycloidio/terracognita/state"
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// Result is the result of a Run
+type Result struct {
+	// Provider is the name of the provider imported, if
+	// more than one they are separated by ','
+	Provider string
+
+	// Report has the outcome of each resource found,
+	// it's nil if it was a DryRun
+	Report *report.Report
+
+	// Graph has the imported resources and the references
+	// between them, it's nil if the Config.Graph was not set
+	Graph *graph.Graph
+
+	// Accounts has the Result of each one of the AWS
+	// accounts if the AWSConfig.Accounts were set
+	Accounts map[string]*Result
+
+	// Projects has the Result of each one of the Google projects
+	// if the GoogleConfig.Projects or Parent were set
+	Projects map[string]*Result
+
+	// Subscriptions has the Result of each one of the AzureRM
+	// subscriptions if the AzureRMConfig.Subscriptions were set
+	Subscriptions map[string]*Result
+}
+
+// Run imports from the providers of the c and writes the outputs, if the
+// AWS Accounts, Google Projects or AzureRM Subscriptions are set each one
+// is imported on its own outputs.
+// The Result is returned even if it fails, when possible, so the
+// outcome of the resources imported until then can be checked.
+// If the ctx is done the import stops and the resources
+// already imported are written
+func Run(ctx context.Context, c Config) (*Result, error) {
+	logger := log.Get()
+	logger = kitlog.With(logger, "func", "terracognita.Run")
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	if c.AWS != nil && len(c.AWS.Accounts) != 0 {
+		return runAWSAccounts(ctx, c)
+	}
+
+	if c.Google != nil && (len(c.Google.Projects) != 0 || c.Google.Parent != "") {
+		return runGoogleProjects(ctx, c)
+	}
+
+	if c.AzureRM != nil && len(c.AzureRM.Subscriptions) != 0 {
+		return runAzureRMSubscriptions(ctx, c)
+	}
+
+	ps, err := newProviders(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	// The same provider can be more than
+	// once with different aliases
+	names := make([]string, 0, len(ps))
+	for i, p := range ps {
+		if i == 0 || ps[i-1].String() != p.String() {
+			names = append(names, p.String())
+		}
+	}
+	res := &Result{Provider: strings.Join(names, ",")}
+
+	f := &filter.Filter{
+		Include: c.Include,
+		Exclude: c.Exclude,
+		Targets: c.Targets,
+		Tags:    c.Tags,
+
+		TagsExpr:    c.TagsExpr,
+		IDPattern:   c.IDPattern,
+		NamePattern: c.NamePattern,
+		Where:       c.Where,
+	}
+
+	filters, err := providersFilters(f, ps)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := provider.ImportOptions{
+		Parallelism:     c.Parallelism,
+		DryRun:          c.DryRun,
+		Strict:          c.Strict,
+		MaxErrors:       c.MaxErrors,
+		MaxErrorRatio:   c.MaxErrorRatio,
+		ResourceTimeout: c.ResourceTimeout,
+		MaxRetries:      c.MaxRetries,
+		RetryMaxWait:    c.RetryMaxWait,
+
+		// All the providers are imported to the same writers
+		// so they are synced once all are imported
+		Interpolator: interpolator.New(ps[0].String()),
+		SkipSync:     true,
+	}
+
+	if len(c.ManagedStates) != 0 {
+		opts.Managed, err = readManagedStates(c.ManagedStates)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var hclW, stateW writer.Writer
+	var outs *outputs
+
+	// On dry run nothing is written
+	// so there is nothing to initialize
+	if !c.DryRun {
+		// It's read before initializing the outputs
+		// as it can be the same file as the TFState
+		if c.ExistingState != "" {
+			opts.Existing, err = readExistingState(c.ExistingState)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		outs, err = newOutputs(c)
+		if err != nil {
+			return nil, err
+		}
+		defer outs.close()
+
+		options := writerOptions(c)
+
+		if outs.hclOut != nil {
+			logger.Log("msg", "initializing HCL writer")
+			hw := hcl.NewWriter(outs.hclOut, ps[0], options)
+			for _, p := range ps[1:] {
+				hw.AddProvider(p)
+			}
+			hclW = hw
+		}
+
+		if outs.stateOut != nil {
+			logger.Log("msg", "initializing TFState writer")
+			stateW = state.NewWriter(outs.stateOut, options)
+		} else if outs.importsOut != nil {
+			// The import blocks are generated from the same
+			// values as the TFState so they are written instead
+			logger.Log("msg", "initializing import blocks writer")
+			stateW = importblock.NewWriter(outs.importsOut, options)
+		}
+
+		if c.Resume {
+			logger.Log("msg", "loading the journal", "path", c.Journal)
+			jf, err := os.OpenFile(c.Journal, os.O_APPEND|os.O_RDWR, 0644)
+			if err != nil {
+				return nil, fmt.Errorf("could not OpenFile %s because: %s", c.Journal, err)
+			}
+			defer jf.Close()
+
+			opts.Journal, err = journal.Load(jf, jf)
+			if err != nil {
+				return nil, err
+			}
+		} else if c.Journal != "" {
+			jf, err := os.OpenFile(c.Journal, os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+			if err != nil {
+				return nil, fmt.Errorf("could not OpenFile %s because: %s", c.Journal, err)
+			}
+			defer jf.Close()
+
+			opts.Journal = journal.New(jf)
+		}
+
+		opts.Report = report.New(res.Provider)
+		res.Report = opts.Report
+
+		if c.Graph != "" {
+			opts.Graph = graph.New()
+			res.Graph = opts.Graph
+		}
+	}
+
+	for i, p := range ps {
+		// Nothing of the filters is of the p
+		if filters[i] == nil {
+			continue
+		}
+
+		logger.Log("msg", "importing", "provider", p.String(), "alias", provider.Alias(p))
+
+		err = provider.Import(ctx, p, hclW, stateW, filters[i], opts, c.Events)
+		if err != nil {
+			break
+		}
+	}
+
+	// If it was stopped the resources imported
+	// until then are written too
+	if !c.DryRun && (err == nil || ctx.Err() != nil) {
+		if serr := provider.Sync(hclW, stateW, opts.Graph, opts.Interpolator, c.Events); serr != nil {
+			err = serr
+		}
+	}
+
+	// The report is written even if the import failed
+	// as it's when it's most useful
+	if c.Report != "" && opts.Report != nil {
+		if rerr := writeReport(c.Report, opts.Report); rerr != nil {
+			return res, rerr
+		}
+	}
+
+	// If it was stopped the resources
+	// imported until then are written
+	if err != nil && ctx.Err() == nil {
+		return res, errors.Wrap(err, "could not import from "+res.Provider)
+	}
+
+	if outs != nil {
+		if werr := outs.write(); werr != nil {
+			return res, werr
+		}
+	}
+
+	if opts.Graph != nil {
+		if gerr := writeGraph(c.Graph, opts.Graph); gerr != nil {
+			return res, gerr
+		}
+	}
+
+	if err != nil {
+		return res, errors.Wrap(err, "could not import from "+res.Provider)
+	}
+
+	return res, nil
+}
+
+// providersFilters returns the filter for each one of the ps from the f. If there is only
+// one provider the f is used as it is, if not each one only has the types of the f that are
+// of the provider, and if the f has Include or Targets but none of them are of the provider
+// it's nil as nothing has to be imported from it
+func providersFilters(f *filter.Filter, ps []provider.Provider) ([]*filter.Filter, error) {
+	if len(ps) == 1 {
+		return []*filter.Filter{f}, nil
+	}
+
+	filters := make([]*filter.Filter, len(ps))
+	for i := range ps {
+		filters[i] = &filter.Filter{Tags: f.Tags, TagsExpr: f.TagsExpr, IDPattern: f.IDPattern, NamePattern: f.NamePattern, Where: f.Where}
+	}
+
+	// providersOf returns the positions on the ps of the providers of the type, or
+	// pattern, t which can be more than one if the same provider is with different
+	// aliases or the pattern matches types of more than one provider
+	providersOf := func(t string) ([]int, error) {
+		var idxs []int
+		for i, p := range ps {
+			ok, err := isOfProvider(p, t)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				idxs = append(idxs, i)
+			}
+		}
+		if len(idxs) == 0 {
+			return nil, errors.Wrapf(errcode.ErrProviderResourceNotSupported, "type %s is not of any of the providers", t)
+		}
+		return idxs, nil
+	}
+
+	for _, t := range f.Include {
+		idxs, err := providersOf(t)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range idxs {
+			filters[i].Include = append(filters[i].Include, t)
+		}
+	}
+
+	for _, t := range f.Exclude {
+		idxs, err := providersOf(t)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range idxs {
+			filters[i].Exclude = append(filters[i].Exclude, t)
+		}
+	}
+
+	for _, t := range f.Targets {
+		idxs, err := providersOf(t)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range idxs {
+			filters[i].Targets = append(filters[i].Targets, t)
+		}
+	}
+
+	for i, pf := range filters {
+		if (hasIncluded(f.Include) && !hasIncluded(pf.Include)) || (len(f.Targets) != 0 && len(pf.Targets) == 0) {
+			filters[i] = nil
+		}
+	}
+
+	return filters, nil
+}
+
+// isOfProvider checks if the type t is of the p, if it's a
+// pattern if it matches any of the types of the p
+func isOfProvider(p provider.Provider, t string) (bool, error) {
+	pt := strings.TrimPrefix(t, "!")
+	if !filter.IsPattern(pt) {
+		return strings.HasPrefix(pt, p.String()+"_"), nil
+	}
+
+	for _, rt := range p.ResourceTypes() {
+		ok, err := filter.Match(pt, rt)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// hasIncluded checks if the include has any type or
+// pattern that is not a negation, so it includes types
+func hasIncluded(include []string) bool {
+	for _, i := range include {
+		if !strings.HasPrefix(i, "!") {
+			return true
+		}
+	}
+	return false
+}
+
+// writerOptions returns the writer.Options from the c
+func writerOptions(c Config) *writer.Options {
+	var module string
+	var mv = make(map[string]struct{})
+	if c.Module != "" {
+		module = filepath.Base(c.Module)
+
+		for k, v := range c.ModuleVariables {
+			for _, vv := range v {
+				mv[fmt.Sprintf("%s.%s", k, vv)] = struct{}{}
+			}
+		}
+	}
+
+	return &writer.Options{
+		Interpolate:      c.Interpolate,
+		Module:           module,
+		ModuleVariables:  mv,
+		HCLProviderBlock: c.HCLProviderBlock,
+	}
+}
+
+// readExistingState reads the ExistingState from the file on path p
+func readExistingState(p string) (*provider.ExistingState, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, fmt.Errorf("could not Open %s because: %s", p, err)
+	}
+	defer f.Close()
+
+	return provider.ReadExistingState(f)
+}
+
+// readManagedStates reads all the TFStates on the paths, which can be
+// files or directories, in which case all the .tfstate files on them are read
+func readManagedStates(paths []string) (*provider.ExistingState, error) {
+	ms := provider.NewExistingState()
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("could not Stat %s because: %s", p, err)
+		}
+
+		files := []string{p}
+		if fi.IsDir() {
+			files, err = filepath.Glob(filepath.Join(p, "*.tfstate"))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, fp := range files {
+			f, err := os.Open(fp)
+			if err != nil {
+				return nil, fmt.Errorf("could not Open %s because: %s", fp, err)
+			}
+
+			s, err := provider.ReadManagedState(f)
+			f.Close()
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid managed state %s", fp)
+			}
+
+			ms.Merge(s)
+		}
+	}
+
+	return ms, nil
+}
+
+// writeGraph writes the g to the file on path p
+// with the format of the extension of p
+func writeGraph(p string, g *graph.Graph) error {
+	gf, err := graph.FormatFromPath(p)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(p, os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("could not OpenFile %s because: %s", p, err)
+	}
+	defer f.Close()
+
+	return g.Write(f, gf)
+}
+
+// writeReport writes the rep to the file on path p
+func writeReport(p string, rep *report.Report) error {
+	f, err := os.OpenFile(p, os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("could not OpenFile %s because: %s", p, err)
+	}
+	defer f.Close()
+
+	return rep.Write(f)
+}
@@ -0,0 +1,109 @@
+package terracognita_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/terracognita"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p = mock.NewProvider(ctrl)
+		)
+
+		defer ctrl.Finish()
+
+		dir, err := ioutil.TempDir("", "terracognita")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		p.EXPECT().String().Return("aws").AnyTimes()
+		p.EXPECT().ResourceTypes().Return([]string{})
+
+		res, err := terracognita.Run(ctx, terracognita.Config{
+			Provider: p,
+			TFState:  filepath.Join(dir, "terraform.tfstate"),
+			Report:   filepath.Join(dir, "report.json"),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "aws", res.Provider)
+		require.NotNil(t, res.Report)
+		assert.True(t, res.Report.Complete())
+		assert.FileExists(t, filepath.Join(dir, "terraform.tfstate"))
+		assert.FileExists(t, filepath.Join(dir, "report.json"))
+	})
+	t.Run("SuccessWithImportBlocks", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p = mock.NewProvider(ctrl)
+		)
+
+		defer ctrl.Finish()
+
+		dir, err := ioutil.TempDir("", "terracognita")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		p.EXPECT().String().Return("aws").AnyTimes()
+		p.EXPECT().Source().Return("hashicorp/aws")
+		p.EXPECT().Version().Return("4.9.0")
+		p.EXPECT().ResourceTypes().Return([]string{})
+
+		_, err = terracognita.Run(ctx, terracognita.Config{
+			Provider:     p,
+			HCL:          filepath.Join(dir, "resources.tf"),
+			ImportBlocks: true,
+		})
+		require.NoError(t, err)
+
+		assert.FileExists(t, filepath.Join(dir, "imports.tf"))
+		assert.NoFileExists(t, filepath.Join(dir, "terraform.tfstate"))
+	})
+	t.Run("ErrorWithManagedStates", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p = mock.NewProvider(ctrl)
+		)
+
+		defer ctrl.Finish()
+
+		dir, err := ioutil.TempDir("", "terracognita")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		err = ioutil.WriteFile(filepath.Join(dir, "terraform.tfstate"), []byte("{invalid"), 0644)
+		require.NoError(t, err)
+
+		p.EXPECT().String().Return("aws").AnyTimes()
+
+		_, err = terracognita.Run(ctx, terracognita.Config{
+			Provider:      p,
+			TFState:       filepath.Join(dir, "output.tfstate"),
+			ManagedStates: []string{dir},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid managed state "+filepath.Join(dir, "terraform.tfstate"))
+	})
+	t.Run("ErrorWithInvalidConfig", func(t *testing.T) {
+		_, err := terracognita.Run(context.Background(), terracognita.Config{})
+		assert.Error(t, err)
+	})
+}
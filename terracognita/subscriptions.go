@@ -0,0 +1,44 @@
+package terracognita
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/azurerm"
+)
+
+// runAzureRMSubscriptions runs the c on each one of the AzureRM Subscriptions.
+// The outputs of each subscription are written on their own directory,
+// see subscriptionConfig. If one of the subscriptions fails the rest
+// are still imported
+func runAzureRMSubscriptions(ctx context.Context, c Config) (*Result, error) {
+	ac := c.AzureRM
+	subscriptions := ac.Subscriptions
+	if len(subscriptions) == 1 && subscriptions[0] == AllAzureRMSubscriptions {
+		var err error
+		subscriptions, err = azurerm.Subscriptions(ctx, ac.ClientID, ac.ClientSecret, ac.Environment, ac.TenantID)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list the AzureRM subscriptions")
+		}
+	}
+
+	results, err := runEach(ctx, "subscription", subscriptions, func(s string) (Config, error) {
+		return subscriptionConfig(c, s)
+	})
+
+	return &Result{Provider: "azurerm", Subscriptions: results}, err
+}
+
+// subscriptionConfig returns the Config to import from the AzureRM subscription s,
+// which has the subscription as alias of its provider and the outputs of the c on
+// a directory named as the subscription next to them (ex: out/main.tf is out/<s>/main.tf)
+func subscriptionConfig(c Config, s string) (Config, error) {
+	ac := *c.AzureRM
+	ac.Subscriptions = nil
+	ac.SubscriptionID = s
+	ac.alias = azurerm.SubscriptionAlias(s)
+	c.AzureRM = &ac
+
+	return outputsIn(c, s)
+}
@@ -0,0 +1,67 @@
+package terracognita
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terracognita-subscriptions")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := "00000000-1111-2222-3333-444444444444"
+	c := Config{
+		AzureRM: &AzureRMConfig{
+			TenantID:          "tenant",
+			AllResourceGroups: true,
+			Subscriptions:     []string{AllAzureRMSubscriptions},
+		},
+		TFState: filepath.Join(dir, "terraform.tfstate"),
+	}
+
+	sc, err := subscriptionConfig(c, s)
+	require.NoError(t, err)
+
+	assert.Equal(t, &AzureRMConfig{
+		TenantID:          "tenant",
+		AllResourceGroups: true,
+		SubscriptionID:    s,
+		alias:             "subscription_00000000_1111_2222_3333_444444444444",
+	}, sc.AzureRM)
+	assert.Equal(t, filepath.Join(dir, s, "terraform.tfstate"), sc.TFState)
+	assert.DirExists(t, filepath.Join(dir, s))
+
+	// The c is not changed
+	assert.Equal(t, []string{AllAzureRMSubscriptions}, c.AzureRM.Subscriptions)
+	assert.Equal(t, filepath.Join(dir, "terraform.tfstate"), c.TFState)
+}
+
+func TestSubscriptionConfigWithModule(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terracognita-subscriptions")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c := Config{
+		AzureRM: &AzureRMConfig{
+			Subscriptions: []string{"sub-a", "sub-b"},
+		},
+		Module:  filepath.Join(dir, "test"),
+		TFState: filepath.Join(dir, "terraform.tfstate"),
+	}
+
+	for _, s := range c.AzureRM.Subscriptions {
+		sc, err := subscriptionConfig(c, s)
+		require.NoError(t, err)
+
+		// Each subscription has its own module
+		assert.Equal(t, filepath.Join(dir, s, "test"), sc.Module)
+		assert.Equal(t, filepath.Join(dir, s, "terraform.tfstate"), sc.TFState)
+		assert.Equal(t, "test", writerOptions(sc).Module)
+	}
+}
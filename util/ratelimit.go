@@ -0,0 +1,191 @@
+package util
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RateLimitAll is the key of the rate limits that
+// is used for the services that do not have one
+const RateLimitAll = "*"
+
+// RateLimiter limits the requests per second made to each
+// service with a token bucket for each one of them. The
+// bucket has the capacity of the requests of one second
+type RateLimiter struct {
+	limits map[string]float64
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewRateLimiter returns a RateLimiter with the limits, in requests per second,
+// set over the defaults ones. If the limits have the RateLimitAll the defaults
+// are not used. A limit of 0 means that the service is not limited
+func NewRateLimiter(defaults, limits map[string]float64) *RateLimiter {
+	ls := make(map[string]float64)
+	if _, ok := limits[RateLimitAll]; !ok {
+		for s, l := range defaults {
+			ls[s] = l
+		}
+	}
+	for s, l := range limits {
+		ls[s] = l
+	}
+
+	return &RateLimiter{
+		limits:  ls,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Wait blocks until a request can be made to the service
+// or the ctx is done, in which case its error is returned.
+// A nil RateLimiter does not limit any service
+func (rl *RateLimiter) Wait(ctx context.Context, service string) error {
+	if rl == nil {
+		return nil
+	}
+
+	b := rl.bucket(service)
+	if b == nil {
+		return nil
+	}
+
+	wait := b.reserve(time.Now())
+	if wait <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(wait)
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		t.Stop()
+		// The token is not used so it's given
+		// back for the next ones to not wait it
+		b.release()
+		return ctx.Err()
+	}
+}
+
+// bucket returns the bucket of the service,
+// nil if the service is not limited
+func (rl *RateLimiter) bucket(service string) *bucket {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if b, ok := rl.buckets[service]; ok {
+		return b
+	}
+
+	l, ok := rl.limits[service]
+	if !ok {
+		l = rl.limits[RateLimitAll]
+	}
+
+	var b *bucket
+	if l > 0 {
+		burst := math.Max(1, l)
+		b = &bucket{rate: l, burst: burst, tokens: burst}
+	}
+	rl.buckets[service] = b
+
+	return b
+}
+
+// bucket is a token bucket that is filled
+// with rate tokens per second up to the burst
+type bucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// reserve takes one token from the bucket and returns how long
+// to wait for it, which is 0 if the bucket had it
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.last.IsZero() {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+
+	// The tokens can be negative so the
+	// next ones wait after the previous ones
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// release gives back one token taken
+// with reserve that has not been used
+func (b *bucket) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// rateUnits are the units of the rate limits
+var rateUnits = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// ParseRateLimits parses the ls with the format 'service=rate[/unit]' (ex: iam=5/s)
+// to the requests per second of each service. The unit can be 's', 'm' or 'h',
+// by default 's', and the service can be RateLimitAll
+func ParseRateLimits(ls []string) (map[string]float64, error) {
+	limits := make(map[string]float64, len(ls))
+	for _, l := range ls {
+		service, rate := splitKeyValue(l, "=")
+		if service == "" || rate == "" {
+			return nil, errors.Errorf("invalid rate limit %q, the format is 'service=rate[/unit]'", l)
+		}
+
+		n, unit := splitKeyValue(rate, "/")
+		if unit == "" {
+			unit = "s"
+		}
+		d, ok := rateUnits[unit]
+		if !ok {
+			return nil, errors.Errorf("invalid rate limit %q, the unit has to be 's', 'm' or 'h'", l)
+		}
+
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil || f < 0 {
+			return nil, errors.Errorf("invalid rate limit %q, the rate has to be a positive number", l)
+		}
+
+		limits[service] = f / d.Seconds()
+	}
+
+	return limits, nil
+}
+
+// splitKeyValue splits the s by the first sep,
+// if it has no sep the value is empty
+func splitKeyValue(s, sep string) (string, string) {
+	kv := strings.SplitN(s, sep, 2)
+	if len(kv) == 1 {
+		return strings.TrimSpace(kv[0]), ""
+	}
+	return strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+}
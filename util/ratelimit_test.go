@@ -0,0 +1,105 @@
+package util_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/util"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		ls, err := util.ParseRateLimits([]string{"iam=5/s", "route53=120/m", "compute=3600/h", "dns=2", "*=0"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]float64{
+			"iam":     5,
+			"route53": 2,
+			"compute": 1,
+			"dns":     2,
+			"*":       0,
+		}, ls)
+	})
+	for _, l := range []string{"iam", "=5/s", "iam=", "iam=5/d", "iam=-1/s", "iam=five/s"} {
+		t.Run("Error_"+l, func(t *testing.T) {
+			_, err := util.ParseRateLimits([]string{l})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Nil", func(t *testing.T) {
+		var rl *util.RateLimiter
+		assert.NoError(t, rl.Wait(ctx, "iam"))
+	})
+	t.Run("NotLimited", func(t *testing.T) {
+		rl := util.NewRateLimiter(map[string]float64{"iam": 1}, nil)
+
+		start := time.Now()
+		for i := 0; i < 10; i++ {
+			require.NoError(t, rl.Wait(ctx, "ec2"))
+		}
+		assert.True(t, time.Since(start) < 100*time.Millisecond)
+	})
+	t.Run("Limited", func(t *testing.T) {
+		rl := util.NewRateLimiter(nil, map[string]float64{"iam": 20})
+
+		// The first 20 are the burst and the
+		// next 10 have to wait half a second
+		start := time.Now()
+		for i := 0; i < 30; i++ {
+			require.NoError(t, rl.Wait(ctx, "iam"))
+		}
+		assert.True(t, time.Since(start) >= 400*time.Millisecond, "waited %s", time.Since(start))
+	})
+	t.Run("LimitsOverDefaults", func(t *testing.T) {
+		rl := util.NewRateLimiter(map[string]float64{"iam": 0.1}, map[string]float64{"iam": 0})
+
+		start := time.Now()
+		for i := 0; i < 10; i++ {
+			require.NoError(t, rl.Wait(ctx, "iam"))
+		}
+		assert.True(t, time.Since(start) < 100*time.Millisecond)
+	})
+	t.Run("AllWithoutDefaults", func(t *testing.T) {
+		rl := util.NewRateLimiter(map[string]float64{"iam": 0.1}, map[string]float64{util.RateLimitAll: 1000})
+
+		start := time.Now()
+		for i := 0; i < 10; i++ {
+			require.NoError(t, rl.Wait(ctx, "iam"))
+		}
+		assert.True(t, time.Since(start) < 100*time.Millisecond)
+	})
+	t.Run("ErrorCanceled", func(t *testing.T) {
+		rl := util.NewRateLimiter(nil, map[string]float64{"iam": 0.001})
+		require.NoError(t, rl.Wait(ctx, "iam"))
+
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		assert.Equal(t, context.Canceled, rl.Wait(cctx, "iam"))
+	})
+	t.Run("CanceledReleasesToken", func(t *testing.T) {
+		rl := util.NewRateLimiter(nil, map[string]float64{"iam": 10})
+		for i := 0; i < 10; i++ {
+			require.NoError(t, rl.Wait(ctx, "iam"))
+		}
+
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		for i := 0; i < 5; i++ {
+			assert.Equal(t, context.Canceled, rl.Wait(cctx, "iam"))
+		}
+
+		// Only the one of this request is waited and
+		// not the ones of the canceled before it
+		start := time.Now()
+		require.NoError(t, rl.Wait(ctx, "iam"))
+		assert.True(t, time.Since(start) < 300*time.Millisecond, "waited %s", time.Since(start))
+	})
+}
@@ -1,53 +1,160 @@
 package util
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/cycloidio/terracognita/log"
 )
 
 const (
-	timesDefault    = 3
-	intervalDefault = 30 * time.Second
+	// MaxRetriesDefault is the default number of
+	// retries of the RetryPolicy
+	MaxRetriesDefault = 2
+
+	// RetryMaxWaitDefault is the default maximum wait
+	// between the retries of the RetryPolicy
+	RetryMaxWaitDefault = 30 * time.Second
+
+	// retryBaseWaitDefault is the default wait before the first
+	// retry, it's doubled on each one until the MaxWait
+	retryBaseWaitDefault = time.Second
 )
 
-// RetryFn it's a type to represent the function wrapped for the
-// Retry or RetryDefault methods
+// RetryFn it's a type to represent the function
+// wrapped by the Retry or RetryContext methods
 type RetryFn func() error
 
-// Retry calls rfn and checks the errors, if it matches the error
-// and if it does it tries 'times' withing the 'interval'
-func Retry(rfn RetryFn, times int, interval time.Duration) error {
-	err := rfn()
-	times--
-	if err != nil {
-		if times == 0 {
+// RetryClassifier returns if the err can be retried and, if
+// the err has it (ex: the Retry-After of an HTTP response), after
+// how long. If the after is 0 the backoff of the RetryPolicy is used
+type RetryClassifier func(err error) (retry bool, after time.Duration)
+
+// RetryPolicy defines when and how many times an operation is retried
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the
+	// first try, if it's 0 it's not retried
+	MaxRetries int
+
+	// BaseWait is the wait before the first retry, it's doubled on
+	// each one (exponential backoff) and a random jitter of up to half
+	// of it is removed so the retries of different calls are spread.
+	// If it's 0 the default is used
+	BaseWait time.Duration
+
+	// MaxWait is the maximum wait between retries, also for the
+	// ones of the Classifier. If it's 0 there is no maximum
+	MaxWait time.Duration
+
+	// Classifier checks which errors are retried,
+	// if it's nil none of them are
+	Classifier RetryClassifier
+}
+
+// NewRetryPolicy returns a RetryPolicy with the default
+// BaseWait and the maxRetries, maxWait and classifier
+func NewRetryPolicy(maxRetries int, maxWait time.Duration, classifier RetryClassifier) RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: maxRetries,
+		BaseWait:   retryBaseWaitDefault,
+		MaxWait:    maxWait,
+		Classifier: classifier,
+	}
+}
+
+// Retry calls rfn and, while the errors it returns are retryable
+// by the p Classifier, calls it again up to the p MaxRetries
+func Retry(rfn RetryFn, p RetryPolicy) error {
+	return RetryContext(context.Background(), rfn, p)
+}
+
+// RetryContext is like Retry but it stops waiting for the next
+// try when the ctx is done, returning the ctx error
+func RetryContext(ctx context.Context, rfn RetryFn, p RetryPolicy) error {
+	for retry := 0; ; retry++ {
+		err := rfn()
+		if err == nil || retry >= p.MaxRetries || p.Classifier == nil {
 			return err
 		}
-		// If the error is from the stdlib we just continue with them
-		// This is a fix because 'request.IsErrorRetryable(err)' will always
-		// retry normal errors "just in case" and we do not want to retry errors
-		// that we return
-		// *errors.errorString is the standar lib
-		// *errors.fundamental is the github.com/pkg/errors
-		// This way if it's an std error or one from us we skip the Retry
-		errtype := fmt.Sprintf("%T", err)
-		if errtype == "*errors.errorString" || errtype == "*errors.fundamental" || errtype == "*errors.withStack" || errtype == "*errors.withMessage" {
+
+		ok, after := p.Classifier(err)
+		if !ok {
 			return err
 		}
-		if request.IsErrorRetryable(err) || request.IsErrorThrottle(err) || request.IsErrorExpiredCreds(err) {
-			log.Get().Log("func", "utils.Retry", "msg", "waiting for Throttling error", "err", fmt.Sprintf("%+v", err), "times-left", times)
-			time.Sleep(interval)
-			return Retry(rfn, times, interval)
+
+		wait := p.Wait(retry, after)
+		log.Get().Log("func", "utils.Retry", "msg", "waiting to retry", "err", fmt.Sprintf("%+v", err), "wait", wait, "retries-left", p.MaxRetries-retry)
+
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Wait returns how long to wait before the retry number retry (starting
+// from 0). If after is not 0 it's used instead of the backoff, always
+// limited by the MaxWait
+func (p RetryPolicy) Wait(retry int, after time.Duration) time.Duration {
+	wait := after
+	if wait == 0 {
+		base := p.BaseWait
+		if base == 0 {
+			base = retryBaseWaitDefault
+		}
+
+		wait = base
+		for i := 0; i < retry && (p.MaxWait == 0 || wait < p.MaxWait); i++ {
+			wait *= 2
+		}
+		if p.MaxWait != 0 && wait > p.MaxWait {
+			wait = p.MaxWait
 		}
+
+		// The jitter is of up to half of the wait
+		if half := int64(wait / 2); half > 0 {
+			wait -= time.Duration(rand.Int63n(half + 1))
+		}
+	}
+
+	if p.MaxWait != 0 && wait > p.MaxWait {
+		wait = p.MaxWait
 	}
 
-	return err
+	return wait
 }
 
-// RetryDefault calls Retry with the default parameters
-func RetryDefault(rfn RetryFn) error {
-	return Retry(rfn, timesDefault, intervalDefault)
+// IsRetryableHTTPStatus checks if the HTTP status code is of an error
+// that can be retried: throttling (429) or temporary of the server (5XX)
+func IsRetryableHTTPStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code == http.StatusInternalServerError ||
+		code == http.StatusBadGateway || code == http.StatusServiceUnavailable || code == http.StatusGatewayTimeout
+}
+
+// RetryAfter returns the wait of the Retry-After header of the h, which can
+// be in seconds or an HTTP date. It's 0 if it's not set or invalid
+func RetryAfter(h http.Header) time.Duration {
+	ra := h.Get("Retry-After")
+	if ra == "" {
+		return 0
+	}
+
+	if s, err := strconv.Atoi(ra); err == nil && s > 0 {
+		return time.Duration(s) * time.Second
+	}
+
+	if t, err := http.ParseTime(ra); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
 }
@@ -1,23 +1,29 @@
 package util_test
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/pkg/errors"
 
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/cycloidio/terracognita/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-const (
-	throttlingErr = "Throttling"
-)
+var errThrottling = errors.New("Throttling")
+
+// throttling only retries the errThrottling
+func throttling(err error) (bool, time.Duration) {
+	return errors.Is(err, errThrottling), 0
+}
 
 func TestRetry(t *testing.T) {
+	policy := util.RetryPolicy{MaxRetries: 2, BaseWait: time.Nanosecond, Classifier: throttling}
+
 	t.Run("Success", func(t *testing.T) {
 		var count int
 		fn := func() error {
@@ -25,7 +31,7 @@ func TestRetry(t *testing.T) {
 			return nil
 		}
 
-		err := util.Retry(fn, 3, 0*time.Second)
+		err := util.Retry(fn, policy)
 		require.NoError(t, err)
 		assert.Equal(t, 1, count)
 	})
@@ -34,12 +40,12 @@ func TestRetry(t *testing.T) {
 		fn := func() error {
 			count++
 			if count == 1 {
-				return awserr.New(throttlingErr, "message", nil)
+				return errThrottling
 			}
 			return nil
 		}
 
-		err := util.Retry(fn, 3, 0*time.Second)
+		err := util.Retry(fn, policy)
 		require.NoError(t, err)
 		assert.Equal(t, 2, count)
 	})
@@ -47,34 +53,100 @@ func TestRetry(t *testing.T) {
 		var count int
 		fn := func() error {
 			count++
-			return awserr.New(throttlingErr, "message", nil)
+			return errThrottling
 		}
 
-		err := util.Retry(fn, 3, 0*time.Second)
-		require.Equal(t, err, awserr.New(throttlingErr, "message", nil))
+		err := util.Retry(fn, policy)
+		require.Equal(t, errThrottling, err)
 		assert.Equal(t, 3, count)
 	})
-	t.Run("NoRetrySTD", func(t *testing.T) {
+	t.Run("NoRetryNotClassified", func(t *testing.T) {
 		var count int
 		fn := func() error {
 			count++
 			return fmt.Errorf("some std error")
 		}
 
-		err := util.Retry(fn, 3, 0*time.Second)
-		require.Equal(t, err, fmt.Errorf("some std error"))
+		err := util.Retry(fn, policy)
+		require.Equal(t, fmt.Errorf("some std error"), err)
+		assert.Equal(t, 1, count)
+	})
+	t.Run("NoRetryWithoutClassifier", func(t *testing.T) {
+		var count int
+		fn := func() error {
+			count++
+			return errThrottling
+		}
+
+		err := util.Retry(fn, util.RetryPolicy{MaxRetries: 2})
+		require.Equal(t, errThrottling, err)
 		assert.Equal(t, 1, count)
 	})
-	t.Run("NoRetry|pk/errors", func(t *testing.T) {
+	t.Run("NoRetryWithoutMaxRetries", func(t *testing.T) {
 		var count int
-		eerr := errors.New("some custom error")
 		fn := func() error {
 			count++
-			return eerr
+			return errThrottling
 		}
 
-		err := util.Retry(fn, 3, 0*time.Second)
-		require.Equal(t, eerr, err)
+		err := util.Retry(fn, util.RetryPolicy{Classifier: throttling})
+		require.Equal(t, errThrottling, err)
 		assert.Equal(t, 1, count)
 	})
 }
+
+func TestRetryContext(t *testing.T) {
+	t.Run("ErrorCanceled", func(t *testing.T) {
+		var count int
+		ctx, cancel := context.WithCancel(context.Background())
+		fn := func() error {
+			count++
+			cancel()
+			return errThrottling
+		}
+
+		err := util.RetryContext(ctx, fn, util.RetryPolicy{MaxRetries: 2, BaseWait: time.Hour, Classifier: throttling})
+		require.Equal(t, context.Canceled, err)
+		assert.Equal(t, 1, count)
+	})
+}
+
+func TestRetryPolicyWait(t *testing.T) {
+	p := util.RetryPolicy{BaseWait: time.Second, MaxWait: 5 * time.Second}
+
+	t.Run("Backoff", func(t *testing.T) {
+		for retry, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+			w := p.Wait(retry, 0)
+			assert.True(t, w <= max && w >= max/2, "retry %d waits %s", retry, w)
+		}
+	})
+	t.Run("After", func(t *testing.T) {
+		assert.Equal(t, 3*time.Second, p.Wait(0, 3*time.Second))
+	})
+	t.Run("AfterMaxWait", func(t *testing.T) {
+		assert.Equal(t, 5*time.Second, p.Wait(0, time.Minute))
+	})
+}
+
+func TestIsRetryableHTTPStatus(t *testing.T) {
+	assert.True(t, util.IsRetryableHTTPStatus(http.StatusTooManyRequests))
+	assert.True(t, util.IsRetryableHTTPStatus(http.StatusServiceUnavailable))
+	assert.False(t, util.IsRetryableHTTPStatus(http.StatusNotFound))
+	assert.False(t, util.IsRetryableHTTPStatus(http.StatusForbidden))
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Run("Seconds", func(t *testing.T) {
+		assert.Equal(t, 5*time.Second, util.RetryAfter(http.Header{"Retry-After": []string{"5"}}))
+	})
+	t.Run("Date", func(t *testing.T) {
+		d := util.RetryAfter(http.Header{"Retry-After": []string{time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)}})
+		assert.True(t, d > 50*time.Second && d <= time.Minute, "waits %s", d)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), util.RetryAfter(http.Header{}))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), util.RetryAfter(http.Header{"Retry-After": []string{"soon"}}))
+	})
+}
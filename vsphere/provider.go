@@ -2,8 +2,9 @@ package vsphere
 
 import (
 	"context"
-
 	"fmt"
+	"net"
+	"time"
 
 	"github.com/cycloidio/terracognita/cache"
 	"github.com/cycloidio/terracognita/filter"
@@ -14,6 +15,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	tfvsphere "github.com/hashicorp/terraform-provider-vsphere/vsphere"
 	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 // version of the Terraform provider, this is automatically changed with the 'make update-terraform-provider'
@@ -22,6 +25,7 @@ const version = "2.2.0"
 type vsphere struct {
 	tfVSphereClient interface{}
 	tfProvider      *schema.Provider
+	grpcClient      *provider.GRPCClient
 
 	configuration map[string]interface{}
 
@@ -73,6 +77,7 @@ func NewProvider(ctx context.Context, soapURL, user, password, vsphereserver str
 	return &vsphere{
 		tfVSphereClient: client,
 		tfProvider:      tfp,
+		grpcClient:      provider.NewGRPCClient(tfp),
 		cache:           cache.New(),
 		reader:          r,
 	}, nil
@@ -82,6 +87,8 @@ func (vs vsphere) TFClient() interface{} { return vs.tfVSphereClient }
 
 func (vs vsphere) TFProvider() *schema.Provider { return vs.tfProvider }
 
+func (vs vsphere) GRPCClient() *provider.GRPCClient { return vs.grpcClient }
+
 func (vs vsphere) String() string { return "vsphere" }
 
 func (vs vsphere) Region() string { return "" }
@@ -122,3 +129,34 @@ func (vs vsphere) Version() string { return version }
 func (vs vsphere) Configuration() map[string]interface{}                { return vs.configuration }
 func (vs vsphere) FixResource(t string, v cty.Value) (cty.Value, error) { return v, nil }
 func (vs vsphere) FilterByTags(tags interface{}) error                  { return nil }
+
+// Retryable retries the vSphere faults that are temporary
+// of the server and the network timeouts
+func (vs vsphere) Retryable(err error) (bool, time.Duration) {
+	var nerr net.Error
+	if errors.As(err, &nerr) && nerr.Timeout() {
+		return true, 0
+	}
+
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		var fault interface{}
+		if soap.IsSoapFault(e) {
+			fault = soap.ToSoapFault(e).VimFault()
+		} else if soap.IsVimFault(e) {
+			fault = soap.ToVimFault(e)
+		} else {
+			continue
+		}
+
+		switch fault.(type) {
+		case types.SystemError, *types.SystemError,
+			types.HostCommunication, *types.HostCommunication,
+			types.TaskInProgress, *types.TaskInProgress,
+			types.RequestCanceled, *types.RequestCanceled:
+			return true, 0
+		}
+		return false, 0
+	}
+
+	return false, 0
+}